@@ -21,6 +21,9 @@ package agents
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -52,6 +55,7 @@ const (
 	SMASessionStart          = "SMA_SESSION_START"
 	SMASessionTerminate      = "SMA_SESSION_TERMINATE"
 	ARICGRResourceAllocation = "CGRResourceAllocation"
+	ARIAbsoluteTimeout       = "TIMEOUT(absolute)"
 )
 
 // NewAsteriskAgent constructs a new Asterisk Agent
@@ -455,9 +459,32 @@ func (sma *AsteriskAgent) V1GetActiveSessionIDs(ctx *context.Context, ignParam s
 
 }
 
-// V1AlterSession is used to implement the sessions.BiRPClient interface
-func (*AsteriskAgent) V1AlterSession(*context.Context, utils.CGREvent, *string) error {
-	return utils.ErrNotImplemented
+// V1AlterSession updates the variables and the absolute timeout of an active channel in Asterisk
+func (sma *AsteriskAgent) V1AlterSession(ctx *context.Context, cgrEv utils.CGREvent, reply *string) error {
+	channelID := engine.NewMapEvent(cgrEv.Event).GetStringIgnoreErrors(utils.OriginID)
+	chanVars, maxUsage, err := alterSessionArgs(cgrEv)
+	if err != nil {
+		return err
+	}
+	if maxUsage != nil {
+		if chanVars == nil {
+			chanVars = make(map[string]string)
+		}
+		// TIMEOUT(absolute) is counted from the moment it is set
+		chanVars[ARIAbsoluteTimeout] = strconv.Itoa(int(math.Ceil(maxUsage.Seconds())))
+	}
+	for _, varName := range slices.Sorted(maps.Keys(chanVars)) {
+		if _, err = sma.astConn.Call(aringo.HTTP_POST,
+			fmt.Sprintf("channels/%s/variable", channelID),
+			map[string]string{"variable": varName, "value": chanVars[varName]}, nil); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> setting <%s> for channelID: <%s>",
+					utils.AsteriskAgent, err.Error(), varName, channelID))
+			return err
+		}
+	}
+	*reply = utils.OK
+	return nil
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
@@ -465,7 +492,22 @@ func (*AsteriskAgent) V1DisconnectPeer(*context.Context, *utils.DPRArgs, *string
 	return utils.ErrNotImplemented
 }
 
-// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
-func (sma *AsteriskAgent) V1WarnDisconnect(*context.Context, map[string]any, *string) error {
-	return utils.ErrNotImplemented
+// V1WarnDisconnect is called when call goes under the minimum duration threshold, so Asterisk can play an announcement message
+func (sma *AsteriskAgent) V1WarnDisconnect(ctx *context.Context, args map[string]any, reply *string) error {
+	annFile := sma.cgrCfg.AsteriskAgentCfg().LowBalanceAnnFile
+	if annFile == utils.EmptyString {
+		*reply = utils.OK
+		return nil
+	}
+	channelID := engine.NewMapEvent(args).GetStringIgnoreErrors(utils.OriginID)
+	if _, err := sma.astConn.Call(aringo.HTTP_POST,
+		fmt.Sprintf("channels/%s/play", channelID),
+		map[string]string{"media": annFile}, nil); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: <%s> playing <%s> for channelID: <%s>",
+				utils.AsteriskAgent, err.Error(), annFile, channelID))
+		return err
+	}
+	*reply = utils.OK
+	return nil
 }
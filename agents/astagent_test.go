@@ -21,9 +21,11 @@ import (
 	"bytes"
 	"log"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/cgrates/aringo"
 	"github.com/cgrates/birpc"
 	"github.com/cgrates/birpc/context"
 	"github.com/cgrates/cgrates/config"
@@ -96,13 +98,40 @@ func TestHandleChannelDestroyedFail(t *testing.T) {
 	}
 }
 
+type testMockARIConn struct {
+	calls []string
+	err   error
+}
+
+func (c *testMockARIConn) Call(method, uri string, queryStr, _ map[string]string) (aringo.RESTResponse, error) {
+	c.calls = append(c.calls, method+" "+uri+" "+utils.ToJSON(queryStr))
+	return aringo.RESTResponse{}, c.err
+}
+
 func TestAstAgentV1WarnDisconnect(t *testing.T) {
-	tAsteriskAgent := &AsteriskAgent{}
-	tMap := map[string]any{}
+	astConn := new(testMockARIConn)
+	tAsteriskAgent := &AsteriskAgent{cgrCfg: config.NewDefaultCGRConfig(), astConn: astConn}
+	tMap := map[string]any{utils.OriginID: "1714719185.3"}
 	tString := ""
-	err := tAsteriskAgent.V1WarnDisconnect(nil, tMap, &tString)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected error: %v, got: %v", utils.ErrNotImplemented, err)
+	if err := tAsteriskAgent.V1WarnDisconnect(nil, tMap, &tString); err != nil {
+		t.Error(err)
+	} else if tString != utils.OK {
+		t.Errorf("Expected %q, got %q", utils.OK, tString)
+	}
+	if len(astConn.calls) != 0 {
+		t.Errorf("Expected no ARI calls, got %q", astConn.calls)
+	}
+	tAsteriskAgent.cgrCfg.AsteriskAgentCfg().LowBalanceAnnFile = "sound:low-balance"
+	if err := tAsteriskAgent.V1WarnDisconnect(nil, tMap, &tString); err != nil {
+		t.Error(err)
+	}
+	exp := []string{`POST channels/1714719185.3/play {"media":"sound:low-balance"}`}
+	if !reflect.DeepEqual(astConn.calls, exp) {
+		t.Errorf("Expected %q, got %q", exp, astConn.calls)
+	}
+	astConn.err = utils.ErrServerError
+	if err := tAsteriskAgent.V1WarnDisconnect(nil, tMap, &tString); err != utils.ErrServerError {
+		t.Errorf("Expected error: %v, got: %v", utils.ErrServerError, err)
 	}
 }
 
@@ -117,12 +146,32 @@ func TestAsteriskAgentV1DisconnectPeer(t *testing.T) {
 }
 
 func TestAsteriskAgentV1AlterSession(t *testing.T) {
-	tAsteriskAgent := &AsteriskAgent{}
-	tCGREvent := utils.CGREvent{}
+	astConn := new(testMockARIConn)
+	tAsteriskAgent := &AsteriskAgent{astConn: astConn}
+	tCGREvent := utils.CGREvent{
+		Event: map[string]any{utils.OriginID: "1714719185.3"},
+	}
 	tString := ""
-	err := tAsteriskAgent.V1AlterSession(nil, tCGREvent, &tString)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected error: %v, got: %v", utils.ErrNotImplemented, err)
+	if err := tAsteriskAgent.V1AlterSession(nil, tCGREvent, &tString); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.MetaChannelVars, utils.MetaMaxUsage).Error() {
+		t.Errorf("Expected error: %v, got: %v",
+			utils.NewErrMandatoryIeMissing(utils.MetaChannelVars, utils.MetaMaxUsage), err)
+	}
+	tCGREvent.APIOpts = map[string]any{
+		utils.MetaChannelVars: map[string]any{"CGRSubject": "1001"},
+		utils.MetaMaxUsage:    "90500ms",
+	}
+	if err := tAsteriskAgent.V1AlterSession(nil, tCGREvent, &tString); err != nil {
+		t.Error(err)
+	} else if tString != utils.OK {
+		t.Errorf("Expected %q, got %q", utils.OK, tString)
+	}
+	exp := []string{
+		`POST channels/1714719185.3/variable {"value":"1001","variable":"CGRSubject"}`,
+		`POST channels/1714719185.3/variable {"value":"91","variable":"TIMEOUT(absolute)"}`,
+	}
+	if !reflect.DeepEqual(astConn.calls, exp) {
+		t.Errorf("Expected %q, got %q", exp, astConn.calls)
 	}
 }
 
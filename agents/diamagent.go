@@ -21,9 +21,11 @@ package agents
 import (
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cgrates/birpc"
@@ -61,7 +63,12 @@ func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		raa:     make(map[string]chan *diam.Message),
 		dpa:     make(map[string]chan *diam.Message),
 		peers:   make(map[string]diam.Conn),
+
+		relayed:    make(map[uint32]*diamRelayedReq),
+		relayConns: make(map[string]diam.Conn),
+		relayStats: make(map[string]*diamRelayStats),
 	}
+	da.relayHbH.Store(rand.Uint32())
 	srv, err := birpc.NewServiceWithMethodsRename(da, utils.AgentV1, true, func(oldFn string) (newFn string) {
 		return strings.TrimPrefix(oldFn, "V1")
 	})
@@ -109,6 +116,12 @@ type DiameterAgent struct {
 	dpaLck   sync.RWMutex
 	dpa      map[string]chan *diam.Message
 
+	relayLck   sync.Mutex
+	relayed    map[uint32]*diamRelayedReq // relayed requests indexed by the upstream hop-by-hop id
+	relayHbH   atomic.Uint32
+	relayConns map[string]diam.Conn       // upstream connections indexed by the configured peer address
+	relayStats map[string]*diamRelayStats // relay counters indexed by the connection remote address
+
 	ctx *context.Context
 }
 
@@ -144,6 +157,11 @@ func (da *DiameterAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 
 	go da.handleConns(dSM.HandshakeNotify())
 
+	relayPeers, relayApps := da.relayPeers()
+	for _, peer := range relayPeers {
+		go da.connectRelayPeer(peer, relayApps[peer.Address], stopChan)
+	}
+
 	go func() {
 		errCh := dSM.ErrorReports()
 		for {
@@ -236,6 +254,10 @@ func (da *DiameterAgent) handlers() *sm.StateMachine {
 
 // handleALL is the handler of all messages coming in via Diameter
 func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 &&
+		da.relayAnswer(c, m) {
+		return
+	}
 	dApp, err := m.Dictionary().App(m.Header.ApplicationID)
 	if err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> decoding app: %d, err: %s",
@@ -298,19 +320,32 @@ func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
 	rply := utils.NewOrderedNavigableMap() // share it among different processors
 	var processed bool
 	for _, reqProcessor := range da.cgrCfg.DiameterAgentCfg().RequestProcessors {
+		agReq := NewAgentRequest(
+			diamDP, reqVars, cgrRplyNM, rply,
+			opts, reqProcessor.Tenant,
+			da.cgrCfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(
+				reqProcessor.Timezone,
+				da.cgrCfg.GeneralCfg().DefaultTimezone,
+			),
+			da.filterS, nil)
+		if reqProcessor.Flags.Has(utils.MetaRelay) {
+			var pass bool
+			if pass, err = da.filterS.Pass(agReq.Tenant,
+				reqProcessor.Filters, agReq); err != nil {
+				break
+			}
+			if pass && m.Header.CommandFlags&diam.ProxiableFlag != 0 { // non-proxiable requests are only answered locally
+				da.relayRequest(c, m, reqVars)
+				return
+			}
+			continue
+		}
 		var lclProcessed bool
 		lclProcessed, err = processRequest(
 			da.ctx,
 			reqProcessor,
-			NewAgentRequest(
-				diamDP, reqVars, cgrRplyNM, rply,
-				opts, reqProcessor.Tenant,
-				da.cgrCfg.GeneralCfg().DefaultTenant,
-				utils.FirstNonEmpty(
-					reqProcessor.Timezone,
-					da.cgrCfg.GeneralCfg().DefaultTimezone,
-				),
-				da.filterS, nil),
+			agReq,
 			utils.DiameterAgent, da.connMgr,
 			da.cgrCfg.DiameterAgentCfg().SessionSConns,
 			da.cgrCfg.DiameterAgentCfg().StatSConns,
@@ -485,6 +520,9 @@ func (da *DiameterAgent) V1AlterSession(ctx *context.Context, cgrEv utils.CGREve
 
 // handleRAA is used to handle all Re-Authorize Answers that are received
 func (da *DiameterAgent) handleRAA(c diam.Conn, m *diam.Message) {
+	if da.relayAnswer(c, m) {
+		return
+	}
 	avp, err := m.FindAVP(avp.SessionID, dict.UndefinedVendorID)
 	if err != nil {
 		return
@@ -525,6 +563,11 @@ func (da *DiameterAgent) sendConnStatusReport(metadata *smpeer.Metadata, status,
 			utils.MetaEventType: utils.EventConnectionStatusReport,
 		},
 	}
+	if st := da.relayStatsFor(remoteAddr); st != nil {
+		ev.Event[utils.ConnRelayedRequests] = st.requests.Load()
+		ev.Event[utils.ConnRelayedAnswers] = st.answers.Load()
+		ev.Event[utils.ConnRelayTimeouts] = st.timeouts.Load()
+	}
 
 	if len(daCfg.StatSConns) != 0 {
 		ev.APIOpts[utils.OptsStatsProfileIDs] = daCfg.ConnStatusStatQueueIDs
@@ -561,6 +604,11 @@ func (da *DiameterAgent) handleConns(peers <-chan diam.Conn) {
 		da.peersLck.Lock()
 		da.peers[remoteAddr] = c
 		da.peersLck.Unlock()
+		if len(da.cgrCfg.DiameterAgentCfg().RealmRoutes) != 0 {
+			da.relayLck.Lock()
+			da.relayStats[remoteAddr] = new(diamRelayStats)
+			da.relayLck.Unlock()
+		}
 		connStatus := utils.ConnStatusUp
 		da.sendConnStatusReport(meta, connStatus, localAddr, remoteAddr)
 		go func() {
@@ -573,6 +621,9 @@ func (da *DiameterAgent) handleConns(peers <-chan diam.Conn) {
 				delete(da.peers, remoteAddr)
 				da.peersLck.Unlock()
 				da.sendConnStatusReport(meta, utils.ConnStatusDown, localAddr, remoteAddr)
+				da.relayLck.Lock()
+				delete(da.relayStats, remoteAddr)
+				da.relayLck.Unlock()
 			}()
 
 			closeChan := c.(diam.CloseNotifier).CloseNotify()
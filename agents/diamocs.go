@@ -0,0 +1,527 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/go-diameter/diam"
+	"github.com/cgrates/go-diameter/diam/avp"
+	"github.com/cgrates/go-diameter/diam/datatype"
+	"github.com/cgrates/go-diameter/diam/dict"
+	"github.com/cgrates/go-diameter/diam/sm"
+	"github.com/cgrates/go-diameter/diam/sm/smpeer"
+	"github.com/cgrates/rpcclient"
+)
+
+// CC-Request-Type values (RFC 4006 8.3)
+const (
+	ccrInitial   = 1
+	ccrUpdate    = 2
+	ccrTerminate = 3
+	ccrEvent     = 4
+)
+
+const (
+	diamCCApplicationID = 4 // Diameter Credit-Control Application
+	diamCheckBalance    = 2 // Requested-Action CHECK_BALANCE
+	diamNoCredit        = 1 // Check-Balance-Result NO_CREDIT
+
+	diamUnableToDeliver   = 3002
+	diamTooBusy           = 3004
+	diamCreditLimitReachd = 4012
+	diamUserUnknown       = 5030
+
+	// variables available in the ccr_template and cca_template
+	diamOCSSessionID        = "SessionId"
+	diamOCSOriginHost       = "OriginHost"
+	diamOCSOriginRealm      = "OriginRealm"
+	diamOCSDestinationRealm = "DestinationRealm"
+	diamOCSCCRequestType    = "CCRequestType"
+	diamOCSCCRequestNumber  = "CCRequestNumber"
+	diamOCSRequestedAction  = "RequestedAction"
+	diamOCSRequestedUsage   = "RequestedUsage"
+	diamOCSUsedUsage        = "UsedUsage"
+)
+
+func init() {
+	engine.RegisterRPCTransport(utils.MetaDiameter, newDiamOCSConn)
+}
+
+// diamOCSSessions holds the credit-control sessions opened by the *diameter connections,
+// shared by the peers of one connection pool so the session survives the failover
+var diamOCSSessions = struct {
+	sync.Mutex
+	s map[string]*diamOCSSession
+}{s: make(map[string]*diamOCSSession)}
+
+var diamOCSSessionCounter atomic.Uint32
+
+// diamOCSSession is one credit-control session towards the OCS
+type diamOCSSession struct {
+	sync.Mutex
+	key     string
+	id      string // Session-Id
+	reqNr   int    // CC-Request-Number of the last answered CCR
+	usage   time.Duration
+	pending *diamOCSPending
+	conn    *diamOCSConn    // peer which answered last, used on Tcc expiry
+	ev      *utils.CGREvent // last event, used on Tcc expiry
+	tcc     *time.Timer
+	ended   bool
+}
+
+// diamOCSPending is the CCR which was not answered yet, retransmitted
+// with the T flag when the pool fails over to the next peer
+type diamOCSPending struct {
+	ev         *utils.CGREvent
+	reqNr      int
+	endToEndID uint32
+}
+
+// newDiamOCSConn constructs the *diameter connection towards one OCS peer,
+// connecting on the first request
+func newDiamOCSConn(rmtHost *config.RemoteHost, keyPath, certPath, poolID string,
+	connectTimeout time.Duration, fltrS *engine.FilterS) (birpc.ClientConnector, error) {
+	cgrCfg := config.CgrConfig()
+	if dictsPath := cgrCfg.DiameterAgentCfg().DictionariesPath; len(dictsPath) != 0 {
+		var err error
+		diamDictOnce.Do(func() {
+			err = loadDictionaries(dictsPath, utils.DiameterAgent)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &diamOCSConn{
+		cgrCfg:         cgrCfg,
+		fltrS:          fltrS,
+		address:        rmtHost.Address,
+		tls:            rmtHost.TLS,
+		keyPath:        keyPath,
+		certPath:       certPath,
+		poolID:         poolID,
+		connectTimeout: connectTimeout,
+		ccas:           make(map[uint32]chan *diam.Message),
+	}, nil
+}
+
+// diamOCSConn acts as CTF towards an OCS peer over Gy/Ro (RFC 4006),
+// translating the SessionSv1 requests into CCRs and the CCAs into SessionS replies
+type diamOCSConn struct {
+	cgrCfg         *config.CGRConfig
+	fltrS          *engine.FilterS
+	address        string
+	tls            bool
+	keyPath        string
+	certPath       string
+	poolID         string
+	connectTimeout time.Duration
+
+	connMux sync.Mutex
+	conn    diam.Conn
+
+	ccasMux sync.Mutex
+	ccas    map[uint32]chan *diam.Message // indexed on Hop-by-Hop Identifier
+}
+
+// Call implements birpc.ClientConnector
+func (oc *diamOCSConn) Call(_ *context.Context, serviceMethod string, args, reply any) (err error) {
+	switch serviceMethod {
+	case utils.SessionSv1AuthorizeEvent:
+		aArgs, canCastArgs := args.(*sessions.V1AuthorizeArgs)
+		rply, canCastRply := reply.(*sessions.V1AuthorizeReply)
+		if !canCastArgs || !canCastRply || aArgs.CGREvent == nil {
+			return utils.ErrCastFailed
+		}
+		rply.MaxUsage, err = oc.chargeSession(aArgs.CGREvent, ccrEvent)
+	case utils.SessionSv1InitiateSession:
+		iArgs, canCastArgs := args.(*sessions.V1InitSessionArgs)
+		rply, canCastRply := reply.(*sessions.V1InitSessionReply)
+		if !canCastArgs || !canCastRply || iArgs.CGREvent == nil {
+			return utils.ErrCastFailed
+		}
+		rply.MaxUsage, err = oc.chargeSession(iArgs.CGREvent, ccrInitial)
+	case utils.SessionSv1UpdateSession:
+		uArgs, canCastArgs := args.(*sessions.V1UpdateSessionArgs)
+		rply, canCastRply := reply.(*sessions.V1UpdateSessionReply)
+		if !canCastArgs || !canCastRply || uArgs.CGREvent == nil {
+			return utils.ErrCastFailed
+		}
+		rply.MaxUsage, err = oc.chargeSession(uArgs.CGREvent, ccrUpdate)
+	case utils.SessionSv1TerminateSession:
+		tArgs, canCastArgs := args.(*sessions.V1TerminateSessionArgs)
+		rply, canCastRply := reply.(*string)
+		if !canCastArgs || !canCastRply || tArgs.CGREvent == nil {
+			return utils.ErrCastFailed
+		}
+		if _, err = oc.chargeSession(tArgs.CGREvent, ccrTerminate); err == nil {
+			*rply = utils.OK
+		}
+	default:
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	return
+}
+
+// session returns the credit-control session of the event together with
+// the CC-Request-Number of the CCR and whether the CCR is a retransmission
+func (oc *diamOCSConn) session(ev *utils.CGREvent, originID string, reqType int) (s *diamOCSSession,
+	reqNr int, retrans bool, err error) {
+	if reqType == ccrEvent { // one time request, no session to keep
+		s = &diamOCSSession{id: oc.newSessionID(originID)}
+		s.Lock()
+		return
+	}
+	key := utils.ConcatenatedKey(oc.poolID, originID)
+	diamOCSSessions.Lock()
+	s, has := diamOCSSessions.s[key]
+	if !has {
+		if reqType != ccrInitial {
+			diamOCSSessions.Unlock()
+			return nil, 0, false, utils.ErrNoActiveSession
+		}
+		s = &diamOCSSession{key: key, id: oc.newSessionID(originID)}
+		diamOCSSessions.s[key] = s
+	}
+	diamOCSSessions.Unlock()
+	s.Lock()
+	switch {
+	case s.ended:
+		err = utils.ErrNoActiveSession
+	case s.pending != nil &&
+		(s.pending.ev == ev || (reqType == ccrInitial && s.pending.reqNr == 0)):
+		// failover of the CCR not answered by the previous peer
+		reqNr, retrans = s.pending.reqNr, true
+	case reqType == ccrInitial && has:
+		err = utils.ErrExists
+	case reqType != ccrInitial:
+		reqNr = s.reqNr + 1
+	}
+	if err != nil {
+		s.Unlock()
+		return nil, 0, false, err
+	}
+	return
+}
+
+// newSessionID builds the Session-Id as recommended by RFC 6733 8.8
+func (oc *diamOCSConn) newSessionID(originID string) string {
+	return fmt.Sprintf("%s;%d;%d;%s", oc.cgrCfg.DiameterAgentCfg().OriginHost,
+		time.Now().Unix(), diamOCSSessionCounter.Add(1), originID)
+}
+
+// endSession removes the session from the ones tracked
+func (s *diamOCSSession) endSession() {
+	s.ended = true
+	if s.tcc != nil {
+		s.tcc.Stop()
+	}
+	diamOCSSessions.Lock()
+	if diamOCSSessions.s[s.key] == s {
+		delete(diamOCSSessions.s, s.key)
+	}
+	diamOCSSessions.Unlock()
+}
+
+// expire terminates the session not updated within Tcc
+func (s *diamOCSSession) expire() {
+	s.Lock()
+	oc, ev := s.conn, s.ev.Clone()
+	s.Unlock()
+	delete(ev.Event, utils.Usage)
+	delete(ev.Event, utils.LastUsed)
+	if _, err := oc.chargeSession(ev, ccrTerminate); err != nil &&
+		err != utils.ErrNoActiveSession {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed terminating the session with Session-Id: <%s> on Tcc expiry, err: %s",
+				utils.DiameterAgent, s.id, err.Error()))
+	}
+}
+
+// chargeSession sends the CCR of reqType for the event, returning the usage granted by the OCS
+func (oc *diamOCSConn) chargeSession(ev *utils.CGREvent, reqType int) (maxUsage *time.Duration, err error) {
+	originID, err := ev.FieldAsString(utils.OriginID)
+	if err != nil {
+		return nil, utils.NewErrMandatoryIeMissing(utils.OriginID)
+	}
+	conn, err := oc.connect()
+	if err != nil {
+		return
+	}
+	s, reqNr, retrans, err := oc.session(ev, originID, reqType)
+	if err != nil {
+		return
+	}
+	defer s.Unlock()
+	ocCfg := oc.cgrCfg.DiameterAgentCfg().OCSClient
+	vars := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	vars.Set([]string{diamOCSSessionID}, s.id)
+	vars.Set([]string{diamOCSOriginHost}, oc.cgrCfg.DiameterAgentCfg().OriginHost)
+	vars.Set([]string{diamOCSOriginRealm}, oc.cgrCfg.DiameterAgentCfg().OriginRealm)
+	if meta, has := smpeer.FromContext(conn.Context()); has {
+		vars.Set([]string{diamOCSDestinationRealm}, string(meta.OriginRealm))
+	}
+	vars.Set([]string{diamOCSCCRequestType}, strconv.Itoa(reqType))
+	vars.Set([]string{diamOCSCCRequestNumber}, strconv.Itoa(reqNr))
+	if reqType == ccrEvent {
+		vars.Set([]string{diamOCSRequestedAction}, strconv.Itoa(diamCheckBalance))
+	}
+	var reqUsage time.Duration
+	if reqType != ccrTerminate && ev.HasField(utils.Usage) {
+		if reqUsage, err = ev.FieldAsDuration(utils.Usage); err != nil {
+			return
+		}
+		vars.Set([]string{diamOCSRequestedUsage}, reqUsage)
+	}
+	usedUsage, hasUsed, err := s.usedUsage(ev, reqType)
+	if err != nil {
+		return
+	}
+	if hasUsed {
+		vars.Set([]string{diamOCSUsedUsage}, usedUsage)
+	}
+	aReq := NewAgentRequest(utils.MapStorage(ev.Event), vars, nil, nil, ev.APIOpts, nil,
+		utils.FirstNonEmpty(ev.Tenant, oc.cgrCfg.GeneralCfg().DefaultTenant),
+		oc.cgrCfg.GeneralCfg().DefaultTimezone, oc.fltrS, nil)
+	if err = aReq.SetFields(oc.cgrCfg.TemplatesCfg()[ocCfg.CCRTemplate]); err != nil {
+		return
+	}
+	m := diam.NewRequest(diam.CreditControl, diamCCApplicationID, nil)
+	if err = updateDiamMsgFromNavMap(m, aReq.diamreq,
+		oc.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	if retrans {
+		m.Header.CommandFlags |= diam.RetransmittedFlag
+		m.Header.EndToEndID = s.pending.endToEndID
+	}
+	if reqType != ccrEvent {
+		s.pending = &diamOCSPending{ev: ev, reqNr: reqNr, endToEndID: m.Header.EndToEndID}
+	}
+	cca, err := oc.send(conn, m)
+	if err != nil {
+		return
+	}
+	resCode, err := diamResultCode(cca)
+	if err != nil {
+		return
+	}
+	if resCode == diamUnableToDeliver || resCode == diamTooBusy {
+		return nil, rpcclient.ErrDisconnected // the pool fails over to the next peer
+	}
+	s.pending = nil
+	s.reqNr = reqNr
+	if reqType == ccrTerminate || reqType == ccrEvent ||
+		(reqType == ccrInitial && resCode/1000 != 2) {
+		s.endSession()
+	} else {
+		s.usage += usedUsage
+		s.conn, s.ev = oc, ev
+		if ocCfg.TccTimer != 0 {
+			if s.tcc == nil {
+				s.tcc = time.AfterFunc(ocCfg.TccTimer, s.expire)
+			} else {
+				s.tcc.Reset(ocCfg.TccTimer)
+			}
+		}
+	}
+	switch {
+	case resCode == diamCreditLimitReachd:
+		return nil, utils.ErrInsufficientCredit
+	case resCode == diamUserUnknown:
+		return nil, utils.ErrAccountNotFound
+	case resCode/1000 != 2:
+		return nil, fmt.Errorf("OCS_ERROR:%d", resCode)
+	case reqType == ccrTerminate:
+		return
+	}
+	if reqType == ccrEvent {
+		if cbr, _ := cca.FindAVP(avp.CheckBalanceResult, dict.UndefinedVendorID); cbr != nil {
+			if res, _ := diamAVPAsString(cbr); res == strconv.Itoa(diamNoCredit) {
+				return nil, utils.ErrInsufficientCredit
+			}
+		}
+	}
+	ccaReq := NewAgentRequest(newDADataProvider(conn, cca), vars, nil, nil, ev.APIOpts, nil,
+		utils.FirstNonEmpty(ev.Tenant, oc.cgrCfg.GeneralCfg().DefaultTenant),
+		oc.cgrCfg.GeneralCfg().DefaultTimezone, oc.fltrS, nil)
+	if err = ccaReq.SetFields(oc.cgrCfg.TemplatesCfg()[ocCfg.CCATemplate]); err != nil {
+		return
+	}
+	maxUsageIface, err := ccaReq.CGRReply.FieldAsInterface([]string{utils.CapMaxUsage, "0"})
+	if err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		if reqType == ccrEvent && reqUsage != 0 { // balance checked for the requested usage
+			return &reqUsage, nil
+		}
+		return nil, nil
+	}
+	usage, err := utils.IfaceAsDuration(maxUsageIface)
+	if err != nil {
+		return
+	}
+	return &usage, nil
+}
+
+// usedUsage returns the usage reported in the CCR as consumed since the previous one
+func (s *diamOCSSession) usedUsage(ev *utils.CGREvent, reqType int) (used time.Duration, has bool, err error) {
+	switch {
+	case reqType == ccrTerminate && ev.HasField(utils.Usage): // total usage of the session
+		if used, err = ev.FieldAsDuration(utils.Usage); err != nil {
+			return
+		}
+		return max(used-s.usage, 0), true, nil
+	case reqType != ccrInitial && reqType != ccrEvent && ev.HasField(utils.LastUsed):
+		used, err = ev.FieldAsDuration(utils.LastUsed)
+		return used, err == nil, err
+	}
+	return
+}
+
+// diamResultCode returns the Result-Code of the answer
+func diamResultCode(m *diam.Message) (int, error) {
+	rcAVP, err := m.FindAVP(avp.ResultCode, dict.UndefinedVendorID)
+	if err != nil {
+		return 0, err
+	}
+	if rcAVP == nil {
+		return 0, utils.NewErrMandatoryIeMissing("Result-Code")
+	}
+	rc, err := diamAVPAsString(rcAVP)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(rc)
+}
+
+// connect returns the connection to the peer, establishing it if needed
+func (oc *diamOCSConn) connect() (conn diam.Conn, err error) {
+	oc.connMux.Lock()
+	defer oc.connMux.Unlock()
+	if oc.conn != nil {
+		return oc.conn, nil
+	}
+	daCfg := oc.cgrCfg.DiameterAgentCfg()
+	dSM := sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(daCfg.OriginHost),
+		OriginRealm:      datatype.DiameterIdentity(daCfg.OriginRealm),
+		VendorID:         datatype.Unsigned32(daCfg.VendorID),
+		ProductName:      datatype.UTF8String(daCfg.ProductName),
+		FirmwareRevision: datatype.Unsigned32(utils.DiameterFirmwareRevision),
+	})
+	dSM.HandleFunc("CCA", oc.handleCCA)
+	go func() {
+		for err := range dSM.ErrorReports() {
+			utils.Logger.Warning(fmt.Sprintf("<%s> OCS peer <%s> error: %v",
+				utils.DiameterAgent, oc.address, err))
+		}
+	}()
+	cli := &sm.Client{
+		Handler:            dSM,
+		MaxRetransmits:     1,
+		RetransmitInterval: oc.connectTimeout,
+		EnableWatchdog:     true,
+		WatchdogInterval:   daCfg.OCSClient.WatchdogInterval,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diamCCApplicationID)),
+		},
+	}
+	if oc.tls {
+		conn, err = cli.DialTLSExt(utils.TCP, oc.address, oc.certPath, oc.keyPath, oc.connectTimeout, nil)
+	} else {
+		conn, err = cli.DialExt(utils.TCP, oc.address, oc.connectTimeout, nil)
+	}
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed connecting to OCS peer <%s>, err: %s",
+			utils.DiameterAgent, oc.address, err.Error()))
+		return nil, rpcclient.ErrDisconnected
+	}
+	oc.conn = conn
+	if cn, canNotify := conn.(diam.CloseNotifier); canNotify {
+		go func() {
+			<-cn.CloseNotify() // the watchdog closes the connection when DWRs are not answered
+			oc.disconnect(conn)
+		}()
+	}
+	return
+}
+
+// disconnect drops the connection, the next request reconnecting
+func (oc *diamOCSConn) disconnect(conn diam.Conn) {
+	oc.connMux.Lock()
+	if oc.conn == conn {
+		oc.conn = nil
+	}
+	oc.connMux.Unlock()
+	conn.Close()
+}
+
+// send writes the CCR and waits Tx for its answer
+func (oc *diamOCSConn) send(conn diam.Conn, m *diam.Message) (cca *diam.Message, err error) {
+	ccaCh := make(chan *diam.Message, 1)
+	oc.ccasMux.Lock()
+	oc.ccas[m.Header.HopByHopID] = ccaCh
+	oc.ccasMux.Unlock()
+	defer func() {
+		oc.ccasMux.Lock()
+		delete(oc.ccas, m.Header.HopByHopID)
+		oc.ccasMux.Unlock()
+	}()
+	if err = writeOnConn(conn, m); err != nil {
+		oc.disconnect(conn)
+		return nil, rpcclient.ErrDisconnected
+	}
+	tx := time.NewTimer(oc.cgrCfg.DiameterAgentCfg().OCSClient.TxTimer)
+	defer tx.Stop()
+	select {
+	case cca = <-ccaCh:
+		return
+	case <-tx.C:
+		utils.Logger.Warning(fmt.Sprintf("<%s> Tx expired waiting CCA from OCS peer <%s>",
+			utils.DiameterAgent, oc.address))
+		return nil, context.DeadlineExceeded
+	}
+}
+
+// handleCCA passes the CCA to the request waiting for it
+func (oc *diamOCSConn) handleCCA(_ diam.Conn, m *diam.Message) {
+	oc.ccasMux.Lock()
+	ccaCh, has := oc.ccas[m.Header.HopByHopID]
+	oc.ccasMux.Unlock()
+	if !has {
+		return
+	}
+	select {
+	case ccaCh <- m:
+	default:
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/go-diameter/diam"
+	"github.com/cgrates/go-diameter/diam/avp"
+	"github.com/cgrates/go-diameter/diam/datatype"
+	"github.com/cgrates/go-diameter/diam/dict"
+	"github.com/cgrates/go-diameter/diam/sm"
+	"github.com/cgrates/rpcclient"
+)
+
+// testDiamOCS starts an OCS answering the CCRs with resultCode and granting 300 seconds,
+// the received CCRs being passed on the returned channel. No answer is sent for resultCode 0.
+func testDiamOCS(t *testing.T, resultCode uint32) (string, chan *diam.Message) {
+	t.Helper()
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	ccrs := make(chan *diam.Message, 10)
+	dSM := sm.New(&sm.Settings{
+		OriginHost:  "ocs.partner.org",
+		OriginRealm: "partner.org",
+		VendorID:    0,
+		ProductName: "OCS",
+	})
+	dSM.HandleFunc("CCR", func(c diam.Conn, m *diam.Message) {
+		ccrs <- m
+		if resultCode == 0 {
+			return
+		}
+		a := m.Answer(resultCode)
+		for _, code := range []uint32{avp.SessionID, avp.CCRequestType, avp.CCRequestNumber} {
+			if rcv, _ := m.FindAVP(code, dict.UndefinedVendorID); rcv != nil {
+				a.AddAVP(rcv)
+			}
+		}
+		a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("ocs.partner.org"))
+		a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("partner.org"))
+		a.NewAVP(avp.GrantedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{diam.NewAVP(avp.CCTime, avp.Mbit, 0, datatype.Unsigned32(300))},
+		})
+		a.WriteTo(c)
+	})
+	go diam.Serve(ln, dSM)
+	return ln.Addr().String(), ccrs
+}
+
+// testDiamOCSCfg sets the configuration used by the *diameter connections
+func testDiamOCSCfg(t *testing.T) *config.CGRConfig {
+	t.Helper()
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().OCSClient.TxTimer = 200 * time.Millisecond
+	oldCfg := config.CgrConfig()
+	config.SetCgrConfig(cfg)
+	t.Cleanup(func() {
+		config.SetCgrConfig(oldCfg)
+		diamOCSSessions.Lock()
+		diamOCSSessions.s = make(map[string]*diamOCSSession)
+		diamOCSSessions.Unlock()
+	})
+	return cfg
+}
+
+// testDiamAVP returns the value of the AVP found at path
+func testDiamAVP(t *testing.T, m *diam.Message, path ...any) string {
+	t.Helper()
+	avps, err := m.FindAVPsWithPath(path, dict.UndefinedVendorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(avps) == 0 {
+		return utils.EmptyString
+	}
+	val, err := diamAVPAsString(avps[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return val
+}
+
+func TestDiamOCSConnSession(t *testing.T) {
+	testDiamOCSCfg(t)
+	addr, ccrs := testDiamOCS(t, diam.Success)
+	oc, err := newDiamOCSConn(&config.RemoteHost{Address: addr, Transport: utils.MetaDiameter},
+		utils.EmptyString, utils.EmptyString, "ocs1", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "OCSSession",
+		Event: map[string]any{
+			utils.OriginID:     "session1",
+			utils.AccountField: "1001",
+			utils.Usage:        time.Minute,
+		},
+	}
+	var iRply sessions.V1InitSessionReply
+	if err = oc.Call(context.Background(), utils.SessionSv1InitiateSession,
+		&sessions.V1InitSessionArgs{InitSession: true, CGREvent: ev}, &iRply); err != nil {
+		t.Fatal(err)
+	}
+	if iRply.MaxUsage == nil || *iRply.MaxUsage != 300*time.Second {
+		t.Errorf("expected MaxUsage 5m, received %v", utils.ToJSON(iRply.MaxUsage))
+	}
+	ccr := <-ccrs
+	sessionID := testDiamAVP(t, ccr, avp.SessionID)
+	for path, exp := range map[int]string{
+		avp.CCRequestType:     "1",
+		avp.CCRequestNumber:   "0",
+		avp.DestinationRealm:  "partner.org",
+		avp.AuthApplicationID: "4",
+		avp.ServiceContextID:  "32260@3gpp.org",
+		avp.UsedServiceUnit:   utils.EmptyString,
+	} {
+		if rcv := testDiamAVP(t, ccr, path); rcv != exp {
+			t.Errorf("expected %q for AVP %d, received %q", exp, path, rcv)
+		}
+	}
+	if rcv := testDiamAVP(t, ccr, avp.SubscriptionID, avp.SubscriptionIDData); rcv != "1001" {
+		t.Errorf("expected Subscription-Id-Data 1001, received %q", rcv)
+	}
+	if rcv := testDiamAVP(t, ccr, avp.RequestedServiceUnit, avp.CCTime); rcv != "60" {
+		t.Errorf("expected requested CC-Time 60, received %q", rcv)
+	}
+
+	ev.Event[utils.LastUsed] = 30 * time.Second
+	var uRply sessions.V1UpdateSessionReply
+	if err = oc.Call(context.Background(), utils.SessionSv1UpdateSession,
+		&sessions.V1UpdateSessionArgs{UpdateSession: true, CGREvent: ev}, &uRply); err != nil {
+		t.Fatal(err)
+	}
+	ccr = <-ccrs
+	if rcv := testDiamAVP(t, ccr, avp.SessionID); rcv != sessionID {
+		t.Errorf("expected Session-Id %q, received %q", sessionID, rcv)
+	}
+	if rcv := testDiamAVP(t, ccr, avp.CCRequestNumber); rcv != "1" {
+		t.Errorf("expected CC-Request-Number 1, received %q", rcv)
+	}
+	if rcv := testDiamAVP(t, ccr, avp.UsedServiceUnit, avp.CCTime); rcv != "30" {
+		t.Errorf("expected used CC-Time 30, received %q", rcv)
+	}
+
+	delete(ev.Event, utils.LastUsed)
+	ev.Event[utils.Usage] = 100 * time.Second
+	var tRply string
+	if err = oc.Call(context.Background(), utils.SessionSv1TerminateSession,
+		&sessions.V1TerminateSessionArgs{TerminateSession: true, CGREvent: ev}, &tRply); err != nil {
+		t.Fatal(err)
+	} else if tRply != utils.OK {
+		t.Errorf("expected OK, received %q", tRply)
+	}
+	ccr = <-ccrs
+	if rcv := testDiamAVP(t, ccr, avp.CCRequestType); rcv != "3" {
+		t.Errorf("expected CC-Request-Type 3, received %q", rcv)
+	}
+	if rcv := testDiamAVP(t, ccr, avp.RequestedServiceUnit); rcv != utils.EmptyString {
+		t.Errorf("expected no Requested-Service-Unit on termination, received %q", rcv)
+	}
+	if rcv := testDiamAVP(t, ccr, avp.UsedServiceUnit, avp.CCTime); rcv != "70" {
+		t.Errorf("expected used CC-Time 70, received %q", rcv)
+	}
+	if err = oc.Call(context.Background(), utils.SessionSv1UpdateSession,
+		&sessions.V1UpdateSessionArgs{UpdateSession: true, CGREvent: ev}, &uRply); err != utils.ErrNoActiveSession {
+		t.Errorf("expected %v, received %v", utils.ErrNoActiveSession, err)
+	}
+	if err = oc.Call(context.Background(), utils.SessionSv1ProcessCDR, ev, &tRply); err != rpcclient.ErrUnsupporteServiceMethod {
+		t.Errorf("expected %v, received %v", rpcclient.ErrUnsupporteServiceMethod, err)
+	}
+}
+
+func TestDiamOCSConnAuthorizeNoCredit(t *testing.T) {
+	testDiamOCSCfg(t)
+	addr, ccrs := testDiamOCS(t, diamCreditLimitReachd)
+	oc, err := newDiamOCSConn(&config.RemoteHost{Address: addr, Transport: utils.MetaDiameter},
+		utils.EmptyString, utils.EmptyString, "ocs2", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rply sessions.V1AuthorizeReply
+	if err = oc.Call(context.Background(), utils.SessionSv1AuthorizeEvent,
+		&sessions.V1AuthorizeArgs{GetMaxUsage: true, CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			Event:  map[string]any{utils.OriginID: "event1", utils.Usage: time.Minute},
+		}}, &rply); err != utils.ErrInsufficientCredit {
+		t.Errorf("expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	ccr := <-ccrs
+	if rcv := testDiamAVP(t, ccr, avp.CCRequestType); rcv != "4" {
+		t.Errorf("expected CC-Request-Type 4, received %q", rcv)
+	}
+	if rcv := testDiamAVP(t, ccr, avp.RequestedAction); rcv != "2" {
+		t.Errorf("expected Requested-Action 2, received %q", rcv)
+	}
+}
+
+func TestDiamOCSConnFailover(t *testing.T) {
+	cfg := testDiamOCSCfg(t)
+	addr1, ccrs1 := testDiamOCS(t, 0) // Tx expires waiting for the answer
+	addr2, ccrs2 := testDiamOCS(t, diam.Success)
+	cfg.RPCConns()["partnerOCS"] = &config.RPCConn{
+		Strategy: rpcclient.PoolFirst,
+		Conns: []*config.RemoteHost{
+			{Address: addr1, Transport: utils.MetaDiameter},
+			{Address: addr2, Transport: utils.MetaDiameter},
+		},
+	}
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	t.Cleanup(func() { engine.Cache.Clear([]string{utils.CacheRPCConnections}) })
+	connMgr := engine.NewConnManager(cfg, nil)
+	var rply sessions.V1InitSessionReply
+	if err := connMgr.Call(context.Background(), []string{"partnerOCS"}, utils.SessionSv1InitiateSession,
+		&sessions.V1InitSessionArgs{InitSession: true, CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			Event:  map[string]any{utils.OriginID: "session2", utils.Usage: time.Minute},
+		}}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if rply.MaxUsage == nil || *rply.MaxUsage != 300*time.Second {
+		t.Errorf("expected MaxUsage 5m, received %v", utils.ToJSON(rply.MaxUsage))
+	}
+	ccr1, ccr2 := <-ccrs1, <-ccrs2
+	if ccr1.Header.CommandFlags&diam.RetransmittedFlag != 0 {
+		t.Error("expected the first CCR without the T flag")
+	}
+	if ccr2.Header.CommandFlags&diam.RetransmittedFlag == 0 {
+		t.Error("expected the CCR sent to the next peer with the T flag")
+	}
+	if ccr1.Header.EndToEndID != ccr2.Header.EndToEndID {
+		t.Errorf("expected End-to-End Identifier %d, received %d",
+			ccr1.Header.EndToEndID, ccr2.Header.EndToEndID)
+	}
+	if sID1, sID2 := testDiamAVP(t, ccr1, avp.SessionID), testDiamAVP(t, ccr2, avp.SessionID); sID1 != sID2 {
+		t.Errorf("expected Session-Id %q, received %q", sID1, sID2)
+	}
+}
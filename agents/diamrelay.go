@@ -0,0 +1,285 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/go-diameter/diam"
+	"github.com/cgrates/go-diameter/diam/avp"
+	"github.com/cgrates/go-diameter/diam/datatype"
+	"github.com/cgrates/go-diameter/diam/dict"
+	"github.com/cgrates/go-diameter/diam/sm"
+	"github.com/cgrates/go-diameter/diam/sm/smpeer"
+)
+
+// diamRelayedReq is a request relayed upstream, waiting for its answer
+type diamRelayedReq struct {
+	c       diam.Conn     // downstream connection the request came in on
+	m       *diam.Message // original request, as received from downstream
+	reqVars *utils.DataNode
+	peer    string // remote address of the upstream connection
+	timer   *time.Timer
+}
+
+// diamRelayStats are the relay counters of one upstream connection
+type diamRelayStats struct {
+	requests atomic.Uint64
+	answers  atomic.Uint64
+	timeouts atomic.Uint64
+}
+
+// relayRequest relays the request to the first connected peer of the matching realm route
+func (da *DiameterAgent) relayRequest(c diam.Conn, m *diam.Message, reqVars *utils.DataNode) {
+	originHost := da.cgrCfg.DiameterAgentCfg().OriginHost
+	rrAVPs, err := m.FindAVPsWithPath([]any{avp.RouteRecord}, dict.UndefinedVendorID)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> decoding Route-Record, err: %s, message: %s",
+			utils.DiameterAgent, err.Error(), m))
+		diamErr(c, m, diam.UnableToComply, reqVars, da.cgrCfg, da.filterS)
+		return
+	}
+	for _, rrAVP := range rrAVPs {
+		if rr, err := diamAVPAsString(rrAVP); err == nil && rr == originHost {
+			utils.Logger.Warning(fmt.Sprintf("<%s> loop detected relaying message: %s",
+				utils.DiameterAgent, m))
+			diamErr(c, m, diam.LoopDetected, reqVars, da.cgrCfg, da.filterS)
+			return
+		}
+	}
+	var destRealm string
+	if drAVP, err := m.FindAVP(avp.DestinationRealm, dict.UndefinedVendorID); err == nil {
+		destRealm, _ = diamAVPAsString(drAVP)
+	}
+	peerConn := da.relayPeer(destRealm, m.Header.ApplicationID)
+	if peerConn == nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> no connected peer to relay message with Destination-Realm <%s> and Application-Id <%d>",
+			utils.DiameterAgent, destRealm, m.Header.ApplicationID))
+		diamErr(c, m, diam.UnableToDeliver, reqVars, da.cgrCfg, da.filterS)
+		return
+	}
+	hbh := da.relayHbH.Add(1)
+	fwd := diam.NewMessage(m.Header.CommandCode, m.Header.CommandFlags,
+		m.Header.ApplicationID, hbh, m.Header.EndToEndID, m.Dictionary())
+	for _, a := range m.AVP {
+		fwd.AddAVP(a)
+	}
+	routeRecord := c.RemoteAddr().String()
+	if meta, ok := smpeer.FromContext(c.Context()); ok {
+		routeRecord = string(meta.OriginHost)
+	}
+	fwd.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity(routeRecord))
+
+	peer := peerConn.RemoteAddr().String()
+	rReq := &diamRelayedReq{c: c, m: m, reqVars: reqVars, peer: peer}
+	da.relayLck.Lock()
+	da.relayed[hbh] = rReq
+	rReq.timer = time.AfterFunc(da.cgrCfg.GeneralCfg().ReplyTimeout, func() {
+		if da.popRelayed(hbh) == nil {
+			return // answered meanwhile
+		}
+		if st := da.relayStatsFor(peer); st != nil {
+			st.timeouts.Add(1)
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> no answer from peer <%s> for relayed message: %s",
+			utils.DiameterAgent, peer, m))
+		diamErr(c, m, diam.UnableToDeliver, reqVars, da.cgrCfg, da.filterS)
+	})
+	da.relayLck.Unlock()
+	if err := writeOnConn(peerConn, fwd); err != nil {
+		if da.popRelayed(hbh) != nil {
+			rReq.timer.Stop()
+			diamErr(c, m, diam.UnableToDeliver, reqVars, da.cgrCfg, da.filterS)
+		}
+		return
+	}
+	if st := da.relayStatsFor(peer); st != nil {
+		st.requests.Add(1)
+	}
+}
+
+// relayAnswer sends the answer of a relayed request back downstream, returning false
+// if the answer does not belong to a request relayed towards the peer it came from
+func (da *DiameterAgent) relayAnswer(c diam.Conn, m *diam.Message) bool {
+	da.relayLck.Lock()
+	rReq := da.relayed[m.Header.HopByHopID]
+	if rReq == nil || rReq.peer != c.RemoteAddr().String() {
+		da.relayLck.Unlock()
+		return false
+	}
+	delete(da.relayed, m.Header.HopByHopID)
+	da.relayLck.Unlock()
+	rReq.timer.Stop()
+	if st := da.relayStatsFor(rReq.peer); st != nil {
+		st.answers.Add(1)
+	}
+	m.Header.HopByHopID = rReq.m.Header.HopByHopID
+	writeOnConn(rReq.c, m)
+	return true
+}
+
+// popRelayed removes the relayed request with the given hop-by-hop id
+func (da *DiameterAgent) popRelayed(hbh uint32) (rReq *diamRelayedReq) {
+	da.relayLck.Lock()
+	if rReq = da.relayed[hbh]; rReq != nil {
+		delete(da.relayed, hbh)
+	}
+	da.relayLck.Unlock()
+	return
+}
+
+// relayPeer returns the connection to the first connected peer of the route
+// matching the realm and application, the *any routes being considered last
+func (da *DiameterAgent) relayPeer(realm string, appID uint32) diam.Conn {
+	var dfltRoutes []*config.DiamRealmRoute
+	da.relayLck.Lock()
+	defer da.relayLck.Unlock()
+	for _, route := range da.cgrCfg.DiameterAgentCfg().RealmRoutes {
+		if len(route.ApplicationIDs) != 0 &&
+			!slices.Contains(route.ApplicationIDs, appID) {
+			continue
+		}
+		if route.Realm == utils.MetaAny {
+			dfltRoutes = append(dfltRoutes, route)
+			continue
+		}
+		if route.Realm != realm {
+			continue
+		}
+		if c := da.connectedRelayPeer(route); c != nil {
+			return c
+		}
+	}
+	for _, route := range dfltRoutes {
+		if c := da.connectedRelayPeer(route); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// connectedRelayPeer returns the first connected peer of the route, relayLck must be held
+func (da *DiameterAgent) connectedRelayPeer(route *config.DiamRealmRoute) diam.Conn {
+	for _, peer := range route.Peers {
+		if c := da.relayConns[peer.Address]; c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// relayStatsFor returns the relay counters of the connection, nil if not tracked
+func (da *DiameterAgent) relayStatsFor(remoteAddr string) *diamRelayStats {
+	da.relayLck.Lock()
+	defer da.relayLck.Unlock()
+	return da.relayStats[remoteAddr]
+}
+
+// relayPeers returns the unique peers of the realm routes together with the
+// applications advertised towards them
+func (da *DiameterAgent) relayPeers() (peers []config.DiameterListener, peerApps map[string][]uint32) {
+	peerApps = make(map[string][]uint32)
+	allApps := make(map[string]bool)
+	for _, route := range da.cgrCfg.DiameterAgentCfg().RealmRoutes {
+		for _, peer := range route.Peers {
+			if _, has := peerApps[peer.Address]; !has {
+				peers = append(peers, peer)
+				peerApps[peer.Address] = nil
+			}
+			if len(route.ApplicationIDs) == 0 {
+				allApps[peer.Address] = true
+			}
+			for _, appID := range route.ApplicationIDs {
+				if !slices.Contains(peerApps[peer.Address], appID) {
+					peerApps[peer.Address] = append(peerApps[peer.Address], appID)
+				}
+			}
+		}
+	}
+	for addr := range allApps {
+		peerApps[addr] = nil // advertise all the supported applications
+	}
+	return
+}
+
+// connectRelayPeer keeps the connection to the upstream peer, reconnecting on failure
+func (da *DiameterAgent) connectRelayPeer(peer config.DiameterListener, appIDs []uint32,
+	stopChan <-chan struct{}) {
+	daCfg := da.cgrCfg.DiameterAgentCfg()
+	// the client handshake replaces the CER handler, hence a state machine of its own
+	dSM := da.handlers()
+	go da.handleConns(dSM.HandshakeNotify())
+	go func() {
+		for err := range dSM.ErrorReports() {
+			utils.Logger.Warning(fmt.Sprintf("<%s> relay peer <%s> error: %v",
+				utils.DiameterAgent, peer.Address, err))
+		}
+	}()
+	cli := &sm.Client{
+		Handler:            dSM,
+		MaxRetransmits:     1,
+		RetransmitInterval: da.cgrCfg.GeneralCfg().ConnectTimeout,
+		EnableWatchdog:     true,
+		WatchdogInterval:   daCfg.RelayWatchdogInterval,
+	}
+	for _, app := range sm.PrepareSupportedApps(dict.Default, daCfg.CeApplications) {
+		if len(appIDs) != 0 && !slices.Contains(appIDs, app.ID) {
+			continue
+		}
+		if app.AppType == "acct" {
+			cli.AcctApplicationID = append(cli.AcctApplicationID,
+				diam.NewAVP(avp.AcctApplicationID, avp.Mbit, 0, datatype.Unsigned32(app.ID)))
+			continue
+		}
+		cli.AuthApplicationID = append(cli.AuthApplicationID,
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(app.ID)))
+	}
+	for {
+		conn, err := cli.DialExt(utils.FirstNonEmpty(peer.Network, utils.TCP), peer.Address,
+			da.cgrCfg.GeneralCfg().ConnectTimeout, nil)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed connecting to relay peer <%s>, err: %s",
+				utils.DiameterAgent, peer.Address, err.Error()))
+		} else {
+			utils.Logger.Info(fmt.Sprintf("<%s> connected to relay peer <%s>",
+				utils.DiameterAgent, peer.Address))
+			da.relayLck.Lock()
+			da.relayConns[peer.Address] = conn
+			da.relayLck.Unlock()
+			select {
+			case <-conn.(diam.CloseNotifier).CloseNotify(): // the watchdog closes the connection when DWRs are not answered
+			case <-stopChan:
+			}
+			da.relayLck.Lock()
+			delete(da.relayConns, peer.Address)
+			da.relayLck.Unlock()
+			conn.Close()
+		}
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(daCfg.RelayWatchdogInterval):
+		}
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/go-diameter/diam"
+	"github.com/cgrates/go-diameter/diam/avp"
+	"github.com/cgrates/go-diameter/diam/datatype"
+	"github.com/cgrates/go-diameter/diam/dict"
+	"github.com/cgrates/go-diameter/diam/sm"
+)
+
+// testDiamRelay starts a DiameterAgent relaying the requests for partner.org towards
+// an upstream peer answering with resultCode, returning the agent, its address and the
+// requests received upstream
+func testDiamRelay(t *testing.T, resultCode uint32) (*DiameterAgent, string, chan *diam.Message) {
+	t.Helper()
+	upAddr, upReqs := testDiamOCS(t, resultCode)
+	ln, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daAddr := ln.Addr().String()
+	ln.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().ReplyTimeout = 300 * time.Millisecond
+	daCfg := cfg.DiameterAgentCfg()
+	daCfg.DictionariesPath = utils.EmptyString
+	daCfg.Listeners = []config.DiameterListener{{Address: daAddr, Network: utils.TCP}}
+	daCfg.RelayWatchdogInterval = 100 * time.Millisecond
+	daCfg.RealmRoutes = []*config.DiamRealmRoute{{
+		Realm:          "partner.org",
+		ApplicationIDs: []uint32{4},
+		Peers: []config.DiameterListener{
+			{Address: "127.0.0.1:1", Network: utils.TCP}, // never connected
+			{Address: upAddr, Network: utils.TCP},
+		},
+	}}
+	daCfg.RequestProcessors = []*config.RequestProcessor{{
+		ID:      "relay",
+		Filters: []string{"*notstring:~*req.Session-Id:local"},
+		Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaRelay}),
+	}}
+	da, err := NewDiameterAgent(cfg, engine.NewFilterS(cfg, nil, nil), nil, engine.NewCaps(0, utils.MetaBusy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	go da.ListenAndServe(stop)
+	for i := 0; ; i++ {
+		da.relayLck.Lock()
+		connected := da.relayConns[upAddr] != nil
+		da.relayLck.Unlock()
+		if connected {
+			break
+		}
+		if i == 50 {
+			t.Fatal("relay peer not connected")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return da, daAddr, upReqs
+}
+
+// testDiamRelayClient connects a downstream peer to the agent, returning the
+// connection and the answers it receives
+func testDiamRelayClient(t *testing.T, daAddr string) (diam.Conn, chan *diam.Message) {
+	t.Helper()
+	answers := make(chan *diam.Message, 10)
+	cSM := sm.New(&sm.Settings{
+		OriginHost:  "client.cgrates.org",
+		OriginRealm: "cgrates.org",
+		ProductName: "Client",
+	})
+	cSM.HandleFunc("CCA", func(c diam.Conn, m *diam.Message) { answers <- m })
+	cli := &sm.Client{
+		Handler:            cSM,
+		MaxRetransmits:     1,
+		RetransmitInterval: time.Second,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(4)),
+		},
+	}
+	conn, err := cli.DialNetwork(utils.TCP, daAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, answers
+}
+
+func testDiamRelayCCR(sessionID, destRealm string, routeRecords ...string) *diam.Message {
+	m := diam.NewRequest(diam.CreditControl, 4, nil)
+	m.Header.CommandFlags |= diam.ProxiableFlag
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("client.cgrates.org"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity(destRealm))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(4))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(1))
+	m.NewAVP(avp.CCRequestNumber, avp.Mbit, 0, datatype.Unsigned32(0))
+	for _, rr := range routeRecords {
+		m.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity(rr))
+	}
+	return m
+}
+
+func testDiamRelayAnswer(t *testing.T, answers chan *diam.Message, ccr *diam.Message, resultCode uint32) {
+	t.Helper()
+	select {
+	case cca := <-answers:
+		if cca.Header.HopByHopID != ccr.Header.HopByHopID ||
+			cca.Header.EndToEndID != ccr.Header.EndToEndID {
+			t.Errorf("expected answer to %d/%d, received %d/%d",
+				ccr.Header.HopByHopID, ccr.Header.EndToEndID,
+				cca.Header.HopByHopID, cca.Header.EndToEndID)
+		}
+		if rc := testDiamAVP(t, cca, avp.ResultCode); rc != strconv.Itoa(int(resultCode)) {
+			t.Errorf("expected Result-Code %d, received %s", resultCode, rc)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no answer received")
+	}
+}
+
+func TestDiamRelayRequest(t *testing.T) {
+	da, daAddr, upReqs := testDiamRelay(t, diam.Success)
+	conn, answers := testDiamRelayClient(t, daAddr)
+
+	ccr := testDiamRelayCCR("relay1", "partner.org")
+	if _, err := ccr.WriteTo(conn); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case upCCR := <-upReqs:
+		if upCCR.Header.HopByHopID == ccr.Header.HopByHopID {
+			t.Errorf("expected the hop-by-hop id rewritten, received %d", upCCR.Header.HopByHopID)
+		}
+		if upCCR.Header.EndToEndID != ccr.Header.EndToEndID {
+			t.Errorf("expected end-to-end id %d, received %d",
+				ccr.Header.EndToEndID, upCCR.Header.EndToEndID)
+		}
+		if rr := testDiamAVP(t, upCCR, avp.RouteRecord); rr != "client.cgrates.org" {
+			t.Errorf("expected Route-Record <client.cgrates.org>, received <%s>", rr)
+		}
+		if sID := testDiamAVP(t, upCCR, avp.SessionID); sID != "relay1" {
+			t.Errorf("expected Session-Id <relay1>, received <%s>", sID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("request not relayed")
+	}
+	testDiamRelayAnswer(t, answers, ccr, diam.Success)
+
+	var upPeer string
+	da.relayLck.Lock()
+	for _, c := range da.relayConns {
+		upPeer = c.RemoteAddr().String()
+	}
+	da.relayLck.Unlock()
+	st := da.relayStatsFor(upPeer)
+	if st == nil {
+		t.Fatalf("no relay stats for <%s>", upPeer)
+	}
+	if rcv := st.requests.Load(); rcv != 1 {
+		t.Errorf("expected 1 relayed request, received %d", rcv)
+	}
+	if rcv := st.answers.Load(); rcv != 1 {
+		t.Errorf("expected 1 relayed answer, received %d", rcv)
+	}
+}
+
+func TestDiamRelayErrors(t *testing.T) {
+	_, daAddr, upReqs := testDiamRelay(t, diam.Success)
+	conn, answers := testDiamRelayClient(t, daAddr)
+
+	for _, tc := range []struct {
+		name       string
+		ccr        *diam.Message
+		resultCode uint32
+	}{
+		{
+			name:       "LoopDetected",
+			ccr:        testDiamRelayCCR("relay2", "partner.org", "client.cgrates.org", "CGR-DA"),
+			resultCode: diam.LoopDetected,
+		},
+		{
+			name:       "NoRoute",
+			ccr:        testDiamRelayCCR("relay3", "unknown.org"),
+			resultCode: diam.UnableToDeliver,
+		},
+		{
+			name: "NotProxiable", // no local processor to answer it
+			ccr: func() *diam.Message {
+				m := testDiamRelayCCR("relay5", "partner.org")
+				m.Header.CommandFlags &^= diam.ProxiableFlag
+				return m
+			}(),
+			resultCode: diam.UnableToComply,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.ccr.WriteTo(conn); err != nil {
+				t.Fatal(err)
+			}
+			testDiamRelayAnswer(t, answers, tc.ccr, tc.resultCode)
+		})
+	}
+	select {
+	case upCCR := <-upReqs:
+		t.Errorf("unexpected request relayed: %s", upCCR)
+	default:
+	}
+}
+
+func TestDiamRelayTimeout(t *testing.T) {
+	da, daAddr, upReqs := testDiamRelay(t, 0)
+	conn, answers := testDiamRelayClient(t, daAddr)
+
+	ccr := testDiamRelayCCR("relay4", "partner.org")
+	if _, err := ccr.WriteTo(conn); err != nil {
+		t.Fatal(err)
+	}
+	testDiamRelayAnswer(t, answers, ccr, diam.UnableToDeliver)
+	if upCCR := <-upReqs; testDiamAVP(t, upCCR, avp.SessionID) != "relay4" {
+		t.Errorf("unexpected request relayed: %s", upCCR)
+	}
+	var timeouts uint64
+	da.relayLck.Lock()
+	for _, st := range da.relayStats {
+		timeouts += st.timeouts.Load()
+	}
+	da.relayLck.Unlock()
+	if timeouts != 1 {
+		t.Errorf("expected 1 relay timeout, received %d", timeouts)
+	}
+}
+
+// testDiamPeerConn is a diam.Conn with a fixed remote address
+type testDiamPeerConn struct {
+	diam.Conn
+	addr net.Addr
+}
+
+func (c testDiamPeerConn) RemoteAddr() net.Addr { return c.addr }
+
+func TestDiamRelayAnswerUnknown(t *testing.T) {
+	da := &DiameterAgent{relayed: make(map[uint32]*diamRelayedReq)}
+	c := testDiamPeerConn{addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 3868}}
+	m := diam.NewMessage(diam.CreditControl, 0, 4, 1, 1, dict.Default)
+	if da.relayAnswer(c, m) {
+		t.Error("expected the answer not to be relayed")
+	}
+	// answers are accepted only from the peer the request was relayed to
+	da.relayed[1] = &diamRelayedReq{peer: "127.0.0.1:3869", timer: time.NewTimer(time.Minute)}
+	if da.relayAnswer(c, m) {
+		t.Error("expected the answer from another peer not to be relayed")
+	}
+	if _, has := da.relayed[1]; !has {
+		t.Error("expected the relayed request to wait for the answer of its peer")
+	}
+}
@@ -20,7 +20,9 @@ package agents
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -52,33 +54,54 @@ type DNSAgent struct {
 	caps    *engine.Caps
 	fltrS   *engine.FilterS // connection towards FilterS
 	servers []*dns.Server
+	dohSrvs []*http.Server // DNS-over-HTTPS listeners
 }
 
 // initDNSServer instantiates the DNS server
 func (da *DNSAgent) initDNSServer() (_ error) {
 	da.servers = make([]*dns.Server, 0, len(da.cgrCfg.DNSAgentCfg().Listeners))
-	for i := range da.cgrCfg.DNSAgentCfg().Listeners {
-		da.servers = append(da.servers, &dns.Server{
-			Addr: da.cgrCfg.DNSAgentCfg().Listeners[i].Address,
-			Net:  da.cgrCfg.DNSAgentCfg().Listeners[i].Network,
+	da.dohSrvs = nil
+	for _, lstnr := range da.cgrCfg.DNSAgentCfg().Listeners {
+		if lstnr.Network == utils.DoH {
+			tlsCfg, err := da.tlsConfig()
+			if err != nil {
+				return err
+			}
+			mux := http.NewServeMux()
+			mux.Handle(utils.DoHPath, &dohHandler{handleMessage: da.handleMessage})
+			da.dohSrvs = append(da.dohSrvs, &http.Server{
+				Addr:      lstnr.Address,
+				Handler:   mux,
+				TLSConfig: tlsCfg,
+			})
+			continue
+		}
+		srv := &dns.Server{
+			Addr: lstnr.Address,
+			Net:  lstnr.Network,
 			Handler: dns.HandlerFunc(func(w dns.ResponseWriter, m *dns.Msg) {
 				go da.handleMessage(w, m)
 			}),
-		})
-		if strings.HasSuffix(da.cgrCfg.DNSAgentCfg().Listeners[i].Network, utils.TLSNoCaps) {
-			cert, err := tls.LoadX509KeyPair(da.cgrCfg.TLSCfg().ServerCerificate, da.cgrCfg.TLSCfg().ServerKey)
-			if err != nil {
-				return fmt.Errorf("load certificate error <%v>", err)
-			}
-			da.servers[i].Net = "tcp-tls"
-			da.servers[i].TLSConfig = &tls.Config{
-				Certificates: []tls.Certificate{cert},
+		}
+		if strings.HasSuffix(lstnr.Network, utils.TLSNoCaps) {
+			var err error
+			if srv.TLSConfig, err = da.tlsConfig(); err != nil {
+				return err
 			}
+			srv.Net = "tcp-tls"
 		}
+		da.servers = append(da.servers, srv)
 	}
 	return
 }
 
+// tlsConfig builds the TLS configuration used by the tcp-tls and doh listeners
+func (da *DNSAgent) tlsConfig() (*tls.Config, error) {
+	tlsCfg := da.cgrCfg.TLSCfg()
+	return utils.LoadTLSConfig(tlsCfg.ServerCerificate, tlsCfg.ServerKey,
+		tlsCfg.CaCertificate, tlsCfg.ServerPolicy, tlsCfg.ServerName)
+}
+
 // ListenAndServe will run the DNS handler doing also the connection to listen address
 func (da *DNSAgent) ListenAndServe(stopChan chan struct{}) error {
 	errChan := make(chan error)
@@ -98,6 +121,22 @@ func (da *DNSAgent) ListenAndServe(stopChan chan struct{}) error {
 		}(server)
 	}
 
+	for _, server := range da.dohSrvs {
+		utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s:%s>",
+			utils.DNSAgent, utils.DoH, server.Addr))
+		go func(srv *http.Server) {
+			err := srv.ListenAndServeTLS(utils.EmptyString, utils.EmptyString)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error <%v>, on ListenAndServe <%s:%s>",
+					utils.DNSAgent, err, utils.DoH, srv.Addr))
+				if strings.Contains(err.Error(), "address already in use") {
+					return
+				}
+				errChan <- err
+			}
+		}(server)
+	}
+
 	select {
 	case <-stopChan:
 		return da.Shutdown()
@@ -163,6 +202,13 @@ func (da *DNSAgent) Shutdown() error {
 			err = shtdErr
 		}
 	}
+	for _, server := range da.dohSrvs {
+		if shtdErr := server.Close(); shtdErr != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error <%v>, on Shutdown <%s:%s>",
+				utils.DNSAgent, shtdErr, utils.DoH, server.Addr))
+			err = shtdErr
+		}
+	}
 	return err
 }
 
@@ -22,8 +22,13 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"net/http"
 	"path"
+	"reflect"
 	"testing"
 	"time"
 
@@ -58,6 +63,12 @@ var (
 		testDNSitClntADryRun,
 		testDNSitClntSRVDryRun,
 		testDNSitClntNAPTRDryRun,
+		testDNSitClntAAAADryRun,
+		testDNSitClntTXTDryRun,
+		testDNSitClntMXDryRun,
+		testDNSitClntPTRDryRun,
+		testDNSitClntCNAMEDryRun,
+		testDNSitClntDoHDryRun,
 		testDNSitClntAAttributes,
 		testDNSitClntSRVAttributes,
 		testDNSitClntNAPTRAttributes,
@@ -1500,6 +1511,172 @@ func testDNSitClntNAPTROptsWithAttributes(t *testing.T) {
 
 }
 
+func testDNSitClntAAAADryRun(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeAAAA)
+	if err := dnsClnt.UDP.WriteMsg(m); err != nil {
+		t.Error(err)
+	}
+	if rply, err := dnsClnt.UDP.ReadMsg(); err != nil {
+		t.Error(err)
+	} else if len(rply.Answer) != 2 {
+		t.Fatalf("wrong number of records: %s", utils.ToIJSON(rply.Answer))
+	} else {
+		if rply.Rcode != dns.RcodeSuccess {
+			t.Errorf("failed to get an valid answer\n%v", rply)
+		}
+		answr0 := rply.Answer[0].(*dns.AAAA)
+		if answr0.AAAA.String() != "2001:db8::1" || answr0.Hdr.Ttl != 120 {
+			t.Errorf("unexpected answer: %s", answr0)
+		}
+		answr1 := rply.Answer[1].(*dns.AAAA)
+		if answr1.AAAA.String() != "2001:db8::2" || answr1.Hdr.Ttl != 60 {
+			t.Errorf("unexpected answer: %s", answr1)
+		}
+	}
+}
+
+func testDNSitClntTXTDryRun(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeTXT)
+	if err := dnsClnt.UDP.WriteMsg(m); err != nil {
+		t.Error(err)
+	}
+	if rply, err := dnsClnt.UDP.ReadMsg(); err != nil {
+		t.Error(err)
+	} else if len(rply.Answer) != 1 {
+		t.Fatalf("wrong number of records: %s", utils.ToIJSON(rply.Answer))
+	} else {
+		if rply.Rcode != dns.RcodeSuccess {
+			t.Errorf("failed to get an valid answer\n%v", rply)
+		}
+		answr := rply.Answer[0].(*dns.TXT)
+		if exp := []string{"carrier=cgrates"}; !reflect.DeepEqual(answr.Txt, exp) {
+			t.Errorf("Expected :<%q> , received: <%q>", exp, answr.Txt)
+		}
+	}
+}
+
+func testDNSitClntMXDryRun(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeMX)
+	if err := dnsClnt.UDP.WriteMsg(m); err != nil {
+		t.Error(err)
+	}
+	if rply, err := dnsClnt.UDP.ReadMsg(); err != nil {
+		t.Error(err)
+	} else if len(rply.Answer) != 1 {
+		t.Fatalf("wrong number of records: %s", utils.ToIJSON(rply.Answer))
+	} else {
+		if rply.Rcode != dns.RcodeSuccess {
+			t.Errorf("failed to get an valid answer\n%v", rply)
+		}
+		answr := rply.Answer[0].(*dns.MX)
+		if answr.Preference != 10 || answr.Mx != "mail.cgrates.org." {
+			t.Errorf("unexpected answer: %s", answr)
+		}
+	}
+}
+
+func testDNSitClntPTRDryRun(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("188.77.38.51.in-addr.arpa.", dns.TypePTR)
+	if err := dnsClnt.UDP.WriteMsg(m); err != nil {
+		t.Error(err)
+	}
+	if rply, err := dnsClnt.UDP.ReadMsg(); err != nil {
+		t.Error(err)
+	} else if len(rply.Answer) != 1 {
+		t.Fatalf("wrong number of records: %s", utils.ToIJSON(rply.Answer))
+	} else {
+		if rply.Rcode != dns.RcodeSuccess {
+			t.Errorf("failed to get an valid answer\n%v", rply)
+		}
+		answr := rply.Answer[0].(*dns.PTR)
+		if answr.Ptr != "cgrates.org." {
+			t.Errorf("Expected :<%q> , received: <%q>", "cgrates.org.", answr.Ptr)
+		}
+	}
+}
+
+func testDNSitClntCNAMEDryRun(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("www.cgrates.org.", dns.TypeA)
+	if err := dnsClnt.UDP.WriteMsg(m); err != nil {
+		t.Error(err)
+	}
+	if rply, err := dnsClnt.UDP.ReadMsg(); err != nil {
+		t.Error(err)
+	} else if len(rply.Answer) != 2 {
+		t.Fatalf("wrong number of records: %s", utils.ToIJSON(rply.Answer))
+	} else {
+		if rply.Rcode != dns.RcodeSuccess {
+			t.Errorf("failed to get an valid answer\n%v", rply)
+		}
+		answr0 := rply.Answer[0].(*dns.CNAME)
+		if answr0.Hdr.Name != "www.cgrates.org." || answr0.Target != "cgrates.org." {
+			t.Errorf("unexpected answer: %s", answr0)
+		}
+		answr1 := rply.Answer[1].(*dns.A)
+		if answr1.Hdr.Name != "cgrates.org." || answr1.A.String() != "51.38.77.188" {
+			t.Errorf("unexpected answer: %s", answr1)
+		}
+	}
+}
+
+func testDNSitClntDoHDryRun(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeA)
+	m.Id = 0 // RFC 8484 recommends using ID 0 for cache friendliness
+	buf, err := m.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnt := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+	dohURL := "https://127.0.0.1" + dnsCfg.DNSAgentCfg().Listeners[3].Address + utils.DoHPath
+
+	postReq, err := http.NewRequest(http.MethodPost, dohURL, bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	postReq.Header.Set(utils.ContentType, utils.DNSMessageContentType)
+	getReq, err := http.NewRequest(http.MethodGet,
+		dohURL+"?dns="+base64.RawURLEncoding.EncodeToString(buf), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range []*http.Request{getReq, postReq} {
+		resp, err := clnt.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("<%s> unexpected status code: %d, body: %s", req.Method, resp.StatusCode, body)
+		}
+		if ct := resp.Header.Get(utils.ContentType); ct != utils.DNSMessageContentType {
+			t.Errorf("<%s> expected content type %q, received %q", req.Method, utils.DNSMessageContentType, ct)
+		}
+		rply := new(dns.Msg)
+		if err = rply.Unpack(body); err != nil {
+			t.Fatal(err)
+		}
+		if rply.Rcode != dns.RcodeSuccess {
+			t.Errorf("failed to get an valid answer\n%v", rply)
+		} else if len(rply.Answer) != 1 {
+			t.Fatalf("wrong number of records: %s", utils.ToIJSON(rply.Answer))
+		} else if answr0 := rply.Answer[0].(*dns.A); answr0.A.String() != "51.38.77.188" {
+			t.Errorf("Expected :<%q> , received: <%q>", "51.38.77.188", answr0.A)
+		}
+	}
+}
+
 func testDNSitStopEngine(t *testing.T) {
 	if err := engine.KillEngine(*utils.WaitRater); err != nil {
 		t.Error(err)
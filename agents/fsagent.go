@@ -21,6 +21,8 @@ package agents
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -564,9 +566,50 @@ func (fsa *FSsessions) V1WarnDisconnect(ctx *context.Context, args map[string]an
 	return
 }
 
-// V1AlterSession is used to implement the sessions.BiRPClient interface
-func (*FSsessions) V1AlterSession(*context.Context, utils.CGREvent, *string) error {
-	return utils.ErrNotImplemented
+// V1AlterSession updates the channel variables and reschedules the hangup of an active call in FreeSWITCH
+func (fsa *FSsessions) V1AlterSession(ctx *context.Context, cgrEv utils.CGREvent, reply *string) (err error) {
+	ev := engine.NewMapEvent(cgrEv.Event)
+	channelID := ev.GetStringIgnoreErrors(utils.OriginID)
+	var connIdx int64
+	if connIdx, err = ev.GetTInt64(FsConnID); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to alter channelID: <%s>",
+				utils.FreeSWITCHAgent, err.Error(), FsConnID, channelID))
+		return
+	}
+	if int(connIdx) >= len(fsa.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(fsa.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.FreeSWITCHAgent, err.Error()))
+		return
+	}
+	chanVars, maxUsage, err := alterSessionArgs(cgrEv)
+	if err != nil {
+		return
+	}
+	for _, varName := range slices.Sorted(maps.Keys(chanVars)) {
+		if _, err = fsa.conns[connIdx].SendApiCmd(fmt.Sprintf("uuid_setvar %s %s %s\n\n",
+			channelID, varName, chanVars[varName])); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> Could not send uuid_setvar to freeswitch, error: <%s>, connIdx: %v",
+				utils.FreeSWITCHAgent, err.Error(), connIdx))
+			return
+		}
+	}
+	if maxUsage != nil {
+		// the tasks scheduled for the channel are grouped under its uuid
+		if _, err = fsa.conns[connIdx].SendApiCmd(fmt.Sprintf("sched_del %s\n\n", channelID)); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> Could not send sched_del to freeswitch, error: <%s>, connIdx: %v",
+				utils.FreeSWITCHAgent, err.Error(), connIdx))
+			return
+		}
+		if _, err = fsa.conns[connIdx].SendApiCmd(fmt.Sprintf("sched_hangup +%d %s alloted_timeout\n\n",
+			int(maxUsage.Seconds()), channelID)); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> Could not send sched_hangup to freeswitch, error: <%s>, connIdx: %v",
+				utils.FreeSWITCHAgent, err.Error(), connIdx))
+			return
+		}
+	}
+	*reply = utils.OK
+	return
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
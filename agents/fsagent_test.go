@@ -45,11 +45,30 @@ func TestFsAgentV1DisconnectPeer(t *testing.T) {
 
 func TestFsAgentV1AlterSession(t *testing.T) {
 	ctx := context.Background()
-	cgrEv := utils.CGREvent{}
+	cgrEv := utils.CGREvent{
+		Event: map[string]any{
+			utils.OriginID: "ID",
+		},
+		APIOpts: map[string]any{
+			utils.MetaMaxUsage: "1m",
+		},
+	}
 	fss := &FSsessions{}
-	err := fss.V1AlterSession(ctx, cgrEv, nil)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected error: %v, got: %v", utils.ErrNotImplemented, err)
+	var reply string
+	if err := fss.V1AlterSession(ctx, cgrEv, &reply); err == nil {
+		t.Error("Expected error for missing FsConnID")
+	}
+	cgrEv.Event[FsConnID] = int64(0)
+	if err := fss.V1AlterSession(ctx, cgrEv, &reply); err == nil ||
+		err.Error() != "Index out of range[0,0): 0 " {
+		t.Errorf("Expected index out of range error, got %v", err)
+	}
+	fss.conns = []*fsock.FSock{nil}
+	delete(cgrEv.APIOpts, utils.MetaMaxUsage)
+	if err := fss.V1AlterSession(ctx, cgrEv, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.MetaChannelVars, utils.MetaMaxUsage).Error() {
+		t.Errorf("Expected error %v, got %v",
+			utils.NewErrMandatoryIeMissing(utils.MetaChannelVars, utils.MetaMaxUsage), err)
 	}
 }
 
@@ -264,20 +283,6 @@ func TestFSsessionsV1DisconnectPeer(t *testing.T) {
 	}
 }
 
-func TestFSsessionsV1AlterSession(t *testing.T) {
-	fsSessions := &FSsessions{
-
-		connMgr: &engine.ConnManager{},
-	}
-	ctx := context.Background()
-	event := utils.CGREvent{}
-	reply := ""
-	err := fsSessions.V1AlterSession(ctx, event, &reply)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected error %v, got %v", utils.ErrNotImplemented, err)
-	}
-}
-
 func TestNewFSsessions(t *testing.T) {
 	fsAgentConfig := &config.FsAgentCfg{}
 	timezone := "UTC"
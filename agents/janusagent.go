@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/birpc"
@@ -37,15 +38,21 @@ import (
 	"nhooyr.io/websocket"
 )
 
+const (
+	janusRequest        = "request"
+	janusWarnDisconnect = "warn_disconnect"
+)
+
 // NewJanusAgent will construct a JanusAgent
 func NewJanusAgent(cgrCfg *config.CGRConfig,
 	connMgr *engine.ConnManager,
 	filterS *engine.FilterS, caps *engine.Caps) (*JanusAgent, error) {
 	jsa := &JanusAgent{
-		cgrCfg:  cgrCfg,
-		connMgr: connMgr,
-		filterS: filterS,
-		caps:    caps,
+		cgrCfg:   cgrCfg,
+		connMgr:  connMgr,
+		filterS:  filterS,
+		caps:     caps,
+		chrgCtls: make(map[uint64]*janusChargingCtl),
 	}
 	srv, err := birpc.NewServiceWithMethodsRename(jsa, utils.AgentV1, true, func(oldFn string) (newFn string) {
 		return strings.TrimPrefix(oldFn, "V1")
@@ -66,6 +73,15 @@ type JanusAgent struct {
 	jnsConn *janus.Gateway
 	adminWs *websocket.Conn
 	ctx     *context.Context
+
+	chrgCtls    map[uint64]*janusChargingCtl // charging controls of the active sessions, indexed on Janus session ID
+	chrgCtlsMux sync.Mutex
+}
+
+// janusChargingCtl controls the prepaid charging of an active Janus session
+type janusChargingCtl struct {
+	maxUsageTimer *time.Timer   // destroys the session once the granted usage is consumed
+	stopDebit     chan struct{} // closed to stop the periodic debits
 }
 
 // Connect will create the connection to the Janus Server
@@ -166,10 +182,56 @@ func (ja *JanusAgent) acntStartSession(s *janus.Session) (err error) {
 		},
 		ForceDuration: true,
 	}
+	dbtItvl := ja.cgrCfg.JanusAgentCfg().DebitInterval
+	if dbtItvl > 0 {
+		initArgs.CGREvent.Event[utils.Usage] = dbtItvl
+	}
 	rply := new(sessions.V1InitSessionReply)
-	err = ja.connMgr.Call(ja.ctx, ja.cgrCfg.JanusAgentCfg().SessionSConns,
+	if err = ja.connMgr.Call(ja.ctx, ja.cgrCfg.JanusAgentCfg().SessionSConns,
 		utils.SessionSv1InitiateSession,
-		initArgs, rply)
+		initArgs, rply); err != nil {
+		return
+	}
+	if rply.MaxUsage == nil || *rply.MaxUsage < 0 { // debits are handled by SessionS
+		return
+	}
+	if dbtItvl > 0 && *rply.MaxUsage >= dbtItvl {
+		ja.startDebitLoop(s, dbtItvl)
+		return
+	}
+	ja.armMaxUsage(s.ID, *rply.MaxUsage)
+	return
+}
+
+// acntUpdateSession debits the next usage of the session, returning the usage granted by SessionS
+func (ja *JanusAgent) acntUpdateSession(s *janus.Session, usage time.Duration) (maxUsage time.Duration, err error) {
+	updateArgs := &sessions.V1UpdateSessionArgs{
+		UpdateSession: true,
+		CGREvent: &utils.CGREvent{
+			Tenant: ja.cgrCfg.GeneralCfg().DefaultTenant,
+			ID:     utils.Sha1(),
+			Time:   utils.TimePointer(time.Now()),
+			Event: map[string]any{
+				utils.AccountField: s.Data[utils.AccountField],
+				utils.OriginHost:   s.Data[utils.OriginHost],
+				utils.OriginID:     s.Data[utils.OriginID],
+				utils.Destination:  s.Data[utils.Destination],
+				utils.AnswerTime:   s.Data[utils.AnswerTime],
+				utils.Usage:        usage,
+			},
+		},
+		ForceDuration: true,
+	}
+	rply := new(sessions.V1UpdateSessionReply)
+	if err = ja.connMgr.Call(ja.ctx, ja.cgrCfg.JanusAgentCfg().SessionSConns,
+		utils.SessionSv1UpdateSession,
+		updateArgs, rply); err != nil {
+		return
+	}
+	maxUsage = usage
+	if rply.MaxUsage != nil {
+		maxUsage = *rply.MaxUsage
+	}
 	return
 }
 
@@ -219,6 +281,119 @@ func (ja *JanusAgent) cdrSession(s *janus.Session) (err error) {
 	return
 }
 
+// armMaxUsage (re)schedules the termination of the session once maxUsage is consumed
+func (ja *JanusAgent) armMaxUsage(sessionID uint64, maxUsage time.Duration) {
+	ja.chrgCtlsMux.Lock()
+	defer ja.chrgCtlsMux.Unlock()
+	ctl, has := ja.chrgCtls[sessionID]
+	if !has {
+		ctl = new(janusChargingCtl)
+		ja.chrgCtls[sessionID] = ctl
+	}
+	if ctl.maxUsageTimer != nil {
+		ctl.maxUsageTimer.Stop()
+	}
+	ctl.maxUsageTimer = time.AfterFunc(maxUsage, func() { ja.forceTerminate(sessionID) })
+}
+
+// startDebitLoop starts debiting the session on each dbtItvl
+func (ja *JanusAgent) startDebitLoop(s *janus.Session, dbtItvl time.Duration) {
+	stopDebit := make(chan struct{})
+	ja.chrgCtlsMux.Lock()
+	ctl, has := ja.chrgCtls[s.ID]
+	if !has {
+		ctl = new(janusChargingCtl)
+		ja.chrgCtls[s.ID] = ctl
+	}
+	ctl.stopDebit = stopDebit
+	ja.chrgCtlsMux.Unlock()
+	go ja.debitLoop(s, dbtItvl, stopDebit)
+}
+
+// debitLoop debits the session periodically until the balance cannot cover a full interval
+func (ja *JanusAgent) debitLoop(s *janus.Session, dbtItvl time.Duration, stopDebit chan struct{}) {
+	tkr := time.NewTicker(dbtItvl)
+	defer tkr.Stop()
+	for {
+		select {
+		case <-stopDebit:
+			return
+		case <-tkr.C:
+		}
+		maxUsage, err := ja.acntUpdateSession(s, dbtItvl)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed debiting session: %d, error: %s, terminating it",
+					utils.JanusAgent, s.ID, err.Error()))
+			ja.forceTerminate(s.ID)
+			return
+		}
+		if maxUsage < dbtItvl { // last debit, disconnect once consumed
+			ja.armMaxUsage(s.ID, maxUsage)
+			return
+		}
+	}
+}
+
+// stopCharging stops the timer and the debits of the session, returning false if none were active
+func (ja *JanusAgent) stopCharging(sessionID uint64) bool {
+	ja.chrgCtlsMux.Lock()
+	ctl, has := ja.chrgCtls[sessionID]
+	delete(ja.chrgCtls, sessionID)
+	ja.chrgCtlsMux.Unlock()
+	if !has {
+		return false
+	}
+	if ctl.maxUsageTimer != nil {
+		ctl.maxUsageTimer.Stop()
+	}
+	if ctl.stopDebit != nil {
+		close(ctl.stopDebit)
+	}
+	return true
+}
+
+// setSessionUsage computes the usage of the session out of its AnswerTime
+func (ja *JanusAgent) setSessionUsage(s *janus.Session) {
+	answerTime, _ := utils.IfaceAsTime(s.Data[utils.AnswerTime], ja.cgrCfg.GeneralCfg().DefaultTimezone)
+	var totalDur time.Duration
+	if !answerTime.IsZero() {
+		totalDur = time.Since(answerTime)
+	}
+	s.Data[utils.Usage] = totalDur // toDo: lock session RW
+}
+
+// forceTerminate ends the accounting of a session which consumed its usage and destroys it on Janus
+func (ja *JanusAgent) forceTerminate(sessionID uint64) {
+	if !ja.stopCharging(sessionID) { // already terminated
+		return
+	}
+	ja.jnsConn.RLock()
+	s, has := ja.jnsConn.Sessions[sessionID]
+	ja.jnsConn.RUnlock()
+	if !has {
+		return
+	}
+	ja.setSessionUsage(s)
+	if err := ja.acntStopSession(s); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed terminating session: %d, error: %s",
+				utils.JanusAgent, sessionID, err.Error()))
+	}
+	if err := ja.cdrSession(s); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed processing CDR for session: %d, error: %s",
+				utils.JanusAgent, sessionID, err.Error()))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if _, err := s.DestroySession(ctx, janus.BaseMsg{Type: "destroy", ID: utils.GenUUID(), Session: sessionID}); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> failed destroying session: %d, error: %s",
+				utils.JanusAgent, sessionID, err.Error()))
+	}
+}
+
 // SessioNKeepalive sends keepalive once OPTIONS are coming for the session from HTTP
 func (ja *JanusAgent) SessionKeepalive(w http.ResponseWriter, r *http.Request) {
 	janusAccessControlHeaders(w, r)
@@ -349,12 +524,8 @@ func (ja *JanusAgent) AttachPlugin(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	if msg.Type == "destroy" {
-		answerTime, _ := utils.IfaceAsTime(session.Data[utils.AnswerTime], ja.cgrCfg.GeneralCfg().DefaultTimezone)
-		var totalDur time.Duration
-		if !answerTime.IsZero() {
-			totalDur = time.Since(answerTime)
-		}
-		session.Data[utils.Usage] = totalDur // toDo: lock session RW
+		ja.stopCharging(session.ID)
+		ja.setSessionUsage(session)
 
 		go func() {
 			ja.acntStopSession(session)
@@ -509,7 +680,10 @@ func (ja *JanusAgent) V1DisconnectSession(ctx *context.Context, cgrEv utils.CGRE
 	if err != nil {
 		return err
 	}
+	ja.stopCharging(uint64(sessionID))
+	ja.jnsConn.RLock()
 	session, has := ja.jnsConn.Sessions[uint64(sessionID)]
+	ja.jnsConn.RUnlock()
 	if has {
 		id := utils.GenUUID()
 		_, err := session.DestroySession(context.Background(), janus.BaseMsg{Type: "destroy", ID: id, Session: uint64(sessionID)})
@@ -521,14 +695,75 @@ func (ja *JanusAgent) V1DisconnectSession(ctx *context.Context, cgrEv utils.CGRE
 	return nil
 }
 
-func (ja *JanusAgent) V1AlterSession(*context.Context, utils.CGREvent, *string) error {
-	return utils.ErrNotImplemented
+// V1AlterSession reschedules the disconnect of the session based on the *maxUsage option
+func (ja *JanusAgent) V1AlterSession(ctx *context.Context, cgrEv utils.CGREvent, reply *string) (err error) {
+	sessionID, err := engine.NewMapEvent(cgrEv.Event).GetTInt64(utils.OriginID)
+	if err != nil {
+		return
+	}
+	_, maxUsage, err := alterSessionArgs(cgrEv)
+	if err != nil {
+		return
+	}
+	if maxUsage == nil { // no channel variables on Janus sessions
+		return utils.NewErrMandatoryIeMissing(utils.MetaMaxUsage)
+	}
+	ja.jnsConn.RLock()
+	_, has := ja.jnsConn.Sessions[uint64(sessionID)]
+	ja.jnsConn.RUnlock()
+	if !has {
+		return utils.ErrNoActiveSession
+	}
+	ja.armMaxUsage(uint64(sessionID), *maxUsage)
+	*reply = utils.OK
+	return
 }
 
 func (ja *JanusAgent) V1DisconnectPeer(*context.Context, *utils.DPRArgs, *string) error {
 	return utils.ErrNotImplemented
 }
 
-func (ja *JanusAgent) V1WarnDisconnect(*context.Context, map[string]any, *string) error {
-	return utils.ErrNotImplemented
+// V1WarnDisconnect notifies the plugins attached to the session about the upcoming disconnect
+func (ja *JanusAgent) V1WarnDisconnect(ctx *context.Context, args map[string]any, reply *string) (err error) {
+	sessionID, err := engine.NewMapEvent(args).GetTInt64(utils.OriginID)
+	if err != nil {
+		return
+	}
+	ja.jnsConn.RLock()
+	session, has := ja.jnsConn.Sessions[uint64(sessionID)]
+	ja.jnsConn.RUnlock()
+	if !has {
+		return utils.ErrNoActiveSession
+	}
+	session.Lock()
+	handles := make([]*janus.Handle, 0, len(session.Handles))
+	for _, handle := range session.Handles {
+		handles = append(handles, handle)
+	}
+	session.Unlock()
+	jCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for _, handle := range handles {
+		if _, err = handle.Message(jCtx, janus.HandlerMessageJsep{
+			HandlerMessage: janus.HandlerMessage{
+				BaseMsg: janus.BaseMsg{
+					Type:    "message",
+					ID:      utils.GenUUID(),
+					Session: session.ID,
+					Handle:  handle.ID,
+				},
+				Handle: handle.ID,
+				Body: map[string]any{
+					janusRequest: janusWarnDisconnect,
+				},
+			},
+		}); err != nil {
+			utils.Logger.Err(
+				fmt.Sprintf("<%s> failed sending warn disconnect to session: %d, handle: %d, error: %s",
+					utils.JanusAgent, session.ID, handle.ID, err.Error()))
+			return
+		}
+	}
+	*reply = utils.OK
+	return
 }
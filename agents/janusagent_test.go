@@ -21,17 +21,35 @@ package agents
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/cgrates/birpc"
 	"github.com/cgrates/birpc/context"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
+	janus "github.com/cgrates/janusgo"
 )
 
 func TestV1WarnDisconnect(t *testing.T) {
-	ja := &JanusAgent{}
-	err := ja.V1WarnDisconnect(nil, nil, nil)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected error %v, got %v", utils.ErrNotImplemented, err)
+	ja := &JanusAgent{
+		jnsConn: &janus.Gateway{Sessions: map[uint64]*janus.Session{}},
+	}
+	var reply string
+	if err := ja.V1WarnDisconnect(nil, nil, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected error %v, got %v", utils.ErrNotFound, err)
+	}
+	if err := ja.V1WarnDisconnect(nil, map[string]any{utils.OriginID: "1234"}, &reply); err != utils.ErrNoActiveSession {
+		t.Errorf("Expected error %v, got %v", utils.ErrNoActiveSession, err)
+	}
+	ja.jnsConn.Sessions[1234] = &janus.Session{ID: 1234, Handles: map[uint64]*janus.Handle{}}
+	if err := ja.V1WarnDisconnect(nil, map[string]any{utils.OriginID: "1234"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected reply %v, got %v", utils.OK, reply)
 	}
 }
 
@@ -47,13 +65,43 @@ func TestV1DisconnectPeerJanus(t *testing.T) {
 }
 
 func TestV1AlterSession(t *testing.T) {
-	ja := &JanusAgent{}
+	ja := &JanusAgent{
+		jnsConn:  &janus.Gateway{Sessions: map[uint64]*janus.Session{}},
+		chrgCtls: make(map[uint64]*janusChargingCtl),
+	}
 	var ctx context.Context
-	var event utils.CGREvent
-	var msg *string
-	err := ja.V1AlterSession(&ctx, event, msg)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected error %v, got %v", utils.ErrNotImplemented, err)
+	var reply string
+	if err := ja.V1AlterSession(&ctx, utils.CGREvent{}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected error %v, got %v", utils.ErrNotFound, err)
+	}
+	cgrEv := utils.CGREvent{
+		Event: map[string]any{utils.OriginID: "1234"},
+		APIOpts: map[string]any{
+			utils.MetaChannelVars: map[string]any{"var1": "val1"},
+		},
+	}
+	expErr := utils.NewErrMandatoryIeMissing(utils.MetaMaxUsage)
+	if err := ja.V1AlterSession(&ctx, cgrEv, &reply); err == nil || err.Error() != expErr.Error() {
+		t.Errorf("Expected error %v, got %v", expErr, err)
+	}
+	cgrEv.APIOpts[utils.MetaMaxUsage] = "1h"
+	if err := ja.V1AlterSession(&ctx, cgrEv, &reply); err != utils.ErrNoActiveSession {
+		t.Errorf("Expected error %v, got %v", utils.ErrNoActiveSession, err)
+	}
+	ja.jnsConn.Sessions[1234] = &janus.Session{ID: 1234}
+	if err := ja.V1AlterSession(&ctx, cgrEv, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected reply %v, got %v", utils.OK, reply)
+	}
+	if ctl, has := ja.chrgCtls[1234]; !has || ctl.maxUsageTimer == nil {
+		t.Errorf("Expected max usage timer armed, received: %+v", ctl)
+	}
+	if !ja.stopCharging(1234) {
+		t.Error("Expected active charging control")
+	}
+	if ja.stopCharging(1234) {
+		t.Error("Expected charging control removed")
 	}
 }
 
@@ -91,3 +139,103 @@ func TestCORSOptions(t *testing.T) {
 		t.Errorf("Expected Access-Control-Allow-Headers header to be 'Accept, Accept-Language, Content-Type', got %v", headers)
 	}
 }
+
+func testJanusAgentWithSessionS(t *testing.T, dbtItvl time.Duration,
+	calls map[string]func(arg any, rply any) error) *JanusAgent {
+	t.Helper()
+	engine.Cache.Clear([]string{utils.CacheRPCConnections}) // the internal connections are cached by ID
+	t.Cleanup(func() { engine.Cache.Clear([]string{utils.CacheRPCConnections}) })
+	cfg := config.NewDefaultCGRConfig()
+	cfg.JanusAgentCfg().DebitInterval = dbtItvl
+	internalSessionSChan := make(chan birpc.ClientConnector, 1)
+	internalSessionSChan <- &testMockSessionConn{calls: calls}
+	connMgr := engine.NewConnManager(cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): internalSessionSChan,
+	})
+	ja, err := NewJanusAgent(cfg, connMgr, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ja.jnsConn = &janus.Gateway{Sessions: map[uint64]*janus.Session{}}
+	return ja
+}
+
+func TestJanusAgentAcntStartSessionMaxUsage(t *testing.T) {
+	maxUsage := time.Hour
+	ja := testJanusAgentWithSessionS(t, 0, map[string]func(arg any, rply any) error{
+		utils.SessionSv1InitiateSession: func(arg any, rply any) error {
+			if _, has := arg.(*sessions.V1InitSessionArgs).CGREvent.Event[utils.Usage]; has {
+				t.Error("Unexpected Usage without debit interval")
+			}
+			rply.(*sessions.V1InitSessionReply).MaxUsage = utils.DurationPointer(maxUsage)
+			return nil
+		},
+	})
+	s := &janus.Session{ID: 1234, Data: map[string]any{}}
+	if err := ja.acntStartSession(s); err != nil {
+		t.Fatal(err)
+	}
+	if ctl, has := ja.chrgCtls[s.ID]; !has || ctl.maxUsageTimer == nil || ctl.stopDebit != nil {
+		t.Errorf("Expected only max usage timer armed, received: %+v", ctl)
+	}
+	ja.stopCharging(s.ID)
+
+	maxUsage = -1 // debits handled by SessionS
+	if err := ja.acntStartSession(s); err != nil {
+		t.Fatal(err)
+	}
+	if len(ja.chrgCtls) != 0 {
+		t.Errorf("Expected no charging controls, received: %+v", ja.chrgCtls)
+	}
+}
+
+func TestJanusAgentAcntStartSessionDebitLoop(t *testing.T) {
+	dbtItvl := 10 * time.Millisecond
+	var updates int
+	var mux sync.Mutex
+	ja := testJanusAgentWithSessionS(t, dbtItvl, map[string]func(arg any, rply any) error{
+		utils.SessionSv1InitiateSession: func(arg any, rply any) error {
+			if usage := arg.(*sessions.V1InitSessionArgs).CGREvent.Event[utils.Usage]; usage != dbtItvl {
+				t.Errorf("Expected Usage %v, received: %v", dbtItvl, usage)
+			}
+			rply.(*sessions.V1InitSessionReply).MaxUsage = utils.DurationPointer(dbtItvl)
+			return nil
+		},
+		utils.SessionSv1UpdateSession: func(arg any, rply any) error {
+			if usage := arg.(*sessions.V1UpdateSessionArgs).CGREvent.Event[utils.Usage]; usage != dbtItvl {
+				t.Errorf("Expected Usage %v, received: %v", dbtItvl, usage)
+			}
+			mux.Lock()
+			updates++
+			maxUsage := dbtItvl
+			if updates == 3 { // balance exhausted
+				maxUsage = time.Millisecond
+			}
+			mux.Unlock()
+			rply.(*sessions.V1UpdateSessionReply).MaxUsage = utils.DurationPointer(maxUsage)
+			return nil
+		},
+	})
+	s := &janus.Session{ID: 1234, Data: map[string]any{}}
+	if err := ja.acntStartSession(s); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		ja.chrgCtlsMux.Lock()
+		_, active := ja.chrgCtls[s.ID]
+		ja.chrgCtlsMux.Unlock()
+		if !active {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("session charging was not terminated")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	if updates != 3 {
+		t.Errorf("Expected 3 updates, received: %d", updates)
+	}
+}
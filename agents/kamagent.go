@@ -505,9 +505,26 @@ func (ka *KamailioAgent) Reload() {
 	ka.conns = make([]*kamevapi.KamEvapi, len(ka.cfg.EvapiConns))
 }
 
-// V1AlterSession is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) V1AlterSession(*context.Context, utils.CGREvent, *string) error {
-	return utils.ErrNotImplemented
+// V1AlterSession updates the variables and the timeout of an active dialog in Kamailio
+func (ka *KamailioAgent) V1AlterSession(ctx *context.Context, cgrEv utils.CGREvent, reply *string) (err error) {
+	hEntry := utils.IfaceAsString(cgrEv.Event[KamHashEntry])
+	hID := utils.IfaceAsString(cgrEv.Event[KamHashID])
+	connIdx, err := ka.evapiConnIdx(cgrEv.Event)
+	if err != nil {
+		return
+	}
+	chanVars, maxUsage, err := alterSessionArgs(cgrEv)
+	if err != nil {
+		return
+	}
+	altEv := NewKamSessionAlter(hEntry, hID, chanVars, maxUsage)
+	if err = ka.conns[connIdx].Send(altEv.String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending alter request: %s, connection id: %v, error %s",
+			utils.KamailioAgent, altEv, connIdx, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
@@ -515,7 +532,44 @@ func (*KamailioAgent) V1DisconnectPeer(*context.Context, *utils.DPRArgs, *string
 	return utils.ErrNotImplemented
 }
 
-// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) V1WarnDisconnect(*context.Context, map[string]any, *string) error {
-	return utils.ErrNotImplemented
+// V1WarnDisconnect is called when call goes under the minimum duration threshold, so Kamailio can play an announcement message
+func (ka *KamailioAgent) V1WarnDisconnect(ctx *context.Context, args map[string]any, reply *string) (err error) {
+	if ka.cfg.LowBalanceAnnFile == utils.EmptyString {
+		*reply = utils.OK
+		return
+	}
+	connIdx, err := ka.evapiConnIdx(args)
+	if err != nil {
+		return
+	}
+	warnEv := NewKamSessionWarn(utils.IfaceAsString(args[KamHashEntry]),
+		utils.IfaceAsString(args[KamHashID]), ka.cfg.LowBalanceAnnFile)
+	if err = ka.conns[connIdx].Send(warnEv.String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending warn request: %s, connection id: %v, error %s",
+			utils.KamailioAgent, warnEv, connIdx, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// evapiConnIdx returns the index of the evapi connection the session event was received on
+func (ka *KamailioAgent) evapiConnIdx(ev map[string]any) (connIdx int, err error) {
+	connIdxIface, has := ev[EvapiConnID]
+	if !has {
+		err = utils.NewErrMandatoryIeMissing(EvapiConnID)
+		utils.Logger.Err(fmt.Sprintf("<%s> error: %s for <%s:%s> and <%s:%s>",
+			utils.KamailioAgent, err.Error(), KamHashEntry, utils.IfaceAsString(ev[KamHashEntry]),
+			KamHashID, utils.IfaceAsString(ev[KamHashID])))
+		return
+	}
+	var connIdx64 int64
+	if connIdx64, err = utils.IfaceAsTInt64(connIdxIface); err != nil {
+		return
+	}
+	if connIdx = int(connIdx64); connIdx >= len(ka.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(ka.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
+	}
+	return
 }
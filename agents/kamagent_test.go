@@ -31,13 +31,27 @@ func TestKAsSessionSClientIface(t *testing.T) {
 }
 
 func TestKamailioAgentV1WarnDisconnect(t *testing.T) {
-	agent := KamailioAgent{}
+	agent := KamailioAgent{cfg: &config.KamAgentCfg{}}
 	ctx := context.Background()
-	args := make(map[string]any)
+	args := map[string]any{
+		KamHashEntry: "3039",
+		KamHashID:    "7",
+	}
 	var reply string
-	err := agent.V1WarnDisconnect(ctx, args, &reply)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected ErrNotImplemented, got %v", err)
+	if err := agent.V1WarnDisconnect(ctx, args, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, got %q", utils.OK, reply)
+	}
+	agent.cfg.LowBalanceAnnFile = "low_balance.wav"
+	if err := agent.V1WarnDisconnect(ctx, args, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(EvapiConnID).Error() {
+		t.Errorf("Expected error %v, got %v", utils.NewErrMandatoryIeMissing(EvapiConnID), err)
+	}
+	args[EvapiConnID] = 1
+	if err := agent.V1WarnDisconnect(ctx, args, &reply); err == nil ||
+		err.Error() != "Index out of range[0,0): 1 " {
+		t.Errorf("Expected index out of range error, got %v", err)
 	}
 }
 
@@ -54,13 +68,23 @@ func TestKamailioAgentV1DisconnectPeer(t *testing.T) {
 }
 
 func TestKamailioAgentV1AlterSession(t *testing.T) {
-	agent := KamailioAgent{}
+	agent := KamailioAgent{cfg: &config.KamAgentCfg{}}
 	ctx := context.Background()
-	cgrEvent := utils.CGREvent{}
+	cgrEvent := utils.CGREvent{
+		Event: map[string]any{
+			KamHashEntry: "3039",
+			KamHashID:    "7",
+		},
+	}
 	var reply string
-	err := agent.V1AlterSession(ctx, cgrEvent, &reply)
-	if err != utils.ErrNotImplemented {
-		t.Errorf("Expected ErrNotImplemented, got %v", err)
+	if err := agent.V1AlterSession(ctx, cgrEvent, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(EvapiConnID).Error() {
+		t.Errorf("Expected error %v, got %v", utils.NewErrMandatoryIeMissing(EvapiConnID), err)
+	}
+	cgrEvent.Event[EvapiConnID] = 0
+	if err := agent.V1AlterSession(ctx, cgrEvent, &reply); err == nil ||
+		err.Error() != "Index out of range[0,0): 0 " {
+		t.Errorf("Expected index out of range error, got %v", err)
 	}
 }
 
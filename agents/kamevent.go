@@ -21,6 +21,7 @@ package agents
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -35,6 +36,8 @@ const (
 	CGR_AUTH_REQUEST       = "CGR_AUTH_REQUEST"
 	CGR_AUTH_REPLY         = "CGR_AUTH_REPLY"
 	CGR_SESSION_DISCONNECT = "CGR_SESSION_DISCONNECT"
+	CGR_SESSION_ALTER      = "CGR_SESSION_ALTER"
+	CGR_SESSION_WARN       = "CGR_SESSION_WARN"
 	CGR_CALL_START         = "CGR_CALL_START"
 	CGR_CALL_END           = "CGR_CALL_END"
 	CGR_PROCESS_MESSAGE    = "CGR_PROCESS_MESSAGE"
@@ -72,6 +75,57 @@ func (ksd *KamSessionDisconnect) String() string {
 	return utils.ToJSON(ksd)
 }
 
+// NewKamSessionAlter builds the request altering the variables and the timeout of a Kamailio dialog
+func NewKamSessionAlter(hEntry, hID string, chanVars map[string]string, maxUsage *time.Duration) *KamSessionAlter {
+	ksa := &KamSessionAlter{
+		Event:     CGR_SESSION_ALTER,
+		HashEntry: hEntry,
+		HashId:    hID,
+	}
+	for i, varName := range slices.Sorted(maps.Keys(chanVars)) {
+		if i != 0 {
+			ksa.Variables += utils.FieldsSep
+		}
+		ksa.Variables += varName + utils.InInFieldSep + chanVars[varName]
+	}
+	if maxUsage != nil {
+		ksa.MaxUsage = utils.IntPointer(int(utils.Round(maxUsage.Seconds(), 0, utils.MetaRoundingMiddle)))
+	}
+	return ksa
+}
+
+type KamSessionAlter struct {
+	Event     string
+	HashEntry string
+	HashId    string
+	Variables string // dialog variables encoded as the Attributes, key:value pairs comma separated
+	MaxUsage  *int   // new dialog timeout in seconds, nil if not altered
+}
+
+func (ksa *KamSessionAlter) String() string {
+	return utils.ToJSON(ksa)
+}
+
+// NewKamSessionWarn builds the request to play the low balance announcement on a Kamailio dialog
+func NewKamSessionWarn(hEntry, hID, annFile string) *KamSessionWarn {
+	return &KamSessionWarn{
+		Event:     CGR_SESSION_WARN,
+		HashEntry: hEntry,
+		HashId:    hID,
+		AnnFile:   annFile}
+}
+
+type KamSessionWarn struct {
+	Event     string
+	HashEntry string
+	HashId    string
+	AnnFile   string
+}
+
+func (ksw *KamSessionWarn) String() string {
+	return utils.ToJSON(ksw)
+}
+
 // NewKamEvent parses bytes received over the wire from Kamailio into KamEvent
 func NewKamEvent(kamEvData []byte, alias, adress string) (KamEvent, error) {
 	kev := make(map[string]string)
@@ -1097,3 +1097,30 @@ func TestKamReplyString(t *testing.T) {
 		})
 	}
 }
+
+func TestKameventNewKamSessionAlter(t *testing.T) {
+	got := NewKamSessionAlter("3039", "7",
+		map[string]string{"cgrSubject": "1001", "cgrCategory": "premium"},
+		utils.DurationPointer(90*time.Second+400*time.Millisecond))
+	want := &KamSessionAlter{
+		Event:     CGR_SESSION_ALTER,
+		HashEntry: "3039",
+		HashId:    "7",
+		Variables: "cgrCategory:premium,cgrSubject:1001",
+		MaxUsage:  utils.IntPointer(90),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("NewKamSessionAlter() mismatch (-got +want):\n%s", diff)
+	}
+	exp := `{"Event":"CGR_SESSION_ALTER","HashEntry":"3039","HashId":"7","Variables":"","MaxUsage":null}`
+	if rcv := NewKamSessionAlter("3039", "7", nil, nil).String(); rcv != exp {
+		t.Errorf("Expected %s, received %s", exp, rcv)
+	}
+}
+
+func TestKameventNewKamSessionWarn(t *testing.T) {
+	exp := `{"Event":"CGR_SESSION_WARN","HashEntry":"3039","HashId":"7","AnnFile":"low_balance.wav"}`
+	if rcv := NewKamSessionWarn("3039", "7", "low_balance.wav").String(); rcv != exp {
+		t.Errorf("Expected %s, received %s", exp, rcv)
+	}
+}
@@ -305,3 +305,32 @@ func processRequest(ctx *context.Context, reqProcessor *config.RequestProcessor,
 	}
 	return true, nil
 }
+
+// alterSessionArgs extracts from the APIOpts of an AlterSession request the
+// channel variables to set and the new maximum usage of the session
+func alterSessionArgs(cgrEv utils.CGREvent) (chanVars map[string]string, maxUsage *time.Duration, err error) {
+	if varsIface, has := cgrEv.APIOpts[utils.MetaChannelVars]; has {
+		switch vars := varsIface.(type) {
+		case map[string]string:
+			chanVars = vars
+		case map[string]any:
+			chanVars = make(map[string]string, len(vars))
+			for key, val := range vars {
+				chanVars[key] = utils.IfaceAsString(val)
+			}
+		default:
+			return nil, nil, fmt.Errorf("cannot convert %s: %s to map", utils.MetaChannelVars, utils.ToJSON(varsIface))
+		}
+	}
+	var usage time.Duration
+	if usage, err = cgrEv.OptAsDuration(utils.MetaMaxUsage); err == nil {
+		maxUsage = &usage
+	} else if err != utils.ErrNotFound {
+		return
+	}
+	err = nil
+	if len(chanVars) == 0 && maxUsage == nil {
+		err = utils.NewErrMandatoryIeMissing(utils.MetaChannelVars, utils.MetaMaxUsage)
+	}
+	return
+}
@@ -0,0 +1,62 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLibAgentsAlterSessionArgs(t *testing.T) {
+	ev := utils.CGREvent{APIOpts: map[string]any{}}
+	expErr := utils.NewErrMandatoryIeMissing(utils.MetaChannelVars, utils.MetaMaxUsage)
+	if _, _, err := alterSessionArgs(ev); err == nil || err.Error() != expErr.Error() {
+		t.Errorf("Expected error %v, received %v", expErr, err)
+	}
+	ev.APIOpts[utils.MetaChannelVars] = map[string]any{"cgr_subject": "1001", "cgr_maxcost": 1.5}
+	ev.APIOpts[utils.MetaMaxUsage] = "1m"
+	expVars := map[string]string{"cgr_subject": "1001", "cgr_maxcost": "1.5"}
+	if chanVars, maxUsage, err := alterSessionArgs(ev); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(chanVars, expVars) {
+		t.Errorf("Expected %v, received %v", expVars, chanVars)
+	} else if maxUsage == nil || *maxUsage != time.Minute {
+		t.Errorf("Expected %v, received %v", time.Minute, maxUsage)
+	}
+	ev.APIOpts[utils.MetaChannelVars] = map[string]string{"cgr_subject": "1001"}
+	delete(ev.APIOpts, utils.MetaMaxUsage)
+	if chanVars, maxUsage, err := alterSessionArgs(ev); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(chanVars, map[string]string{"cgr_subject": "1001"}) {
+		t.Errorf("Unexpected channel variables: %v", chanVars)
+	} else if maxUsage != nil {
+		t.Errorf("Expected no maximum usage, received %v", *maxUsage)
+	}
+	ev.APIOpts[utils.MetaMaxUsage] = "invalid"
+	if _, _, err := alterSessionArgs(ev); err == nil {
+		t.Error("Expected error for invalid maximum usage")
+	}
+	ev.APIOpts[utils.MetaChannelVars] = []string{"cgr_subject"}
+	if _, _, err := alterSessionArgs(ev); err == nil {
+		t.Error("Expected error for invalid channel variables")
+	}
+}
@@ -19,8 +19,11 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -590,31 +593,44 @@ func updateDnsAnswer(q []dns.RR, qType uint16, qName string, path []string, valu
 		}
 	}
 
+	if len(path) == 2 && path[0] == utils.DNSHdr && path[1] == utils.DNSRrtype {
+		// changing the type of the answer (ie: CNAME followed by A) replaces the record
+		var rrType uint16
+		if rrType, err = dnsTypeFromIface(value); err != nil {
+			return q, err
+		}
+		hdr := q[idx].Header()
+		if hdr.Rrtype == rrType {
+			return q, nil
+		}
+		var a dns.RR
+		if a, err = newDNSAnswer(rrType, hdr.Name); err != nil {
+			return q, err
+		}
+		newHdr := a.Header()
+		*newHdr = *hdr
+		newHdr.Rrtype = rrType
+		q[idx] = a
+		return q, nil
+	}
+
 	switch v := q[idx].(type) {
 	case *dns.NAPTR:
 		err = updateDnsNAPTRAnswer(v, path, value)
 	case *dns.SRV:
 		err = updateDnsSRVAnswer(v, path, value)
 	case *dns.A:
-		if len(path) < 1 ||
-			(path[0] != utils.DNSHdr && len(path) != 1) ||
-			(path[0] == utils.DNSHdr && len(path) != 2) {
-			err = utils.ErrWrongPath
-			return
-		}
-		switch path[0] {
-		case utils.DNSHdr:
-			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
-		case utils.DNSA:
-			v.A = net.ParseIP(utils.IfaceAsString(value))
-			if v.A == nil {
-				err = fmt.Errorf("invalid IP address <%v>",
-					utils.IfaceAsString(value))
-				return
-			}
-		default:
-			err = utils.ErrWrongPath
-		}
+		err = updateDnsAAnswer(v, path, value)
+	case *dns.AAAA:
+		err = updateDnsAAAAAnswer(v, path, value)
+	case *dns.TXT:
+		err = updateDnsTXTAnswer(v, path, value)
+	case *dns.CNAME:
+		err = updateDnsCNAMEAnswer(v, path, value)
+	case *dns.PTR:
+		err = updateDnsPTRAnswer(v, path, value)
+	case *dns.MX:
+		err = updateDnsMXAnswer(v, path, value)
 	case nil:
 		err = fmt.Errorf("unsupported dns option type <%T>", v)
 	default:
@@ -639,81 +655,171 @@ func newDNSAnswer(qType uint16, qName string) (a dns.RR, err error) {
 		a = &dns.NAPTR{Hdr: hdr}
 	case dns.TypeSRV:
 		a = &dns.SRV{Hdr: hdr}
+	case dns.TypeAAAA:
+		a = &dns.AAAA{Hdr: hdr}
+	case dns.TypeTXT:
+		a = &dns.TXT{Hdr: hdr}
+	case dns.TypeCNAME:
+		a = &dns.CNAME{Hdr: hdr}
+	case dns.TypePTR:
+		a = &dns.PTR{Hdr: hdr}
+	case dns.TypeMX:
+		a = &dns.MX{Hdr: hdr}
 	default:
 		err = fmt.Errorf("unsupported DNS type: <%v>", dns.TypeToString[qType])
 	}
 	return
 }
 
-func updateDnsSRVAnswer(v *dns.SRV, path []string, value any) (err error) {
+// updateDnsRRAnswer validates the path of an answer field, updating the header
+// itself and leaving the record specific fields to updFld
+func updateDnsRRAnswer(hdr *dns.RR_Header, path []string, value any,
+	updFld func(fld string) error) error {
 	if len(path) < 1 ||
 		(path[0] != utils.DNSHdr && len(path) != 1) ||
 		(path[0] == utils.DNSHdr && len(path) != 2) {
-		err = utils.ErrWrongPath
-		return
+		return utils.ErrWrongPath
 	}
-	switch path[0] {
-	case utils.DNSHdr:
-		err = updateDnsRRHeader(&v.Hdr, path[1:], value)
-	case utils.DNSPriority:
-		var vItm int64
-		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
-			return
+	if path[0] == utils.DNSHdr {
+		return updateDnsRRHeader(hdr, path[1:], value)
+	}
+	return updFld(path[0])
+}
+
+func updateDnsAAnswer(v *dns.A, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) error {
+		if fld != utils.DNSA {
+			return utils.ErrWrongPath
 		}
-		v.Priority = uint16(vItm)
-	case utils.Weight:
-		var vItm int64
-		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
-			return
+		if v.A = net.ParseIP(utils.IfaceAsString(value)); v.A == nil {
+			return fmt.Errorf("invalid IP address <%v>",
+				utils.IfaceAsString(value))
 		}
-		v.Weight = uint16(vItm)
-	case utils.DNSPort:
-		var vItm int64
-		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
-			return
+		return nil
+	})
+}
+
+func updateDnsSRVAnswer(v *dns.SRV, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) (err error) {
+		switch fld {
+		case utils.DNSPriority:
+			var vItm int64
+			if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+				return
+			}
+			v.Priority = uint16(vItm)
+		case utils.Weight:
+			var vItm int64
+			if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+				return
+			}
+			v.Weight = uint16(vItm)
+		case utils.DNSPort:
+			var vItm int64
+			if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+				return
+			}
+			v.Port = uint16(vItm)
+		case utils.DNSTarget:
+			v.Target = utils.IfaceAsString(value)
+		default:
+			err = utils.ErrWrongPath
 		}
-		v.Port = uint16(vItm)
-	case utils.DNSTarget:
-		v.Target = utils.IfaceAsString(value)
-	default:
-		err = utils.ErrWrongPath
-	}
-	return
+		return
+	})
 }
 
-func updateDnsNAPTRAnswer(v *dns.NAPTR, path []string, value any) (err error) {
-	if len(path) < 1 ||
-		(path[0] != utils.DNSHdr && len(path) != 1) ||
-		(path[0] == utils.DNSHdr && len(path) != 2) {
-		return utils.ErrWrongPath
-	}
-	switch path[0] {
-	case utils.DNSHdr:
-		return updateDnsRRHeader(&v.Hdr, path[1:], value)
-	case utils.Order:
-		var vItm int64
-		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
-			return
+func updateDnsAAAAAnswer(v *dns.AAAA, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) error {
+		if fld != utils.DNSAAAA {
+			return utils.ErrWrongPath
+		}
+		if v.AAAA = net.ParseIP(utils.IfaceAsString(value)); v.AAAA == nil ||
+			v.AAAA.To4() != nil {
+			return fmt.Errorf("invalid IPv6 address <%v>",
+				utils.IfaceAsString(value))
+		}
+		return nil
+	})
+}
+
+// updateDnsTXTAnswer appends each value as a new character-string of the record
+func updateDnsTXTAnswer(v *dns.TXT, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) error {
+		if fld != utils.DNSTxt {
+			return utils.ErrWrongPath
+		}
+		v.Txt = append(v.Txt, utils.IfaceAsString(value))
+		return nil
+	})
+}
+
+func updateDnsCNAMEAnswer(v *dns.CNAME, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) error {
+		if fld != utils.DNSTarget {
+			return utils.ErrWrongPath
+		}
+		v.Target = dns.Fqdn(utils.IfaceAsString(value))
+		return nil
+	})
+}
+
+func updateDnsPTRAnswer(v *dns.PTR, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) error {
+		if fld != utils.DNSPtr {
+			return utils.ErrWrongPath
+		}
+		v.Ptr = dns.Fqdn(utils.IfaceAsString(value))
+		return nil
+	})
+}
+
+func updateDnsMXAnswer(v *dns.MX, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) (err error) {
+		switch fld {
+		case utils.Preference:
+			var vItm int64
+			if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+				return
+			}
+			v.Preference = uint16(vItm)
+		case utils.DNSMx:
+			v.Mx = dns.Fqdn(utils.IfaceAsString(value))
+		default:
+			err = utils.ErrWrongPath
 		}
-		v.Order = uint16(vItm)
-	case utils.Preference:
-		var vItm int64
-		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
-			return
+		return
+	})
+}
+
+func updateDnsNAPTRAnswer(v *dns.NAPTR, path []string, value any) error {
+	return updateDnsRRAnswer(&v.Hdr, path, value, func(fld string) (err error) {
+		switch fld {
+		case utils.Order:
+			var vItm int64
+			if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+				return
+			}
+			v.Order = uint16(vItm)
+		case utils.Preference:
+			var vItm int64
+			if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+				return
+			}
+			v.Preference = uint16(vItm)
+		case utils.Flags:
+			v.Flags = utils.IfaceAsString(value)
+		case utils.Service:
+			v.Service = utils.IfaceAsString(value)
+		case utils.Regexp:
+			v.Regexp = utils.IfaceAsString(value)
+		case utils.Replacement:
+			v.Replacement = utils.IfaceAsString(value)
+		default:
+			err = utils.ErrWrongPath
 		}
-		v.Preference = uint16(vItm)
-	case utils.Flags:
-		v.Flags = utils.IfaceAsString(value)
-	case utils.Service:
-		v.Service = utils.IfaceAsString(value)
-	case utils.Regexp:
-		v.Regexp = utils.IfaceAsString(value)
-	case utils.Replacement:
-		v.Replacement = utils.IfaceAsString(value)
-	default:
-		return utils.ErrWrongPath
-	}
-	return
+		return
+	})
 }
 
 func updateDnsRRHeader(v *dns.RR_Header, path []string, value any) (err error) {
@@ -724,11 +830,7 @@ func updateDnsRRHeader(v *dns.RR_Header, path []string, value any) (err error) {
 	case utils.DNSName:
 		v.Name = utils.IfaceAsString(value)
 	case utils.DNSRrtype:
-		var vItm int64
-		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
-			return
-		}
-		v.Rrtype = uint16(vItm)
+		v.Rrtype, err = dnsTypeFromIface(value)
 	case utils.DNSClass:
 		var vItm int64
 		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
@@ -752,3 +854,118 @@ func updateDnsRRHeader(v *dns.RR_Header, path []string, value any) (err error) {
 	}
 	return
 }
+
+// dnsTypeFromIface returns the DNS type out of its name (ie: AAAA) or its numeric value
+func dnsTypeFromIface(value any) (uint16, error) {
+	if rrType, has := dns.StringToType[strings.ToUpper(utils.IfaceAsString(value))]; has {
+		return rrType, nil
+	}
+	vItm, err := utils.IfaceAsTInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(vItm), nil
+}
+
+// dohHandler serves DNS-over-HTTPS requests as described in RFC 8484
+// passing the DNS messages to the same handler as the other listeners
+type dohHandler struct {
+	handleMessage func(dns.ResponseWriter, *dns.Msg)
+}
+
+func (h *dohHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf []byte
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		dnsParam := r.URL.Query().Get("dns")
+		if dnsParam == utils.EmptyString {
+			http.Error(w, "missing dns parameter", http.StatusBadRequest)
+			return
+		}
+		if buf, err = base64.RawURLEncoding.DecodeString(
+			strings.TrimRight(dnsParam, "=")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodPost:
+		if r.Header.Get(utils.ContentType) != utils.DNSMessageContentType {
+			http.Error(w, http.StatusText(http.StatusUnsupportedMediaType),
+				http.StatusUnsupportedMediaType)
+			return
+		}
+		if buf, err = io.ReadAll(io.LimitReader(r.Body, dns.MaxMsgSize)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", http.MethodGet+utils.FieldsSep+http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed),
+			http.StatusMethodNotAllowed)
+		return
+	}
+	req := new(dns.Msg)
+	if err = req.Unpack(buf); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dohW := &dohResponseWriter{w: w}
+	if dohW.rmtAddr, err = net.ResolveTCPAddr(utils.TCP, r.RemoteAddr); err != nil {
+		dohW.rmtAddr = &net.TCPAddr{}
+	}
+	dohW.lclAddr, _ = r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	h.handleMessage(dohW, req)
+}
+
+// dohResponseWriter implements dns.ResponseWriter on top of the HTTP response
+type dohResponseWriter struct {
+	w       http.ResponseWriter
+	lclAddr net.Addr
+	rmtAddr net.Addr
+	written bool // only the first reply is sent back to the client
+}
+
+func (dw *dohResponseWriter) LocalAddr() net.Addr  { return dw.lclAddr }
+func (dw *dohResponseWriter) RemoteAddr() net.Addr { return dw.rmtAddr }
+
+func (dw *dohResponseWriter) WriteMsg(m *dns.Msg) (err error) {
+	var buf []byte
+	if buf, err = m.Pack(); err != nil {
+		return
+	}
+	if minTTL, has := dnsMinTTL(m); has {
+		dw.w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", minTTL))
+	}
+	_, err = dw.Write(buf)
+	return
+}
+
+func (dw *dohResponseWriter) Write(buf []byte) (int, error) {
+	if dw.written {
+		return 0, fmt.Errorf("reply already written")
+	}
+	dw.written = true
+	dw.w.Header().Set(utils.ContentType, utils.DNSMessageContentType)
+	dw.w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
+	return dw.w.Write(buf)
+}
+
+func (dw *dohResponseWriter) Close() error        { return nil }
+func (dw *dohResponseWriter) TsigStatus() error   { return nil }
+func (dw *dohResponseWriter) TsigTimersOnly(bool) {}
+func (dw *dohResponseWriter) Hijack()             {}
+
+// dnsMinTTL returns the smallest TTL out of the records of the message
+func dnsMinTTL(m *dns.Msg) (minTTL uint32, has bool) {
+	for _, sect := range [][]dns.RR{m.Answer, m.Ns, m.Extra} {
+		for _, rr := range sect {
+			if rr.Header().Rrtype == dns.TypeOPT {
+				continue
+			}
+			if ttl := rr.Header().Ttl; !has || ttl < minTTL {
+				minTTL, has = ttl, true
+			}
+		}
+	}
+	return
+}
@@ -19,8 +19,12 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -1901,3 +1905,246 @@ func TestNewDnsDP(t *testing.T) {
 	}
 
 }
+
+func TestLibDnsNewDNSAnswerRecordTypes(t *testing.T) {
+	for qType, exp := range map[uint16]dns.RR{
+		dns.TypeAAAA:  &dns.AAAA{},
+		dns.TypeTXT:   &dns.TXT{},
+		dns.TypeCNAME: &dns.CNAME{},
+		dns.TypePTR:   &dns.PTR{},
+		dns.TypeMX:    &dns.MX{},
+	} {
+		a, err := newDNSAnswer(qType, "cgrates.org.")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reflect.TypeOf(a) != reflect.TypeOf(exp) {
+			t.Errorf("expected %T, received %T", exp, a)
+		}
+		if hdr := a.Header(); hdr.Rrtype != qType || hdr.Name != "cgrates.org." ||
+			hdr.Class != dns.ClassINET || hdr.Ttl != 60 {
+			t.Errorf("unexpected header: %s", utils.ToJSON(hdr))
+		}
+	}
+}
+
+func TestLibDnsUpdateDnsAAAAAnswer(t *testing.T) {
+	v := &dns.AAAA{}
+	if err := updateDnsAAAAAnswer(v, []string{utils.DNSAAAA}, "2001:db8::1"); err != nil {
+		t.Error(err)
+	} else if v.AAAA.String() != "2001:db8::1" {
+		t.Errorf("expected <2001:db8::1>, received <%s>", v.AAAA)
+	}
+	if err := updateDnsAAAAAnswer(v, []string{utils.DNSHdr, utils.DNSTtl}, 120); err != nil {
+		t.Error(err)
+	} else if v.Hdr.Ttl != 120 {
+		t.Errorf("expected <120>, received <%d>", v.Hdr.Ttl)
+	}
+	errExp := "invalid IPv6 address <51.38.77.188>"
+	if err := updateDnsAAAAAnswer(v, []string{utils.DNSAAAA}, "51.38.77.188"); err == nil || err.Error() != errExp {
+		t.Errorf("expected %q, received %v", errExp, err)
+	}
+	if err := updateDnsAAAAAnswer(v, []string{utils.DNSA}, "2001:db8::1"); err != utils.ErrWrongPath {
+		t.Errorf("expected %v, received %v", utils.ErrWrongPath, err)
+	}
+	if err := updateDnsAAAAAnswer(v, []string{utils.DNSHdr}, 120); err != utils.ErrWrongPath {
+		t.Errorf("expected %v, received %v", utils.ErrWrongPath, err)
+	}
+}
+
+func TestLibDnsUpdateDnsTXTAnswer(t *testing.T) {
+	v := &dns.TXT{}
+	for _, txt := range []string{"carrier=cgrates", "v=spf1 -all"} {
+		if err := updateDnsTXTAnswer(v, []string{utils.DNSTxt}, txt); err != nil {
+			t.Error(err)
+		}
+	}
+	if exp := []string{"carrier=cgrates", "v=spf1 -all"}; !reflect.DeepEqual(v.Txt, exp) {
+		t.Errorf("expected %q, received %q", exp, v.Txt)
+	}
+	if err := updateDnsTXTAnswer(v, []string{utils.DNSTarget}, "cgrates.org"); err != utils.ErrWrongPath {
+		t.Errorf("expected %v, received %v", utils.ErrWrongPath, err)
+	}
+}
+
+func TestLibDnsUpdateDnsCNAMEAnswer(t *testing.T) {
+	v := &dns.CNAME{}
+	if err := updateDnsCNAMEAnswer(v, []string{utils.DNSTarget}, "cgrates.org"); err != nil {
+		t.Error(err)
+	} else if v.Target != "cgrates.org." {
+		t.Errorf("expected <cgrates.org.>, received <%s>", v.Target)
+	}
+	if err := updateDnsCNAMEAnswer(v, []string{utils.DNSPtr}, "cgrates.org"); err != utils.ErrWrongPath {
+		t.Errorf("expected %v, received %v", utils.ErrWrongPath, err)
+	}
+}
+
+func TestLibDnsUpdateDnsPTRAnswer(t *testing.T) {
+	v := &dns.PTR{}
+	if err := updateDnsPTRAnswer(v, []string{utils.DNSPtr}, "cgrates.org."); err != nil {
+		t.Error(err)
+	} else if v.Ptr != "cgrates.org." {
+		t.Errorf("expected <cgrates.org.>, received <%s>", v.Ptr)
+	}
+	if err := updateDnsPTRAnswer(v, []string{utils.DNSTarget}, "cgrates.org"); err != utils.ErrWrongPath {
+		t.Errorf("expected %v, received %v", utils.ErrWrongPath, err)
+	}
+}
+
+func TestLibDnsUpdateDnsMXAnswer(t *testing.T) {
+	v := &dns.MX{}
+	if err := updateDnsMXAnswer(v, []string{utils.Preference}, "10"); err != nil {
+		t.Error(err)
+	}
+	if err := updateDnsMXAnswer(v, []string{utils.DNSMx}, "mail.cgrates.org"); err != nil {
+		t.Error(err)
+	}
+	if v.Preference != 10 || v.Mx != "mail.cgrates.org." {
+		t.Errorf("unexpected answer: %s", v)
+	}
+	if err := updateDnsMXAnswer(v, []string{utils.Preference}, "notANumber"); err == nil {
+		t.Error("expected error for invalid preference")
+	}
+	if err := updateDnsMXAnswer(v, []string{utils.DNSTarget}, "cgrates.org"); err != utils.ErrWrongPath {
+		t.Errorf("expected %v, received %v", utils.ErrWrongPath, err)
+	}
+}
+
+func TestLibDnsUpdateDnsAnswerMultipleRecords(t *testing.T) {
+	var q []dns.RR
+	var err error
+	for _, upd := range []struct {
+		path  []string
+		value any
+	}{
+		{[]string{utils.DNSHdr, utils.DNSRrtype}, "CNAME"},
+		{[]string{utils.DNSTarget}, "cgrates.org"},
+		{[]string{"1", utils.DNSHdr, utils.DNSName}, "cgrates.org."},
+		{[]string{"1", utils.DNSHdr, utils.DNSTtl}, 120},
+		{[]string{"1", utils.DNSA}, "51.38.77.188"},
+	} {
+		if q, err = updateDnsAnswer(q, dns.TypeA, "www.cgrates.org.", upd.path, upd.value, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(q) != 2 {
+		t.Fatalf("expected 2 answers, received: %s", utils.ToJSON(q))
+	}
+	if cname, canCast := q[0].(*dns.CNAME); !canCast {
+		t.Errorf("expected *dns.CNAME, received %T", q[0])
+	} else if cname.Hdr.Name != "www.cgrates.org." || cname.Hdr.Rrtype != dns.TypeCNAME ||
+		cname.Hdr.Ttl != 60 || cname.Target != "cgrates.org." {
+		t.Errorf("unexpected answer: %s", cname)
+	}
+	if a, canCast := q[1].(*dns.A); !canCast {
+		t.Errorf("expected *dns.A, received %T", q[1])
+	} else if a.Hdr.Name != "cgrates.org." || a.Hdr.Ttl != 120 || a.A.String() != "51.38.77.188" {
+		t.Errorf("unexpected answer: %s", a)
+	}
+	// the header type cannot change to one without a supported payload
+	if _, err = updateDnsAnswer(q, dns.TypeA, "www.cgrates.org.",
+		[]string{"1", utils.DNSHdr, utils.DNSRrtype}, "AFSDB", false); err == nil ||
+		err.Error() != "unsupported DNS type: <AFSDB>" {
+		t.Errorf("expected unsupported DNS type error, received: %v", err)
+	}
+	if q[1].Header().Rrtype != dns.TypeA {
+		t.Errorf("expected the answer to remain unchanged, received: %s", q[1])
+	}
+}
+
+func TestLibDnsDNSTypeFromIface(t *testing.T) {
+	for value, exp := range map[any]uint16{
+		"AAAA":       dns.TypeAAAA,
+		"cname":      dns.TypeCNAME,
+		"15":         dns.TypeMX,
+		int64(12):    dns.TypePTR,
+		utils.DNSTxt: dns.TypeTXT,
+	} {
+		if rcv, err := dnsTypeFromIface(value); err != nil {
+			t.Error(err)
+		} else if rcv != exp {
+			t.Errorf("expected <%d> for <%v>, received <%d>", exp, value, rcv)
+		}
+	}
+	if _, err := dnsTypeFromIface("notAType"); err == nil {
+		t.Error("expected error for invalid type")
+	}
+}
+
+func TestLibDnsDoHHandler(t *testing.T) {
+	var rcvReq *dns.Msg
+	var rmtAddr string
+	h := &dohHandler{handleMessage: func(w dns.ResponseWriter, req *dns.Msg) {
+		rcvReq = req
+		rmtAddr = w.RemoteAddr().String()
+		rply := newDnsReply(req)
+		rply.Answer = append(rply.Answer,
+			&dns.A{Hdr: dns.RR_Header{Name: "cgrates.org.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 120},
+				A: net.ParseIP("51.38.77.188")},
+			&dns.A{Hdr: dns.RR_Header{Name: "cgrates.org.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 30},
+				A: net.ParseIP("51.38.77.189")})
+		dnsWriteMsg(w, rply)
+	}}
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeA)
+	buf, err := m.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	postReq := httptest.NewRequest(http.MethodPost, utils.DoHPath, bytes.NewReader(buf))
+	postReq.Header.Set(utils.ContentType, utils.DNSMessageContentType)
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, utils.DoHPath+"?dns="+base64.RawURLEncoding.EncodeToString(buf), nil),
+		postReq,
+	} {
+		rcvReq = nil
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("<%s> unexpected status code: %d", req.Method, rec.Code)
+		}
+		if rcvReq == nil || len(rcvReq.Question) != 1 || rcvReq.Question[0].Name != "cgrates.org." {
+			t.Errorf("<%s> unexpected request: %v", req.Method, rcvReq)
+		}
+		if rmtAddr != req.RemoteAddr {
+			t.Errorf("<%s> expected remote address %q, received %q", req.Method, req.RemoteAddr, rmtAddr)
+		}
+		if ct := rec.Header().Get(utils.ContentType); ct != utils.DNSMessageContentType {
+			t.Errorf("<%s> expected content type %q, received %q", req.Method, utils.DNSMessageContentType, ct)
+		}
+		if cc := rec.Header().Get("Cache-Control"); cc != "max-age=30" {
+			t.Errorf("<%s> expected %q, received %q", req.Method, "max-age=30", cc)
+		}
+		rply := new(dns.Msg)
+		if err := rply.Unpack(rec.Body.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		if rply.Id != m.Id || len(rply.Answer) != 2 {
+			t.Errorf("<%s> unexpected reply: %v", req.Method, rply)
+		}
+	}
+}
+
+func TestLibDnsDoHHandlerErrors(t *testing.T) {
+	h := &dohHandler{handleMessage: func(w dns.ResponseWriter, req *dns.Msg) {
+		t.Errorf("unexpected message: %v", req)
+	}}
+	postReq := httptest.NewRequest(http.MethodPost, utils.DoHPath, strings.NewReader("notADNSMessage"))
+	postReq.Header.Set(utils.ContentType, utils.DNSMessageContentType)
+	for _, tc := range []struct {
+		req  *http.Request
+		code int
+	}{
+		{httptest.NewRequest(http.MethodGet, utils.DoHPath, nil), http.StatusBadRequest},
+		{httptest.NewRequest(http.MethodGet, utils.DoHPath+"?dns=%%%", nil), http.StatusBadRequest},
+		{httptest.NewRequest(http.MethodPost, utils.DoHPath, strings.NewReader("")), http.StatusUnsupportedMediaType},
+		{httptest.NewRequest(http.MethodPut, utils.DoHPath, nil), http.StatusMethodNotAllowed},
+		{postReq, http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, tc.req)
+		if rec.Code != tc.code {
+			t.Errorf("<%s %s> expected status code %d, received %d", tc.req.Method, tc.req.URL, tc.code, rec.Code)
+		}
+	}
+}
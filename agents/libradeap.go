@@ -0,0 +1,286 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+const (
+	radUserPasswordAttr  = 2
+	radStateAttr         = 24
+	radEAPMessageAttr    = 79
+	radMsgAuthAttr       = 80
+	radMicrosoftVendorID = 311
+	radMSMPPESendKey     = 16
+	radMSMPPERecvKey     = 17
+	radMsgAuthLen        = md5.Size
+	radEAPStatePrefix    = "*eap"
+)
+
+// radAttributesBytes encodes the attributes of the packet for the Message-Authenticator computation,
+// with the Message-Authenticator zeroed and the User-Password encrypted back with the secret.
+func radAttributesBytes(pkt *radigo.Packet, secret string) []byte {
+	var attrs []byte
+	for _, avp := range pkt.AVPs {
+		val := avp.RawValue
+		switch avp.Number {
+		case radMsgAuthAttr:
+			val = make([]byte, radMsgAuthLen)
+		case radUserPasswordAttr:
+			if pkt.Code == radigo.AccessRequest { // decrypted by radigo when decoding
+				val = radigo.EncodeUserPassword(val, []byte(secret), pkt.Authenticator[:])
+			}
+		}
+		attrs = append(attrs, avp.Number, uint8(len(val)+2))
+		attrs = append(attrs, val...)
+	}
+	return attrs
+}
+
+// radMessageAuthenticator computes the Message-Authenticator (RFC 3579 3.2) of the packet,
+// using the authenticator of the request for both requests and replies.
+func radMessageAuthenticator(pkt *radigo.Packet, reqAuthenticator [16]byte, secret string) []byte {
+	attrs := radAttributesBytes(pkt, secret)
+	hdr := make([]byte, radHeaderLen)
+	hdr[0] = uint8(pkt.Code)
+	hdr[1] = pkt.Identifier
+	binary.BigEndian.PutUint16(hdr[2:4], uint16(radHeaderLen+len(attrs)))
+	copy(hdr[4:], reqAuthenticator[:])
+	mac := hmac.New(md5.New, []byte(secret))
+	mac.Write(hdr)
+	mac.Write(attrs)
+	return mac.Sum(nil)
+}
+
+// radCheckMessageAuthenticator validates the Message-Authenticator of a packet,
+// returning an error if it is invalid or missing when mandatory.
+func radCheckMessageAuthenticator(pkt *radigo.Packet, reqAuthenticator [16]byte,
+	secret string, mandatory bool) error {
+	var msgAuth []byte
+	for _, avp := range pkt.AVPs {
+		if avp.Number == radMsgAuthAttr {
+			msgAuth = avp.RawValue
+			break
+		}
+	}
+	if msgAuth == nil {
+		if mandatory {
+			return errors.New("missing Message-Authenticator")
+		}
+		return nil
+	}
+	if !hmac.Equal(msgAuth, radMessageAuthenticator(pkt, reqAuthenticator, secret)) {
+		return errors.New("invalid Message-Authenticator")
+	}
+	return nil
+}
+
+// radSetMessageAuthenticator signs the packet with a Message-Authenticator,
+// replacing any previous one. Needs to be the last change before encoding.
+func radSetMessageAuthenticator(pkt *radigo.Packet, reqAuthenticator [16]byte, secret string) {
+	avps := make([]*radigo.AVP, 0, len(pkt.AVPs)+1)
+	for _, avp := range pkt.AVPs {
+		if avp.Number != radMsgAuthAttr {
+			avps = append(avps, avp)
+		}
+	}
+	msgAuth := &radigo.AVP{Number: radMsgAuthAttr, RawValue: make([]byte, radMsgAuthLen)}
+	pkt.AVPs = append(avps, msgAuth)
+	msgAuth.RawValue = radMessageAuthenticator(pkt, reqAuthenticator, secret)
+}
+
+// radAttribute returns the raw value of the first attribute with the given number
+func radAttribute(pkt *radigo.Packet, attrNr uint8) []byte {
+	for _, avp := range pkt.AVPs {
+		if avp.Number == attrNr {
+			return avp.RawValue
+		}
+	}
+	return nil
+}
+
+// radIsAuthenticReply checks the Response Authenticator of a reply to the request with reqAuthenticator
+func radIsAuthenticReply(rawPkt []byte, reqAuthenticator [16]byte, secret string) bool {
+	if len(rawPkt) < radHeaderLen {
+		return false
+	}
+	hash := md5.New()
+	hash.Write(rawPkt[:4])
+	hash.Write(reqAuthenticator[:])
+	hash.Write(rawPkt[radHeaderLen:])
+	hash.Write([]byte(secret))
+	return bytes.Equal(hash.Sum(nil), rawPkt[4:radHeaderLen])
+}
+
+// radMPPEKeyCrypt encrypts or decrypts the MS-MPPE-Send/Recv-Key string (RFC 2548 2.4.2),
+// without the salt, with the secret and the authenticator of the request.
+func radMPPEKeyCrypt(data, salt []byte, secret string, reqAuthenticator [16]byte, decrypt bool) []byte {
+	out := make([]byte, len(data))
+	prev := append(reqAuthenticator[:], salt...)
+	for i := 0; i < len(data); i += md5.Size {
+		hash := md5.New()
+		hash.Write([]byte(secret))
+		hash.Write(prev)
+		b := hash.Sum(nil)
+		end := min(i+md5.Size, len(data))
+		for j := i; j < end; j++ {
+			out[j] = data[j] ^ b[j-i]
+		}
+		if decrypt {
+			prev = data[i:end]
+		} else {
+			prev = out[i:end]
+		}
+	}
+	return out
+}
+
+// radReencryptMPPEKey returns the value of a Vendor-Specific attribute holding one MS-MPPE key
+// encrypted for the client, the value being returned unchanged for other attributes.
+func radReencryptMPPEKey(vsa []byte, srcSecret string, srcAuthenticator [16]byte,
+	dstSecret string, dstAuthenticator [16]byte) []byte {
+	// vendor id (4), vendor type (1), vendor length (1), salt (2), encrypted key
+	if len(vsa) < 8 || binary.BigEndian.Uint32(vsa[:4]) != radMicrosoftVendorID ||
+		(vsa[4] != radMSMPPESendKey && vsa[4] != radMSMPPERecvKey) || int(vsa[5]) != len(vsa)-4 {
+		return vsa
+	}
+	salt := vsa[6:8]
+	key := radMPPEKeyCrypt(vsa[8:], salt, srcSecret, srcAuthenticator, true)
+	out := append([]byte{}, vsa[:8]...)
+	return append(out, radMPPEKeyCrypt(key, salt, dstSecret, dstAuthenticator, false)...)
+}
+
+// newRadEAPClient constructs the client proxying the EAP conversations to the upstream EAP server
+func newRadEAPClient(cfg config.EAPServerOpts, timeout time.Duration) *radEAPClient {
+	transport := cfg.Transport
+	if transport == utils.EmptyString {
+		transport = utils.UDP
+	}
+	return &radEAPClient{
+		address:   cfg.Address,
+		transport: transport,
+		secret:    cfg.Secret,
+		timeout:   timeout,
+	}
+}
+
+// radEAPClient sends the Access-Requests carrying EAP-Message to the upstream EAP server (RFC 3579),
+// opening one connection for each round trip of the conversation.
+type radEAPClient struct {
+	address   string
+	transport string // <udp|tcp>
+	secret    string
+	timeout   time.Duration
+	reqID     atomic.Uint32
+}
+
+// proxy forwards the request, received with reqSecret, returning the reply of the EAP server
+// with the attributes ready to be sent back to the client.
+func (ec *radEAPClient) proxy(reqPacket *radigo.Packet, reqSecret string) (*radigo.Packet, error) {
+	upReq := radigo.NewPacket(radigo.AccessRequest, uint8(ec.reqID.Add(1)), nil, nil, ec.secret)
+	if _, err := rand.Read(upReq.Authenticator[:]); err != nil {
+		return nil, err
+	}
+	for _, avp := range reqPacket.AVPs {
+		val := avp.RawValue
+		switch avp.Number {
+		case radMsgAuthAttr: // signed again below
+			continue
+		case radUserPasswordAttr:
+			val = radigo.EncodeUserPassword(val, []byte(ec.secret), upReq.Authenticator[:])
+		}
+		upReq.AVPs = append(upReq.AVPs, &radigo.AVP{Number: avp.Number, RawValue: val})
+	}
+	radSetMessageAuthenticator(upReq, upReq.Authenticator, ec.secret)
+	var buf [radigo.MaxPacketLen]byte
+	n, err := upReq.Encode(buf[:])
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout(ec.transport, ec.address, ec.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(ec.timeout))
+	if _, err = conn.Write(buf[:n]); err != nil {
+		return nil, err
+	}
+	var rawRply []byte
+	if ec.transport == utils.UDP {
+		if n, err = conn.Read(buf[:]); err != nil {
+			return nil, err
+		}
+		rawRply = buf[:n]
+	} else if rawRply, err = readRadiusPacket(conn); err != nil {
+		return nil, err
+	}
+	if len(rawRply) < radHeaderLen || rawRply[1] != upReq.Identifier {
+		return nil, errors.New("unexpected reply from EAP server")
+	}
+	if !radIsAuthenticReply(rawRply, upReq.Authenticator, ec.secret) {
+		return nil, errors.New("invalid Response Authenticator from EAP server")
+	}
+	upRply := radigo.NewPacket(0, 0, nil, nil, ec.secret)
+	if err = upRply.Decode(rawRply); err != nil {
+		return nil, err
+	}
+	if err = radCheckMessageAuthenticator(upRply, upReq.Authenticator, ec.secret,
+		radAttribute(upRply, radEAPMessageAttr) != nil); err != nil {
+		return nil, fmt.Errorf("%w from EAP server", err)
+	}
+	switch upRply.Code {
+	case radigo.AccessAccept, radigo.AccessReject, radigo.AccessChallenge:
+	default:
+		return nil, fmt.Errorf("unexpected reply code from EAP server: %s", upRply.Code)
+	}
+	rply := reqPacket.Reply()
+	rply.Code = upRply.Code
+	for _, avp := range upRply.AVPs {
+		val := avp.RawValue
+		switch avp.Number {
+		case radMsgAuthAttr: // signed for the client once the reply is final
+			continue
+		case radigo.VendorSpecificNumber:
+			val = radReencryptMPPEKey(val, ec.secret, upReq.Authenticator,
+				reqSecret, reqPacket.Authenticator)
+		}
+		rply.AVPs = append(rply.AVPs, &radigo.AVP{Number: avp.Number, RawValue: val})
+	}
+	return rply, nil
+}
+
+// radEAPStateKey returns the cache key correlating the rounds of one EAP conversation
+func radEAPStateKey(state []byte) string {
+	return utils.ConcatenatedKey(radEAPStatePrefix, fmt.Sprintf("%x", state))
+}
@@ -0,0 +1,188 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+var testRadMPPEKey = []byte("0123456789abcdef0123456789abcdef")
+
+// testRadMPPEKeyVSA builds the MS-MPPE-Recv-Key attribute value encrypted with the secret
+func testRadMPPEKeyVSA(key []byte, secret string, reqAuthenticator [16]byte) []byte {
+	salt := []byte{0x80, 0x01}
+	enc := radMPPEKeyCrypt(key, salt, secret, reqAuthenticator, false)
+	vsa := binary.BigEndian.AppendUint32(nil, radMicrosoftVendorID)
+	vsa = append(vsa, radMSMPPERecvKey, uint8(4+len(enc)))
+	vsa = append(vsa, salt...)
+	return append(vsa, enc...)
+}
+
+// testRadEAPServer starts an EAP server challenging the requests without State
+// and accepting the ones carrying the State it issued
+func testRadEAPServer(t *testing.T, secret string) string {
+	t.Helper()
+	pc, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pc.Close() })
+	go func() {
+		var buf [radigo.MaxPacketLen]byte
+		for {
+			n, addr, err := pc.ReadFrom(buf[:])
+			if err != nil {
+				return
+			}
+			req := radigo.NewPacket(0, 0, nil, nil, secret)
+			if err = req.Decode(buf[:n]); err != nil ||
+				radCheckMessageAuthenticator(req, req.Authenticator, secret, true) != nil {
+				continue
+			}
+			rply := req.Reply()
+			switch state := radAttribute(req, radStateAttr); {
+			case state == nil:
+				rply.Code = radigo.AccessChallenge
+				rply.AVPs = append(rply.AVPs,
+					&radigo.AVP{Number: radStateAttr, RawValue: []byte("round1")},
+					&radigo.AVP{Number: radEAPMessageAttr, RawValue: []byte{1, 2, 0, 6, 13, 0x20}})
+			case string(state) == "round1":
+				rply.Code = radigo.AccessAccept
+				rply.AVPs = append(rply.AVPs,
+					&radigo.AVP{Number: radigo.VendorSpecificNumber,
+						RawValue: testRadMPPEKeyVSA(testRadMPPEKey, secret, req.Authenticator)},
+					&radigo.AVP{Number: radEAPMessageAttr, RawValue: []byte{3, 2, 0, 4}})
+			default:
+				rply.Code = radigo.AccessReject
+			}
+			radSetMessageAuthenticator(rply, req.Authenticator, secret)
+			if n, err = rply.Encode(buf[:]); err != nil {
+				continue
+			}
+			pc.WriteTo(buf[:n], addr)
+		}
+	}()
+	return pc.LocalAddr().String()
+}
+
+func TestLibradeapMessageAuthenticator(t *testing.T) {
+	req := radigo.NewPacket(radigo.StatusServer, 1, dictRad, coder, "CGRateS.org")
+	if _, err := rand.Read(req.Authenticator[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := radCheckMessageAuthenticator(req, req.Authenticator, "CGRateS.org", false); err != nil {
+		t.Errorf("expected optional Message-Authenticator to be accepted, received %v", err)
+	}
+	if err := radCheckMessageAuthenticator(req, req.Authenticator, "CGRateS.org", true); err == nil ||
+		err.Error() != "missing Message-Authenticator" {
+		t.Errorf("expected missing Message-Authenticator, received %v", err)
+	}
+	if err := req.AddAVPWithName("NAS-Identifier", "nas1", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	radSetMessageAuthenticator(req, req.Authenticator, "CGRateS.org")
+	radSetMessageAuthenticator(req, req.Authenticator, "CGRateS.org") // replaces the previous one
+	if len(req.AVPs) != 2 {
+		t.Errorf("expected 2 attributes, received %d", len(req.AVPs))
+	}
+	if err := radCheckMessageAuthenticator(req, req.Authenticator, "CGRateS.org", true); err != nil {
+		t.Error(err)
+	}
+	if err := radCheckMessageAuthenticator(req, req.Authenticator, "radsec", true); err == nil ||
+		err.Error() != "invalid Message-Authenticator" {
+		t.Errorf("expected invalid Message-Authenticator, received %v", err)
+	}
+
+	// the signature needs to survive the encoding of the reply
+	rply := req.Reply()
+	rply.Code = radigo.AccessAccept
+	radSetMessageAuthenticator(rply, req.Authenticator, "CGRateS.org")
+	var buf [radigo.MaxPacketLen]byte
+	n, err := rply.Encode(buf[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !radIsAuthenticReply(buf[:n], req.Authenticator, "CGRateS.org") {
+		t.Error("expected authentic reply")
+	}
+	rcvRply := radigo.NewPacket(0, 0, nil, nil, "CGRateS.org")
+	if err = rcvRply.Decode(buf[:n]); err != nil {
+		t.Fatal(err)
+	}
+	if err = radCheckMessageAuthenticator(rcvRply, req.Authenticator, "CGRateS.org", true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLibradeapReencryptMPPEKey(t *testing.T) {
+	var srcAuth, dstAuth [16]byte
+	rand.Read(srcAuth[:])
+	rand.Read(dstAuth[:])
+	vsa := testRadMPPEKeyVSA(testRadMPPEKey, "eapSecret", srcAuth)
+	rcv := radReencryptMPPEKey(vsa, "eapSecret", srcAuth, "clientSecret", dstAuth)
+	if !bytes.Equal(rcv[:8], vsa[:8]) {
+		t.Errorf("expected header %v, received %v", vsa[:8], rcv[:8])
+	}
+	if key := radMPPEKeyCrypt(rcv[8:], rcv[6:8], "clientSecret", dstAuth, true); !bytes.Equal(key, testRadMPPEKey) {
+		t.Errorf("expected %q, received %q", testRadMPPEKey, key)
+	}
+	other := binary.BigEndian.AppendUint32(nil, 9)
+	other = append(other, 1, 6, 'a', 'b', 'c', 'd')
+	if rcv = radReencryptMPPEKey(other, "eapSecret", srcAuth, "clientSecret", dstAuth); !bytes.Equal(rcv, other) {
+		t.Errorf("expected other vendors unchanged, received %v", rcv)
+	}
+}
+
+func TestLibradeapProxy(t *testing.T) {
+	ec := newRadEAPClient(config.EAPServerOpts{
+		Address: testRadEAPServer(t, "eapSecret"),
+		Secret:  "eapSecret",
+	}, time.Second)
+	req := radigo.NewPacket(radigo.AccessRequest, 7, dictRad, coder, "clientSecret")
+	rand.Read(req.Authenticator[:])
+	req.AVPs = append(req.AVPs, &radigo.AVP{Number: radEAPMessageAttr, RawValue: []byte{2, 1, 0, 5, 1}})
+	rply, err := ec.proxy(req, "clientSecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rply.Code != radigo.AccessChallenge || rply.Identifier != req.Identifier {
+		t.Errorf("expected AccessChallenge for request 7, received %s for request %d", rply.Code, rply.Identifier)
+	}
+	if state := radAttribute(rply, radStateAttr); string(state) != "round1" {
+		t.Errorf("expected State round1, received %q", state)
+	}
+	if radAttribute(rply, radMsgAuthAttr) != nil {
+		t.Error("expected the Message-Authenticator of the EAP server to be removed")
+	}
+
+	ec.secret = "wrongSecret" // dropped by the EAP server
+	ec.timeout = 100 * time.Millisecond
+	if _, err = ec.proxy(req, "clientSecret"); err == nil {
+		t.Error("expected error for requests not answered by the EAP server")
+	}
+}
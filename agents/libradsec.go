@@ -0,0 +1,241 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+const (
+	radHeaderLen           = 20 // code, identifier, length and authenticator
+	radsecHandshakeTimeout = 10 * time.Second
+)
+
+// radHandler processes a RADIUS request received from remoteAddr
+type radHandler func(reqPacket *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error)
+
+// radigoHandler adapts a radHandler to radigo.Server, which keeps the remote address on the packet
+func radigoHandler(h radHandler) func(*radigo.Packet) (*radigo.Packet, error) {
+	return func(reqPacket *radigo.Packet) (*radigo.Packet, error) {
+		return h(reqPacket, reqPacket.RemoteAddr())
+	}
+}
+
+// radiusServer is implemented by the listeners of the RadiusAgent
+type radiusServer interface {
+	ListenAndServe(stopChan <-chan struct{}) error
+}
+
+// radsecTLSConfig builds the server side TLS configuration for RadSec out of the engine TLS config,
+// requiring the clients to present a certificate signed by the configured CA (RFC 6614 2.3)
+func radsecTLSConfig(serverCrt, serverKey, caCert, serverName string) (tlsCfg *tls.Config, err error) {
+	if tlsCfg, err = utils.LoadTLSConfig(serverCrt, serverKey, caCert,
+		int(tls.RequireAndVerifyClientCert), serverName); err != nil {
+		return
+	}
+	tlsCfg.MinVersion = tls.VersionTLS12
+	return
+}
+
+// newRadsecServer constructs a RADIUS over TLS (RFC 6614) listener
+func newRadsecServer(addr string, tlsCfg *tls.Config, secrets *radigo.Secrets,
+	dicts *radigo.Dictionaries, handlers map[radigo.PacketCode]radHandler) *radsecServer {
+	return &radsecServer{
+		addr:     addr,
+		tlsCfg:   tlsCfg,
+		secrets:  secrets,
+		dicts:    dicts,
+		coder:    radigo.NewCoder(),
+		handlers: handlers,
+	}
+}
+
+// radsecServer reads the RADIUS packets out of the TLS stream, using the
+// length from their header, and dispatches them to the agent handlers
+type radsecServer struct {
+	addr     string
+	tlsCfg   *tls.Config
+	secrets  *radigo.Secrets // client bounded secrets, *default for server wide
+	dicts    *radigo.Dictionaries
+	coder    radigo.Coder
+	handlers map[radigo.PacketCode]radHandler
+}
+
+// ListenAndServe binds to the address and serves the TLS connections until stopChan is closed
+func (rs *radsecServer) ListenAndServe(stopChan <-chan struct{}) error {
+	ln, err := tls.Listen(utils.TCP, rs.addr, rs.tlsCfg)
+	if err != nil {
+		return err
+	}
+	return rs.serve(stopChan, ln)
+}
+
+func (rs *radsecServer) serve(stopChan <-chan struct{}, ln net.Listener) error {
+	go func() {
+		<-stopChan
+		ln.Close()
+	}()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-stopChan:
+				return nil
+			default:
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s>, when accepting RadSec connection",
+				utils.RadiusAgent, err.Error()))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rs.handleConn(stopChan, conn)
+		}()
+	}
+}
+
+// handleConn reads the requests on one client connection, disconnecting on read errors or malformed packets
+func (rs *radsecServer) handleConn(stopChan <-chan struct{}, conn net.Conn) {
+	defer conn.Close()
+	if tlsConn, isTLS := conn.(*tls.Conn); isTLS {
+		tlsConn.SetDeadline(time.Now().Add(radsecHandshakeTimeout))
+		if err := tlsConn.Handshake(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> RadSec handshake with <%s> failed: %s",
+				utils.RadiusAgent, conn.RemoteAddr(), err.Error()))
+			return
+		}
+		tlsConn.SetDeadline(time.Time{})
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stopChan:
+			conn.Close()
+		case <-done:
+		}
+	}()
+	clientID, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		clientID = conn.RemoteAddr().String()
+	}
+	var wMux sync.Mutex // replies are written concurrently
+	for {
+		rawPkt, err := readRadiusPacket(conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> reading RadSec packets from <%s>, disconnecting",
+					utils.RadiusAgent, err.Error(), conn.RemoteAddr()))
+			}
+			return
+		}
+		secret := rs.secrets.GetSecret(clientID)
+		if !radIsAuthenticReq(rawPkt, secret) {
+			utils.Logger.Warning(fmt.Sprintf("<%s> ignoring RadSec request with invalid authenticator from <%s>",
+				utils.RadiusAgent, conn.RemoteAddr()))
+			continue
+		}
+		reqPacket := radigo.NewPacket(0, 0, rs.dicts.GetInstance(clientID), rs.coder, secret)
+		if err = reqPacket.Decode(rawPkt); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> decoding RadSec packet from <%s>",
+				utils.RadiusAgent, err.Error(), conn.RemoteAddr()))
+			continue
+		}
+		go func() {
+			var rply *radigo.Packet
+			var err error
+			if hndlr, has := rs.handlers[reqPacket.Code]; !has {
+				rply = reqPacket.NegativeReply("no handler")
+			} else if rply, err = hndlr(reqPacket, conn.RemoteAddr()); err != nil {
+				rply = reqPacket.NegativeReply(err.Error())
+			}
+			if rply == nil {
+				return
+			}
+			var buf [radigo.MaxPacketLen]byte
+			n, err := rply.Encode(buf[:])
+			if err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> encoding RadSec reply for <%s>",
+					utils.RadiusAgent, err.Error(), conn.RemoteAddr()))
+				return
+			}
+			wMux.Lock()
+			_, err = conn.Write(buf[:n])
+			wMux.Unlock()
+			if err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> sending RadSec reply to <%s>",
+					utils.RadiusAgent, err.Error(), conn.RemoteAddr()))
+			}
+		}()
+	}
+}
+
+// readRadiusPacket reads one RADIUS packet out of a stream transport (RFC 6613 2.1)
+func readRadiusPacket(r io.Reader) ([]byte, error) {
+	var hdr [4]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	pktLen := int(binary.BigEndian.Uint16(hdr[2:4]))
+	if pktLen < radHeaderLen || pktLen > radigo.MaxPacketLen {
+		return nil, fmt.Errorf("invalid packet length: %d", pktLen)
+	}
+	rawPkt := make([]byte, pktLen)
+	copy(rawPkt, hdr[:])
+	if _, err := io.ReadFull(r, rawPkt[4:]); err != nil {
+		return nil, err
+	}
+	return rawPkt, nil
+}
+
+// radIsAuthenticReq checks the Request Authenticator of the requests signed with the shared secret
+func radIsAuthenticReq(rawPkt []byte, secret string) bool {
+	if len(rawPkt) < radHeaderLen || secret == utils.EmptyString {
+		return false
+	}
+	switch radigo.PacketCode(rawPkt[0]) {
+	case radigo.AccountingRequest, radigo.DisconnectRequest, radigo.CoARequest:
+	default: // random authenticator
+		return true
+	}
+	hash := md5.New()
+	hash.Write(rawPkt[:4])
+	hash.Write(make([]byte, 16))
+	hash.Write(rawPkt[radHeaderLen:])
+	hash.Write([]byte(secret))
+	return bytes.Equal(hash.Sum(nil), rawPkt[4:radHeaderLen])
+}
@@ -0,0 +1,262 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+// testRadsecCerts writes a self-signed certificate, valid for both server and client
+// authentication, returning the paths towards the certificate and its key
+func testRadsecCerts(t *testing.T) (crtPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "radsec.cgrates.org"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	crtPath = filepath.Join(dir, "radsec.crt")
+	keyPath = filepath.Join(dir, "radsec.key")
+	if err = os.WriteFile(crtPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestLibradsecReadRadiusPacket(t *testing.T) {
+	var buf [radigo.MaxPacketLen]byte
+	n, err := radigo.NewPacket(radigo.AccessRequest, 1, dictRad, coder, "CGRateS.org").Encode(buf[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := bytes.NewBuffer(nil)
+	stream.Write(buf[:n])
+	stream.Write(buf[:n])
+	for i := 0; i < 2; i++ {
+		if rawPkt, err := readRadiusPacket(stream); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(rawPkt, buf[:n]) {
+			t.Errorf("expected %v, received %v", buf[:n], rawPkt)
+		}
+	}
+	if _, err := readRadiusPacket(stream); err != io.EOF {
+		t.Errorf("expected %v, received %v", io.EOF, err)
+	}
+	if _, err := readRadiusPacket(bytes.NewReader([]byte{1, 1, 0, 4})); err == nil ||
+		err.Error() != "invalid packet length: 4" {
+		t.Errorf("expected invalid packet length, received %v", err)
+	}
+	if _, err := readRadiusPacket(bytes.NewReader(buf[:n-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected %v, received %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestLibradsecIsAuthenticReq(t *testing.T) {
+	var buf [radigo.MaxPacketLen]byte
+	acctReq := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org")
+	if err := acctReq.AddAVPWithName("User-Name", "1001", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	n, err := acctReq.Encode(buf[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !radIsAuthenticReq(buf[:n], "CGRateS.org") {
+		t.Error("expected authentic request")
+	}
+	if radIsAuthenticReq(buf[:n], "radsec") {
+		t.Error("expected request signed with a different secret to be rejected")
+	}
+	if radIsAuthenticReq(buf[:n], utils.EmptyString) {
+		t.Error("expected request rejected without secret")
+	}
+	if n, err = radigo.NewPacket(radigo.AccessRequest, 1, dictRad, coder, "CGRateS.org").Encode(buf[:]); err != nil {
+		t.Fatal(err)
+	}
+	if !radIsAuthenticReq(buf[:n], "radsec") {
+		t.Error("expected access request with random authenticator to be accepted")
+	}
+}
+
+func TestLibradsecTLSConfig(t *testing.T) {
+	crtPath, keyPath := testRadsecCerts(t)
+	tlsCfg, err := radsecTLSConfig(crtPath, keyPath, crtPath, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsCfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("expected %v, received %v", tls.RequireAndVerifyClientCert, tlsCfg.ClientAuth)
+	}
+	if len(tlsCfg.Certificates) != 1 {
+		t.Errorf("expected one certificate, received %d", len(tlsCfg.Certificates))
+	}
+	if _, err = radsecTLSConfig(crtPath, keyPath, keyPath, utils.EmptyString); err == nil {
+		t.Error("expected error for invalid CA")
+	}
+	if _, err = radsecTLSConfig(crtPath, "/tmp/inexistent.key", crtPath, utils.EmptyString); err == nil {
+		t.Error("expected error for missing key")
+	}
+}
+
+func TestLibradsecServe(t *testing.T) {
+	crtPath, keyPath := testRadsecCerts(t)
+	tlsCfg, err := radsecTLSConfig(crtPath, keyPath, crtPath, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := tls.Listen(utils.TCP, "127.0.0.1:0", tlsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets := radigo.NewSecrets(map[string]string{
+		utils.MetaDefault: "CGRateS.org",
+		"127.0.0.1":       "radsec",
+	})
+	dicts := radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad})
+	rcvAddrs := make(chan net.Addr, 2)
+	rs := newRadsecServer(ln.Addr().String(), tlsCfg, secrets, dicts,
+		map[radigo.PacketCode]radHandler{
+			radigo.AccountingRequest: func(req *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error) {
+				rcvAddrs <- remoteAddr
+				rply := req.Reply()
+				rply.Code = radigo.AccountingResponse
+				return rply, nil
+			},
+		})
+	stop := make(chan struct{})
+	served := make(chan error)
+	go func() { served <- rs.serve(stop, ln) }()
+
+	var buf [radigo.MaxPacketLen]byte
+	clntCert, err := tls.LoadX509KeyPair(crtPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// with TLS 1.3 the client certificate is verified after the client handshake completes
+	if noCrtConn, err := tls.Dial(utils.TCP, ln.Addr().String(), &tls.Config{RootCAs: tlsCfg.ClientCAs}); err == nil {
+		noCrtConn.SetReadDeadline(time.Now().Add(time.Second))
+		if _, err = noCrtConn.Read(buf[:]); err == nil {
+			t.Error("expected connection without client certificate to fail")
+		}
+		noCrtConn.Close()
+	}
+	conn, err := tls.Dial(utils.TCP, ln.Addr().String(), &tls.Config{
+		RootCAs:      tlsCfg.ClientCAs,
+		Certificates: []tls.Certificate{clntCert},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// signed with the *default secret instead of the one of the client, ignored
+	n, err := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org").Encode(buf[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.Write(buf[:n]); err != nil {
+		t.Fatal(err)
+	}
+	// unknown packet code, negative reply
+	if n, err = radigo.NewPacket(radigo.AccessRequest, 2, dictRad, coder, "radsec").Encode(buf[:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.Write(buf[:n]); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	rawRply, err := readRadiusPacket(conn)
+	if err != nil {
+		t.Fatal(err)
+	} else if rawRply[0] != byte(radigo.AccessReject) || rawRply[1] != 2 {
+		t.Errorf("expected AccessReject for request 2, received code %d for request %d", rawRply[0], rawRply[1])
+	}
+
+	if n, err = radigo.NewPacket(radigo.AccountingRequest, 3, dictRad, coder, "radsec").Encode(buf[:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.Write(buf[:n]); err != nil {
+		t.Fatal(err)
+	}
+	if rawRply, err = readRadiusPacket(conn); err != nil {
+		t.Fatal(err)
+	} else if rawRply[0] != byte(radigo.AccountingResponse) || rawRply[1] != 3 {
+		t.Errorf("expected AccountingResponse for request 3, received code %d for request %d", rawRply[0], rawRply[1])
+	}
+	select {
+	case addr := <-rcvAddrs:
+		if addr.String() != conn.LocalAddr().String() {
+			t.Errorf("expected remote address %s, received %s", conn.LocalAddr(), addr)
+		}
+	default:
+		t.Error("accounting handler not called")
+	}
+	if len(rcvAddrs) != 0 {
+		t.Error("expected the request with invalid authenticator to be ignored")
+	}
+
+	close(stop)
+	select {
+	case err = <-served:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RadSec server did not stop")
+	}
+}
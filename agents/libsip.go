@@ -19,13 +19,23 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/sipingo"
+	"golang.org/x/net/websocket"
 )
 
 // updateSIPMsgFromNavMap will update the diameter message with items from navigable map
@@ -69,3 +79,180 @@ func bareSipErr(m sipingo.Message, err string) sipingo.Message {
 	m.PrepareReply()
 	return m
 }
+
+// sipOptionsReply answers the OPTIONS keepalives without passing them to the request processors
+func sipOptionsReply(m sipingo.Message) sipingo.Message {
+	m[requestHeader] = sipOK
+	m.PrepareReply()
+	m[allowHeader] = sipAllowedMethods
+	return m
+}
+
+// sipMethodProcessors returns the request processors with the given IDs, in the order of the IDs
+func sipMethodProcessors(reqProcessors []*config.RequestProcessor, procIDs []string) (procs []*config.RequestProcessor) {
+	procs = make([]*config.RequestProcessor, 0, len(procIDs))
+	for _, procID := range procIDs {
+		for _, reqProcessor := range reqProcessors {
+			if reqProcessor.ID == procID {
+				procs = append(procs, reqProcessor)
+				break
+			}
+		}
+	}
+	return
+}
+
+// sipRealm returns the host of the Request-URI used as realm for the digest authentication
+func sipRealm(requestLine string) (realm string) {
+	flds := strings.Fields(requestLine)
+	if len(flds) < 2 {
+		return
+	}
+	realm = flds[1]
+	if idx := strings.IndexByte(realm, ':'); idx != -1 { // scheme
+		realm = realm[idx+1:]
+	}
+	if idx := strings.LastIndexByte(realm, '@'); idx != -1 { // user
+		realm = realm[idx+1:]
+	}
+	if idx := strings.IndexAny(realm, ";?"); idx != -1 { // uri parameters and headers
+		realm = realm[:idx]
+	}
+	if host, _, err := net.SplitHostPort(realm); err == nil {
+		realm = host
+	}
+	return
+}
+
+// sipNonce creates a nonce which can be later verified without storing it
+func sipNonce(key []byte, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(ts))
+	return ts + utils.NestingSep + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sipNonceValid checks if the nonce was created with our key and if it is still fresh
+func sipNonceValid(key []byte, nonce string, now time.Time) (valid, stale bool) {
+	ts, _, has := strings.Cut(nonce, utils.NestingSep)
+	if !has {
+		return
+	}
+	unixTime, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil ||
+		!hmac.Equal([]byte(nonce), []byte(sipNonce(key, time.Unix(unixTime, 0)))) {
+		return
+	}
+	if now.Sub(time.Unix(unixTime, 0)) > sipNonceTTL {
+		return false, true
+	}
+	return true, false
+}
+
+// sipDigestChallenge builds the value of the WWW-Authenticate/Proxy-Authenticate header
+func sipDigestChallenge(realm, nonce string, stale bool) (chlg string) {
+	chlg = fmt.Sprintf(`Digest realm="%s",nonce="%s",algorithm=MD5,qop="auth"`, realm, nonce)
+	if stale {
+		chlg += ",stale=true"
+	}
+	return
+}
+
+// parseSIPDigest returns the parameters out of the Digest Authorization header
+func parseSIPDigest(hdr string) (params map[string]string, err error) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(hdr), " ")
+	if !strings.EqualFold(scheme, "Digest") {
+		return nil, fmt.Errorf("unsupported authorization scheme: <%s>", scheme)
+	}
+	params = make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != utils.EmptyString; {
+		key, val, has := strings.Cut(rest, "=")
+		if !has {
+			return nil, fmt.Errorf("malformed authorization parameter: <%s>", rest)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+		if strings.HasPrefix(val, `"`) {
+			end := strings.IndexByte(val[1:], '"')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated quoted value for parameter: <%s>", key)
+			}
+			params[key] = val[1 : end+1]
+			rest = val[end+2:]
+		} else {
+			params[key], rest, _ = strings.Cut(val, utils.FieldsSep)
+			params[key] = strings.TrimSpace(params[key])
+		}
+		rest = strings.TrimLeft(rest, " ,")
+	}
+	for _, key := range []string{"username", "realm", "nonce", "uri", "response"} {
+		if _, has := params[key]; !has {
+			return nil, utils.NewErrMandatoryIeMissing(key)
+		}
+	}
+	return
+}
+
+// sipDigestResponse computes the expected digest response as described in RFC 2617
+func sipDigestResponse(method, password string, params map[string]string) string {
+	ha1 := md5Hex(params["username"] + ":" + params["realm"] + ":" + password)
+	ha2 := md5Hex(method + ":" + params["uri"])
+	if params["qop"] == "auth" {
+		return md5Hex(ha1 + ":" + params["nonce"] + ":" + params["nc"] + ":" +
+			params["cnonce"] + ":" + params["qop"] + ":" + ha2)
+	}
+	return md5Hex(ha1 + ":" + params["nonce"] + ":" + ha2)
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// sipViaReceived adds the received and rport parameters to the topmost Via
+// so the replies reach the client behind NAT or WebSocket (RFC 3261 18.2.1, RFC 3581)
+func sipViaReceived(via, remoteAddr string) string {
+	host, port, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return via
+	}
+	top, rest, hasRest := strings.Cut(via, utils.FieldsSep)
+	params := strings.Split(top, utils.InfieldSep)
+	sentBy := params[0]
+	if idx := strings.LastIndexByte(sentBy, ' '); idx != -1 {
+		sentBy = sentBy[idx+1:]
+	}
+	if h, _, err := net.SplitHostPort(sentBy); err == nil {
+		sentBy = h
+	}
+	sentBy = strings.Trim(sentBy, "[]")
+	var hasRport, hasReceived bool
+	for i, param := range params[1:] {
+		name, _, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch strings.ToLower(name) {
+		case "rport":
+			params[i+1] = "rport=" + port
+			hasRport = true
+		case "received":
+			params[i+1] = "received=" + host
+			hasReceived = true
+		}
+	}
+	if !hasReceived && (hasRport || sentBy != host) {
+		params = append(params, "received="+host)
+	}
+	top = strings.Join(params, utils.InfieldSep)
+	if hasRest {
+		return top + utils.FieldsSep + rest
+	}
+	return top
+}
+
+// sipWSHandshake accepts only the WebSocket clients negotiating the sip subprotocol (RFC 7118)
+func sipWSHandshake(cfg *websocket.Config, _ *http.Request) error {
+	if !slices.Contains(cfg.Protocol, utils.SIPWSSubProto) {
+		return fmt.Errorf("unsupported subprotocols: %q", cfg.Protocol)
+	}
+	cfg.Protocol = []string{utils.SIPWSSubProto}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/sipingo"
+	"golang.org/x/net/websocket"
 )
 
 func TestUpdateSIPMsgFromNavMap(t *testing.T) {
@@ -71,3 +72,165 @@ func TestLibsipBareSipErr(t *testing.T) {
 		t.Errorf("expected error message %s, got %s", errMsg, updatedMsg["requestHeader"])
 	}
 }
+
+func TestLibsipSIPOptionsReply(t *testing.T) {
+	m := sipingo.Message{
+		requestHeader:  "OPTIONS sip:cgrates.org SIP/2.0",
+		"Call-ID":      "a84b4c76e66710",
+		"Content-Type": "application/sdp",
+		"Content":      "v=0",
+	}
+	exp := sipingo.Message{
+		requestHeader:    sipOK,
+		"Call-ID":        "a84b4c76e66710",
+		"Content-Length": "0",
+		allowHeader:      sipAllowedMethods,
+	}
+	if rcv := sipOptionsReply(m); !reflect.DeepEqual(rcv, exp) {
+		t.Errorf("Expected: %s , received: %s", exp, rcv)
+	}
+}
+
+func TestLibsipSIPMethodProcessors(t *testing.T) {
+	reqProcessors := []*config.RequestProcessor{{ID: "RP1"}, {ID: "RP2"}, {ID: "RP3"}}
+	exp := []*config.RequestProcessor{reqProcessors[2], reqProcessors[0]}
+	if rcv := sipMethodProcessors(reqProcessors, []string{"RP3", "RP4", "RP1"}); !reflect.DeepEqual(rcv, exp) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestLibsipSIPRealm(t *testing.T) {
+	for reqLine, exp := range map[string]string{
+		"REGISTER sip:cgrates.org SIP/2.0":                     "cgrates.org",
+		"MESSAGE sip:1002@cgrates.org:5060;user=phone SIP/2.0": "cgrates.org",
+		"INVITE sips:1002@[2001:db8::1]:5061 SIP/2.0":          "2001:db8::1",
+		"INVALID": "",
+	} {
+		if rcv := sipRealm(reqLine); rcv != exp {
+			t.Errorf("Expected %q for %q, received %q", exp, reqLine, rcv)
+		}
+	}
+}
+
+func TestLibsipSIPNonce(t *testing.T) {
+	key := []byte("secret")
+	now := time.Now()
+	nonce := sipNonce(key, now)
+	if valid, stale := sipNonceValid(key, nonce, now.Add(time.Minute)); !valid || stale {
+		t.Errorf("Expected valid nonce, received valid: %v, stale: %v", valid, stale)
+	}
+	if valid, stale := sipNonceValid(key, nonce, now.Add(sipNonceTTL+time.Second)); valid || !stale {
+		t.Errorf("Expected stale nonce, received valid: %v, stale: %v", valid, stale)
+	}
+	if valid, stale := sipNonceValid([]byte("otherSecret"), nonce, now); valid || stale {
+		t.Errorf("Expected invalid nonce, received valid: %v, stale: %v", valid, stale)
+	}
+	if valid, _ := sipNonceValid(key, "notANonce", now); valid {
+		t.Error("Expected invalid nonce")
+	}
+}
+
+func TestLibsipParseSIPDigest(t *testing.T) {
+	hdr := `Digest username="1001",realm="cgrates.org", nonce="17.ab,cd", uri="sip:cgrates.org",response="6629fae49393a05397450978507c4ef1",algorithm=MD5, qop=auth,nc=00000001,cnonce="0a4f113b"`
+	exp := map[string]string{
+		"username":  "1001",
+		"realm":     "cgrates.org",
+		"nonce":     "17.ab,cd",
+		"uri":       "sip:cgrates.org",
+		"response":  "6629fae49393a05397450978507c4ef1",
+		"algorithm": "MD5",
+		"qop":       "auth",
+		"nc":        "00000001",
+		"cnonce":    "0a4f113b",
+	}
+	if rcv, err := parseSIPDigest(hdr); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rcv, exp) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	for hdr, errExp := range map[string]string{
+		`Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==`:                   "unsupported authorization scheme: <Basic>",
+		`Digest username="1001`:                                "unterminated quoted value for parameter: <username>",
+		`Digest username`:                                      "malformed authorization parameter: <username>",
+		`Digest username="1001",realm="cgrates.org",nonce="1"`: "MANDATORY_IE_MISSING: [uri]",
+	} {
+		if _, err := parseSIPDigest(hdr); err == nil || err.Error() != errExp {
+			t.Errorf("Expected %q, received %v", errExp, err)
+		}
+	}
+}
+
+func TestLibsipSIPDigestResponse(t *testing.T) {
+	// example from RFC 2617
+	params := map[string]string{
+		"username": "Mufasa",
+		"realm":    "testrealm@host.com",
+		"nonce":    "dcd98b7102dd2f0e8b11d0f600bfb0c093",
+		"uri":      "/dir/index.html",
+		"qop":      "auth",
+		"nc":       "00000001",
+		"cnonce":   "0a4f113b",
+	}
+	if rcv := sipDigestResponse("GET", "Circle Of Life", params); rcv != "6629fae49393a05397450978507c4ef1" {
+		t.Errorf("Expected %q, received %q", "6629fae49393a05397450978507c4ef1", rcv)
+	}
+	delete(params, "qop")
+	exp := md5Hex(md5Hex("Mufasa:testrealm@host.com:Circle Of Life") + ":" +
+		"dcd98b7102dd2f0e8b11d0f600bfb0c093:" + md5Hex("GET:/dir/index.html"))
+	if rcv := sipDigestResponse("GET", "Circle Of Life", params); rcv != exp {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+}
+
+func TestLibsipSIPViaReceived(t *testing.T) {
+	for _, tc := range []struct {
+		via, addr, exp string
+	}{
+		{
+			via:  "SIP/2.0/UDP 192.168.1.10:5060;branch=z9hG4bK776asdhds",
+			addr: "192.168.1.10:5060",
+			exp:  "SIP/2.0/UDP 192.168.1.10:5060;branch=z9hG4bK776asdhds",
+		},
+		{
+			via:  "SIP/2.0/TCP 192.168.1.10:5060;branch=z9hG4bK776asdhds",
+			addr: "10.0.0.1:43210",
+			exp:  "SIP/2.0/TCP 192.168.1.10:5060;branch=z9hG4bK776asdhds;received=10.0.0.1",
+		},
+		{
+			via:  "SIP/2.0/WSS df7jal23ls0d.invalid;branch=z9hG4bK56sdasks;rport",
+			addr: "10.0.0.1:43210",
+			exp:  "SIP/2.0/WSS df7jal23ls0d.invalid;branch=z9hG4bK56sdasks;rport=43210;received=10.0.0.1",
+		},
+		{
+			via:  "SIP/2.0/TLS 10.0.0.1;rport;branch=z9hG4bK56sdasks,SIP/2.0/UDP 192.168.1.1;branch=z9hG4bK1",
+			addr: "10.0.0.1:5061",
+			exp:  "SIP/2.0/TLS 10.0.0.1;rport=5061;branch=z9hG4bK56sdasks;received=10.0.0.1,SIP/2.0/UDP 192.168.1.1;branch=z9hG4bK1",
+		},
+		{
+			via:  "SIP/2.0/TCP [2001:db8::1]:5060;branch=z9hG4bK56sdasks",
+			addr: "[2001:db8::1]:43210",
+			exp:  "SIP/2.0/TCP [2001:db8::1]:5060;branch=z9hG4bK56sdasks",
+		},
+		{
+			via:  "SIP/2.0/UDP 192.168.1.10;branch=z9hG4bK56sdasks",
+			addr: "invalid",
+			exp:  "SIP/2.0/UDP 192.168.1.10;branch=z9hG4bK56sdasks",
+		},
+	} {
+		if rcv := sipViaReceived(tc.via, tc.addr); rcv != tc.exp {
+			t.Errorf("Expected %q, received %q", tc.exp, rcv)
+		}
+	}
+}
+
+func TestLibsipSIPWSHandshake(t *testing.T) {
+	cfg := &websocket.Config{Protocol: []string{"chat", utils.SIPWSSubProto}}
+	if err := sipWSHandshake(cfg, nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(cfg.Protocol, []string{utils.SIPWSSubProto}) {
+		t.Errorf("Expected %q, received %q", []string{utils.SIPWSSubProto}, cfg.Protocol)
+	}
+	if err := sipWSHandshake(&websocket.Config{Protocol: []string{"chat"}}, nil); err == nil {
+		t.Error("Expected error for missing sip subprotocol")
+	}
+}
@@ -21,6 +21,7 @@ package agents
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/cgrates/birpc/context"
 	"github.com/cgrates/cgrates/config"
@@ -144,6 +145,7 @@ type PrometheusAgent struct {
 
 	handler           http.Handler
 	statMetrics       *prometheus.GaugeVec
+	statBuckets       *prometheus.GaugeVec
 	cacheGroupsMetric *prometheus.GaugeVec
 	cacheItemsMetric  *prometheus.GaugeVec
 }
@@ -183,6 +185,14 @@ func NewPrometheusAgent(cfg *config.CGRConfig, cm *engine.ConnManager) *Promethe
 			Help:      "Current values for StatQueue metrics",
 		}, []string{"tenant", "queue", "metric"})
 	reg.MustRegister(statMetrics)
+	statBuckets := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "cgrates",
+			Subsystem: "stats",
+			Name:      "histogram_bucket",
+			Help:      "Cumulative bucket counts for StatQueue histogram metrics",
+		}, []string{"tenant", "queue", "metric", "le"})
+	reg.MustRegister(statBuckets)
 	if cfg.PrometheusAgentCfg().CollectGoMetrics {
 		reg.MustRegister(collectors.NewGoCollector())
 	}
@@ -200,6 +210,7 @@ func NewPrometheusAgent(cfg *config.CGRConfig, cm *engine.ConnManager) *Promethe
 		cm:                cm,
 		handler:           handler,
 		statMetrics:       statMetrics,
+		statBuckets:       statBuckets,
 		cacheGroupsMetric: cacheGroupsMetric,
 		cacheItemsMetric:  cacheItemsMetric,
 	}
@@ -253,6 +264,14 @@ func (pa *PrometheusAgent) updateStatsMetrics() {
 			}
 
 			for metricID, val := range metrics {
+				// histogram buckets come as *histogram#<field>#<bounds>#<bound>
+				if strings.HasPrefix(metricID, utils.MetaHistogram) &&
+					strings.Count(metricID, utils.HashtagSep) == 3 {
+					sepIdx := strings.LastIndex(metricID, utils.HashtagSep)
+					pa.statBuckets.WithLabelValues(tenantID.Tenant, tenantID.ID,
+						metricID[:sepIdx], metricID[sepIdx+1:]).Set(val)
+					continue
+				}
 				pa.statMetrics.WithLabelValues(tenantID.Tenant, tenantID.ID, metricID).Set(val)
 			}
 		}
@@ -81,22 +81,53 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS, connMgr *
 	}
 	dicts := radigo.NewDictionaries(dts)
 	secrets := radigo.NewSecrets(radAgentCfg.ClientSecrets)
+	radAgent.secrets = secrets
 	radAgent.dacCfg = newRadiusDAClientCfg(dicts, secrets, radAgentCfg)
-	radAgent.rsAuth = make(map[string]*radigo.Server, len(radAgentCfg.Listeners))
-	radAgent.rsAcct = make(map[string]*radigo.Server, len(radAgentCfg.Listeners))
+	if radAgentCfg.EAPServer.Address != utils.EmptyString {
+		radAgent.eapClnt = newRadEAPClient(radAgentCfg.EAPServer, cgrCfg.GeneralCfg().ReplyTimeout)
+	}
+	radAgent.rsAuth = make(map[string]radiusServer, len(radAgentCfg.Listeners))
+	radAgent.rsAcct = make(map[string]radiusServer, len(radAgentCfg.Listeners))
+	authHandlers := map[radigo.PacketCode]radHandler{
+		radigo.AccessRequest: radAgent.handleAuth,
+		radigo.StatusServer:  radAgent.handleAuth,
+	}
+	acctHandlers := map[radigo.PacketCode]radHandler{
+		radigo.AccountingRequest: radAgent.handleAcct,
+		radigo.StatusServer:      radAgent.handleAcct,
+	}
 	for i := range radAgentCfg.Listeners {
 		net := radAgentCfg.Listeners[i].Network
 		authAddr := radAgentCfg.Listeners[i].AuthAddr
+		acctAddr := radAgentCfg.Listeners[i].AcctAddr
+		if net == utils.TCPTLS { // RadSec
+			tlsCfg, err := radsecTLSConfig(cgrCfg.TLSCfg().ServerCerificate, cgrCfg.TLSCfg().ServerKey,
+				cgrCfg.TLSCfg().CaCertificate, cgrCfg.TLSCfg().ServerName)
+			if err != nil {
+				return nil, err
+			}
+			if authAddr == acctAddr { // RFC 6614 uses one port for both authentication and accounting
+				radAgent.rsAuth[net+"://"+authAddr] = newRadsecServer(authAddr, tlsCfg, secrets, dicts,
+					map[radigo.PacketCode]radHandler{
+						radigo.AccessRequest:     radAgent.handleAuth,
+						radigo.AccountingRequest: radAgent.handleAcct,
+						radigo.StatusServer:      radAgent.handleAuth,
+					})
+				continue
+			}
+			radAgent.rsAuth[net+"://"+authAddr] = newRadsecServer(authAddr, tlsCfg, secrets, dicts, authHandlers)
+			radAgent.rsAcct[net+"://"+acctAddr] = newRadsecServer(acctAddr, tlsCfg, secrets, dicts, acctHandlers)
+			continue
+		}
 		radAgent.rsAuth[net+"://"+authAddr] = radigo.NewServer(net, authAddr, secrets, dicts,
 			map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
-				radigo.AccessRequest: radAgent.handleAuth,
-				radigo.StatusServer:  radAgent.handleAuth,
+				radigo.AccessRequest: radigoHandler(radAgent.handleAuth),
+				radigo.StatusServer:  radigoHandler(radAgent.handleAuth),
 			}, nil, utils.Logger)
-		acctAddr := radAgentCfg.Listeners[i].AcctAddr
 		radAgent.rsAcct[net+"://"+acctAddr] = radigo.NewServer(net, acctAddr, secrets, dicts,
 			map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
-				radigo.AccountingRequest: radAgent.handleAcct,
-				radigo.StatusServer:      radAgent.handleAcct,
+				radigo.AccountingRequest: radigoHandler(radAgent.handleAcct),
+				radigo.StatusServer:      radigoHandler(radAgent.handleAcct),
 			}, nil, utils.Logger)
 	}
 	return radAgent, nil
@@ -108,9 +139,11 @@ type RadiusAgent struct {
 	connMgr *engine.ConnManager
 	caps    *engine.Caps
 	filterS *engine.FilterS
-	rsAuth  map[string]*radigo.Server
-	rsAcct  map[string]*radigo.Server
+	rsAuth  map[string]radiusServer
+	rsAcct  map[string]radiusServer
+	secrets *radigo.Secrets
 	dacCfg  radiusDAClientCfg
+	eapClnt *radEAPClient // nil when EAP proxying is disabled
 	ctx     *context.Context
 	sync.WaitGroup
 }
@@ -143,17 +176,103 @@ func newRadiusDAClientCfg(dicts *radigo.Dictionaries, secrets *radigo.Secrets,
 	return rdac
 }
 
-// handleAuth handles RADIUS Authorization request
-func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet) (*radigo.Packet, error) {
+// clientSecret returns the secret shared with the client sending from remoteAddr
+func (ra *RadiusAgent) clientSecret(remoteAddr net.Addr) string {
+	host, _, err := net.SplitHostPort(remoteAddr.String())
+	if err != nil {
+		host = remoteAddr.String()
+	}
+	return ra.secrets.GetSecret(host)
+}
+
+// handleStatusServer answers the Status-Server probes (RFC 5997) locally, without involving SessionS.
+// Probes without a valid Message-Authenticator are silently discarded.
+func (ra *RadiusAgent) handleStatusServer(reqPacket *radigo.Packet, remoteAddr net.Addr,
+	replyCode radigo.PacketCode) (*radigo.Packet, error) {
+	secret := ra.clientSecret(remoteAddr)
+	if err := radCheckMessageAuthenticator(reqPacket, reqPacket.Authenticator, secret, true); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: <%v> ignoring Status-Server from <%s>",
+			utils.RadiusAgent, err, remoteAddr))
+		return nil, nil
+	}
+	replyPacket := reqPacket.Reply()
+	replyPacket.Code = replyCode
+	radSetMessageAuthenticator(replyPacket, reqPacket.Authenticator, secret)
+	return replyPacket, nil
+}
+
+// proxyEAP relays one round of an EAP conversation to the EAP server. The State received with the
+// Access-Challenge is cached so that the following rounds are accepted only from the same client.
+// The reply is returned together with the confirmation that the conversation ended with Access-Accept.
+func (ra *RadiusAgent) proxyEAP(reqPacket *radigo.Packet, remoteAddr net.Addr,
+	secret string) (*radigo.Packet, bool, error) {
+	if err := radCheckMessageAuthenticator(reqPacket, reqPacket.Authenticator, secret, true); err != nil {
+		return nil, false, err
+	}
+	state := radAttribute(reqPacket, radStateAttr)
+	if state != nil {
+		cached, has := engine.Cache.Get(utils.CacheRadiusPackets, radEAPStateKey(state))
+		if !has {
+			return nil, false, fmt.Errorf("unknown EAP conversation: %w", utils.ErrNotFound)
+		}
+		cachedHost, _, _ := net.SplitHostPort(cached.(*radCachedPacket).remoteAddr)
+		if reqHost, _, _ := net.SplitHostPort(remoteAddr.String()); reqHost != cachedHost {
+			return nil, false, fmt.Errorf("EAP conversation started by <%s>", cachedHost)
+		}
+	}
+	rply, err := ra.eapClnt.proxy(reqPacket, secret)
+	if err != nil {
+		return nil, false, err
+	}
+	if rply.Code != radigo.AccessChallenge { // conversation ended
+		if state != nil {
+			engine.Cache.Remove(utils.CacheRadiusPackets, radEAPStateKey(state),
+				true, utils.NonTransactional)
+		}
+		return rply, rply.Code == radigo.AccessAccept, nil
+	}
+	if rplyState := radAttribute(rply, radStateAttr); rplyState != nil {
+		if err = engine.Cache.Set(utils.CacheRadiusPackets, radEAPStateKey(rplyState),
+			&radCachedPacket{packet: reqPacket, remoteAddr: remoteAddr.String()},
+			nil, true, utils.NonTransactional); err != nil {
+			return nil, false, fmt.Errorf("failed to cache EAP state: %w", err)
+		}
+	}
+	return rply, false, nil
+}
+
+// handleAuth handles RADIUS Authorization request.
+// Requests carrying EAP-Message are authenticated by the EAP server when configured,
+// only the final Access-Accept being passed to the request processors.
+func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error) {
+	if reqPacket.Code == radigo.StatusServer {
+		return ra.handleStatusServer(reqPacket, remoteAddr, radigo.AccessAccept)
+	}
 	if ra.caps.IsLimited() {
 		if err := ra.caps.Allocate(); err != nil {
 			return reqPacket, err
 		}
 		defer ra.caps.Deallocate()
 	}
-	reqPacket.SetAVPValues() // populate string values in AVPs
 	replyPacket := reqPacket.Reply()
 	replyPacket.Code = radigo.AccessAccept
+	var eapSecret string // set when the reply needs to be signed for the EAP peer
+	// proxied before populating the AVP values which decrypts the User-Password again
+	if ra.eapClnt != nil && radAttribute(reqPacket, radEAPMessageAttr) != nil {
+		eapSecret = ra.clientSecret(remoteAddr)
+		eapRply, accepted, err := ra.proxyEAP(reqPacket, remoteAddr, eapSecret)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: <%v> proxying EAP request from <%s>",
+				utils.RadiusAgent, err, remoteAddr))
+			return nil, nil
+		}
+		if !accepted { // Access-Challenge or Access-Reject, nothing to charge yet
+			radSetMessageAuthenticator(eapRply, reqPacket.Authenticator, eapSecret)
+			return eapRply, nil
+		}
+		replyPacket = eapRply
+	}
+	reqPacket.SetAVPValues() // populate string values in AVPs
 	cgrReplyNM := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{}}
 	replyNM := utils.NewOrderedNavigableMap()
 	opts := utils.MapStorage{}
@@ -161,7 +280,7 @@ func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet) (*radigo.Packet, err
 	varsDataNode := &utils.DataNode{
 		Type: utils.NMMapType,
 		Map: map[string]*utils.DataNode{
-			utils.RemoteHost: utils.NewLeafNode(reqPacket.RemoteAddr().String()),
+			utils.RemoteHost: utils.NewLeafNode(remoteAddr.String()),
 			MetaRadReqCode:   utils.NewLeafNode(reqPacket.Code.String()),
 			MetaRadReqType:   utils.NewLeafNode(MetaRadAuth),
 		},
@@ -197,12 +316,18 @@ func (ra *RadiusAgent) handleAuth(reqPacket *radigo.Packet) (*radigo.Packet, err
 			utils.RadiusAgent, err, utils.ToIJSON(reqPacket)))
 		return nil, err
 	}
+	if eapSecret != utils.EmptyString {
+		radSetMessageAuthenticator(replyPacket, reqPacket.Authenticator, eapSecret)
+	}
 	return replyPacket, nil
 }
 
 // handleAcct processes RADIUS Accounting requests and generates a reply.
 // It supports Acct-Status-Type values: Start, Interim-Update, Stop.
-func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, error) {
+func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet, remoteAddr net.Addr) (*radigo.Packet, error) {
+	if reqPacket.Code == radigo.StatusServer {
+		return ra.handleStatusServer(reqPacket, remoteAddr, radigo.AccountingResponse)
+	}
 	if ra.caps.IsLimited() {
 		if err := ra.caps.Allocate(); err != nil {
 			return nil, err
@@ -219,11 +344,10 @@ func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, err
 	rplyNM := utils.NewOrderedNavigableMap()
 	opts := utils.MapStorage{}
 
-	remoteAddr := reqPacket.RemoteAddr().String()
 	varsDataNode := &utils.DataNode{
 		Type: utils.NMMapType,
 		Map: map[string]*utils.DataNode{
-			utils.RemoteHost: utils.NewLeafNode(remoteAddr),
+			utils.RemoteHost: utils.NewLeafNode(remoteAddr.String()),
 			MetaRadReqType:   utils.NewLeafNode(MetaRadAccount),
 			MetaRadReqCode:   utils.NewLeafNode(reqPacket.Code.String()),
 		},
@@ -266,7 +390,7 @@ func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, err
 
 	// Cache the RADIUS Packet for future CoA/Disconnect Requests.
 	if cacheKeyTpl := radAgentCfg.RequestsCacheKey; cacheKeyTpl != nil {
-		err := cacheRadiusPacket(reqPacket, remoteAddr, radAgentCfg,
+		err := cacheRadiusPacket(reqPacket, remoteAddr.String(), radAgentCfg,
 			utils.MapStorage{
 				utils.MetaReq:  radDP,
 				utils.MetaVars: varsDataNode,
@@ -284,6 +408,13 @@ func (ra *RadiusAgent) handleAcct(reqPacket *radigo.Packet) (*radigo.Packet, err
 	return replyPacket, nil
 }
 
+// radCachedPacket is a RADIUS request cached together with the address it was received from,
+// needed since the packets received over RadSec do not carry it.
+type radCachedPacket struct {
+	packet     *radigo.Packet
+	remoteAddr string
+}
+
 // cacheRadiusPacket caches a RADIUS packet if there are client options found for its source address.
 func cacheRadiusPacket(packet *radigo.Packet, address string, cfg *config.RadiusAgentCfg,
 	dp utils.DataProvider) error {
@@ -295,7 +426,8 @@ func cacheRadiusPacket(packet *radigo.Packet, address string, cfg *config.Radius
 	if err != nil {
 		return fmt.Errorf("failed to parse the RADIUS packet cache key: %w", err)
 	}
-	if err = engine.Cache.Set(utils.CacheRadiusPackets, cacheKey, packet,
+	if err = engine.Cache.Set(utils.CacheRadiusPackets, cacheKey,
+		&radCachedPacket{packet: packet, remoteAddr: address},
 		nil, true, utils.NonTransactional); err != nil {
 		return fmt.Errorf("failed to cache RADIUS packet: %w", err)
 	}
@@ -580,7 +712,7 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	errListen := make(chan error, 2)
 	for uri, server := range ra.rsAuth {
 		ra.Add(1)
-		go func(srv *radigo.Server, uri string) {
+		go func(srv radiusServer, uri string) {
 			defer ra.Done()
 			utils.Logger.Info(fmt.Sprintf("<%s> Start listening for auth requests on <%s>", utils.RadiusAgent, uri))
 			if err := srv.ListenAndServe(stopChan); err != nil {
@@ -595,7 +727,7 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	}
 	for uri, server := range ra.rsAcct {
 		ra.Add(1)
-		go func(srv *radigo.Server, uri string) {
+		go func(srv radiusServer, uri string) {
 			defer ra.Done()
 			utils.Logger.Info(fmt.Sprintf("<%s> Start listening for acct requests on <%s>", utils.RadiusAgent, uri))
 			if err := srv.ListenAndServe(stopChan); err != nil {
@@ -695,7 +827,7 @@ func (ra *RadiusAgent) sendRadDaReq(requestType radigo.PacketCode, requestTempla
 	if !has {
 		return 0, fmt.Errorf("failed to retrieve packet from cache: %w", utils.ErrNotFound)
 	}
-	packet := cachedPacket.(*radigo.Packet)
+	packet := cachedPacket.(*radCachedPacket).packet
 
 	agReq := NewAgentRequest(
 		requestEv, requestVars, nil, nil, nil, nil,
@@ -709,7 +841,7 @@ func (ra *RadiusAgent) sendRadDaReq(requestType radigo.PacketCode, requestTempla
 		return 0, fmt.Errorf("could not set attributes: %w", err)
 	}
 
-	remoteAddr, remoteHost, err := daRequestAddress(packet.RemoteAddr().String(),
+	remoteAddr, remoteHost, err := daRequestAddress(cachedPacket.(*radCachedPacket).remoteAddr,
 		ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses)
 	if err != nil {
 		return 0, fmt.Errorf("retrieving remote address failed: %w", err)
@@ -18,12 +18,17 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
+	"crypto/rand"
 	"fmt"
+	"net"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/birpc/context"
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
@@ -193,3 +198,226 @@ func TestRadagentDaRequestAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestRadagentV1DisconnectSessionRadSecClient(t *testing.T) {
+	pc, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daAddr := pc.LocalAddr().(*net.UDPAddr)
+	pc.Close()
+	dicts := radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad})
+	rcvUserName := make(chan string, 1)
+	daSrv := radigo.NewServer(utils.UDP, daAddr.String(),
+		radigo.NewSecrets(map[string]string{utils.MetaDefault: "radsecClientSecret"}), dicts,
+		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
+			radigo.DisconnectRequest: func(req *radigo.Packet) (*radigo.Packet, error) {
+				req.SetAVPValues()
+				if avps := req.AttributesWithName("User-Name", utils.EmptyString); len(avps) != 0 {
+					rcvUserName <- avps[0].StringValue
+				}
+				rply := req.Reply()
+				rply.Code = radigo.DisconnectACK
+				return rply, nil
+			},
+		}, nil, nil)
+	stop := make(chan struct{})
+	defer close(stop)
+	go daSrv.ListenAndServe(stop)
+	time.Sleep(50 * time.Millisecond) // give time to the DA server to bind
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RadiusAgentCfg().ClientSecrets = map[string]string{
+		utils.MetaDefault: "CGRateS.org",
+		"127.0.0.1":       "radsecClientSecret",
+	}
+	cfg.RadiusAgentCfg().ClientDaAddresses = map[string]config.DAClientOpts{
+		"127.0.0.1": {
+			Transport: utils.UDP,
+			Host:      "127.0.0.1",
+			Port:      daAddr.Port,
+		},
+	}
+	ra := &RadiusAgent{
+		cgrCfg: cfg,
+		dacCfg: newRadiusDAClientCfg(dicts, radigo.NewSecrets(cfg.RadiusAgentCfg().ClientSecrets),
+			cfg.RadiusAgentCfg()),
+	}
+
+	// packets received over RadSec carry the client address only inside the cache
+	reqPacket := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "radsecClientSecret")
+	if err = reqPacket.AddAVPWithName("User-Name", "1001", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if err = cacheRadiusPacket(reqPacket, "127.0.0.1:40000", &config.RadiusAgentCfg{
+		ClientDaAddresses: cfg.RadiusAgentCfg().ClientDaAddresses,
+		RequestsCacheKey:  config.NewRSRParsersMustCompile("radsecSession1", utils.InfieldSep),
+	}, utils.MapStorage{}); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Cache.Remove(utils.CacheRadiusPackets, "radsecSession1", true, utils.NonTransactional)
+
+	var reply string
+	if err = ra.V1DisconnectSession(nil, utils.CGREvent{
+		Event: map[string]any{utils.OriginID: "radsecSession1"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("expected %s, received %s", utils.OK, reply)
+	}
+	select {
+	case userName := <-rcvUserName:
+		if userName != "1001" {
+			t.Errorf("expected User-Name 1001, received %s", userName)
+		}
+	case <-time.After(time.Second):
+		t.Error("DisconnectRequest not received")
+	}
+}
+
+func TestRadagentHandleStatusServer(t *testing.T) {
+	ra := &RadiusAgent{
+		secrets: radigo.NewSecrets(map[string]string{
+			utils.MetaDefault: "CGRateS.org",
+			"127.0.0.1":       "nasSecret",
+		}),
+	}
+	remoteAddr := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 40000}
+	req := radigo.NewPacket(radigo.StatusServer, 3, dictRad, coder, "nasSecret")
+	rand.Read(req.Authenticator[:])
+	if rply, err := ra.handleAuth(req, remoteAddr); err != nil || rply != nil {
+		t.Errorf("expected probe without Message-Authenticator to be discarded, received %v, %v", rply, err)
+	}
+	radSetMessageAuthenticator(req, req.Authenticator, "nasSecret")
+	for handler, code := range map[string]radigo.PacketCode{
+		"auth": radigo.AccessAccept,
+		"acct": radigo.AccountingResponse,
+	} {
+		hndlr := ra.handleAuth
+		if handler == "acct" {
+			hndlr = ra.handleAcct
+		}
+		// caps and SessionS are not initialized, the probe is answered by the agent itself
+		rply, err := hndlr(req, remoteAddr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rply.Code != code || rply.Identifier != req.Identifier {
+			t.Errorf("%s: expected %s for request 3, received %s for request %d",
+				handler, code, rply.Code, rply.Identifier)
+		}
+		if err = radCheckMessageAuthenticator(rply, req.Authenticator, "nasSecret", true); err != nil {
+			t.Errorf("%s: %v", handler, err)
+		}
+	}
+}
+
+func TestRadagentHandleAuthEAP(t *testing.T) {
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(`{
+"radius_agent": {
+	"request_processors": [{
+		"id": "EAPAccept",
+		"flags": ["*none"],
+		"request_fields": [],
+		"reply_fields": [
+			{"tag": "ReplyMessage", "path": "*rep.Reply-Message", "type": "*constant", "value": "charged"}
+		]
+	}]
+}
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.RadiusAgentCfg().EAPServer = config.EAPServerOpts{
+		Address:   testRadEAPServer(t, "eapSecret"),
+		Transport: utils.UDP,
+		Secret:    "eapSecret",
+	}
+	ra := &RadiusAgent{
+		cgrCfg:  cfg,
+		caps:    engine.NewCaps(0, utils.MetaBusy),
+		filterS: engine.NewFilterS(cfg, nil, nil),
+		secrets: radigo.NewSecrets(map[string]string{
+			utils.MetaDefault: "CGRateS.org",
+			"127.0.0.1":       "nasSecret",
+			"127.0.0.2":       "nasSecret",
+		}),
+		eapClnt: newRadEAPClient(cfg.RadiusAgentCfg().EAPServer, time.Second),
+	}
+	nasAddr := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 40000}
+	newEAPRequest := func(id uint8, state []byte) *radigo.Packet {
+		req := radigo.NewPacket(radigo.AccessRequest, id, dictRad, coder, "nasSecret")
+		rand.Read(req.Authenticator[:])
+		if err := req.AddAVPWithName("User-Name", "1001", utils.EmptyString); err != nil {
+			t.Fatal(err)
+		}
+		req.AVPs = append(req.AVPs, &radigo.AVP{Number: radEAPMessageAttr, RawValue: []byte{2, id, 0, 5, 1}})
+		if state != nil {
+			req.AVPs = append(req.AVPs, &radigo.AVP{Number: radStateAttr, RawValue: state})
+		}
+		radSetMessageAuthenticator(req, req.Authenticator, "nasSecret")
+		return req
+	}
+
+	// challenged by the EAP server, not charged
+	req := newEAPRequest(1, nil)
+	rply, err := ra.handleAuth(req, nasAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rply.Code != radigo.AccessChallenge {
+		t.Fatalf("expected AccessChallenge, received %s", rply.Code)
+	}
+	if err = radCheckMessageAuthenticator(rply, req.Authenticator, "nasSecret", true); err != nil {
+		t.Error(err)
+	}
+	if radAttribute(rply, radigo.ReplyMessage) != nil {
+		t.Error("expected the challenge not to be processed")
+	}
+	state := radAttribute(rply, radStateAttr)
+	t.Cleanup(func() {
+		engine.Cache.Remove(utils.CacheRadiusPackets, radEAPStateKey(state), true, utils.NonTransactional)
+	})
+
+	// State issued for another client, discarded
+	if rply, err = ra.handleAuth(newEAPRequest(2, state),
+		&net.UDPAddr{IP: net.ParseIP("127.0.0.2"), Port: 40000}); err != nil || rply != nil {
+		t.Errorf("expected request to be discarded, received %v, %v", rply, err)
+	}
+	if rply, err = ra.handleAuth(newEAPRequest(2, []byte("unknown")), nasAddr); err != nil || rply != nil {
+		t.Errorf("expected request with unknown State to be discarded, received %v, %v", rply, err)
+	}
+
+	// accepted by the EAP server and charged
+	req = newEAPRequest(3, state)
+	if rply, err = ra.handleAuth(req, nasAddr); err != nil {
+		t.Fatal(err)
+	}
+	if rply.Code != radigo.AccessAccept {
+		t.Fatalf("expected AccessAccept, received %s", rply.Code)
+	}
+	if err = radCheckMessageAuthenticator(rply, req.Authenticator, "nasSecret", true); err != nil {
+		t.Error(err)
+	}
+	if rplyMsg := radAttribute(rply, radigo.ReplyMessage); string(rplyMsg) != "charged" {
+		t.Errorf("expected Reply-Message charged, received %q", rplyMsg)
+	}
+	vsa := radAttribute(rply, radigo.VendorSpecificNumber)
+	if len(vsa) < 8 {
+		t.Fatalf("expected MS-MPPE-Recv-Key, received %v", vsa)
+	}
+	if key := radMPPEKeyCrypt(vsa[8:], vsa[6:8], "nasSecret", req.Authenticator, true); !bytes.Equal(key, testRadMPPEKey) {
+		t.Errorf("expected key %q, received %q", testRadMPPEKey, key)
+	}
+	if _, has := engine.Cache.Get(utils.CacheRadiusPackets, radEAPStateKey(state)); has {
+		t.Error("expected the EAP conversation to be removed from cache")
+	}
+	var buf [radigo.MaxPacketLen]byte
+	n, err := rply.Encode(buf[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !radIsAuthenticReply(buf[:n], req.Authenticator, "nasSecret") {
+		t.Error("expected authentic reply")
+	}
+}
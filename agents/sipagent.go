@@ -19,9 +19,14 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -31,19 +36,37 @@ import (
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
 	"github.com/cgrates/sipingo"
+	"golang.org/x/net/websocket"
 )
 
 const (
-	bufferSize      = 5000
-	ackMethod       = "ACK"
-	inviteMethod    = "INVITE"
-	requestHeader   = "Request"
-	callIDHeader    = "Call-ID"
-	fromHeader      = "From"
-	sipServerErr    = "SIP/2.0 500 Internal Server Error"
-	userAgentHeader = "User-Agent"
-	method          = "Method"
+	bufferSize             = 5000
+	ackMethod              = "ACK"
+	inviteMethod           = "INVITE"
+	optionsMethod          = "OPTIONS"
+	registerMethod         = "REGISTER"
+	messageMethod          = "MESSAGE"
+	requestHeader          = "Request"
+	callIDHeader           = "Call-ID"
+	fromHeader             = "From"
+	viaHeader              = "Via"
+	allowHeader            = "Allow"
+	authorizationHeader    = "Authorization"
+	proxyAuthHeader        = "Proxy-Authorization"
+	wwwAuthHeader          = "WWW-Authenticate"
+	proxyAuthenticate      = "Proxy-Authenticate"
+	sipServerErr           = "SIP/2.0 500 Internal Server Error"
+	sipOK                  = "SIP/2.0 200 OK"
+	sipForbidden           = "SIP/2.0 403 Forbidden"
+	sipUnauthorized        = "SIP/2.0 401 Unauthorized"
+	sipProxyAuthReq        = "SIP/2.0 407 Proxy Authentication Required"
+	sipAllowedMethods      = "INVITE, ACK, OPTIONS, REGISTER, MESSAGE"
+	sipNonceTTL            = 5 * time.Minute // validity of the digest challenge
+	sipTLSHandshakeTimeout = 10 * time.Second
+	userAgentHeader        = "User-Agent"
+	method                 = "Method"
 )
 
 var (
@@ -60,6 +83,11 @@ func NewSIPAgent(connMgr *engine.ConnManager, cfg *config.CGRConfig,
 		caps:     caps,
 		ackMap:   make(map[string]chan struct{}),
 		stopChan: make(chan struct{}),
+		nonceKey: make([]byte, 32),
+		nonceCnt: ltcache.NewCache(ltcache.UnlimitedCaching, sipNonceTTL, true, false, nil),
+	}
+	if _, err = rand.Read(sa.nonceKey); err != nil {
+		return nil, err
 	}
 	msgTemplates := sa.cfg.TemplatesCfg()
 	// Inflate *template field types
@@ -87,6 +115,9 @@ type SIPAgent struct {
 	stopChan chan struct{}
 	ackMap   map[string]chan struct{}
 	ackLocks sync.RWMutex
+	nonceKey []byte         // signs the nonces of the digest challenges
+	nonceCnt *ltcache.Cache // last nonce count accepted for each nonce, protects against replays
+	nonceMux sync.Mutex
 }
 
 // Shutdown will stop the SIPAgent server
@@ -103,15 +134,33 @@ func (sa *SIPAgent) Shutdown() {
 func (sa *SIPAgent) ListenAndServe() (err error) {
 	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s:%s>",
 		utils.SIPAgent, sa.cfg.SIPAgentCfg().ListenNet, sa.cfg.SIPAgentCfg().Listen))
+	var tlsCfg *tls.Config
 	switch sa.cfg.SIPAgentCfg().ListenNet {
-	case utils.TCP:
-		return sa.serveTCP(sa.stopChan)
+	case utils.TCPTLS, utils.WSS:
+		if tlsCfg, err = sa.tlsConfig(); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> error: %s", utils.SIPAgent, err.Error()))
+			return
+		}
+	}
+	switch sa.cfg.SIPAgentCfg().ListenNet {
+	case utils.TCP, utils.TCPTLS:
+		return sa.serveTCP(sa.stopChan, tlsCfg)
 	case utils.UDP:
 		return sa.serveUDP(sa.stopChan)
+	case utils.WS, utils.WSS:
+		return sa.serveWS(sa.stopChan, tlsCfg)
 	default:
 		return fmt.Errorf("Unecepected protocol %s", sa.cfg.SIPAgentCfg().ListenNet)
 	}
 }
+
+// tlsConfig builds the server side TLS configuration out of the engine TLS config
+func (sa *SIPAgent) tlsConfig() (*tls.Config, error) {
+	tlsCfg := sa.cfg.TLSCfg()
+	return utils.LoadTLSConfig(tlsCfg.ServerCerificate, tlsCfg.ServerKey,
+		tlsCfg.CaCertificate, tlsCfg.ServerPolicy, tlsCfg.ServerName)
+}
+
 func (sa *SIPAgent) InitStopChan() {
 	sa.stopChan = make(chan struct{})
 }
@@ -133,7 +182,7 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 		select {
 		case <-stop:
 			wg.Wait()
-			return
+			return nil // the last deadline error is not relevant
 		default:
 		}
 		conn.SetDeadline(time.Now().Add(time.Second))
@@ -155,7 +204,7 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 		}
 		wg.Add(1)
 		go func(message string, saddr net.Addr, conn net.PacketConn) {
-			sa.answerMessage(message, saddr.String(), func(ans []byte) (werr error) {
+			sa.answerMessage(message, saddr.String(), false, func(ans []byte) (werr error) {
 				_, werr = conn.WriteTo(ans, saddr)
 				return
 			}) // do not log the received error because is already logged in function so for now just ignore it
@@ -164,7 +213,8 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 	}
 }
 
-func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
+// serveTCP serves SIP over TCP, wrapping the connections in TLS if tlsCfg is provided
+func (sa *SIPAgent) serveTCP(stop chan struct{}, tlsCfg *tls.Config) (err error) {
 	var l *net.TCPListener
 	var addr *net.TCPAddr
 	if addr, err = net.ResolveTCPAddr("tcp", sa.cfg.SIPAgentCfg().Listen); err != nil {
@@ -187,7 +237,7 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 		select {
 		case <-stop:
 			wg.Wait()
-			return
+			return nil // the last deadline error is not relevant
 		default:
 		}
 		l.SetDeadline(time.Now().Add(time.Second))
@@ -203,19 +253,38 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 		}
 		wg.Add(1)
 		go func(conn net.Conn) {
+			defer wg.Done()
+			defer conn.Close()
+			if tlsCfg != nil {
+				tlsConn := tls.Server(conn, tlsCfg)
+				tlsConn.SetDeadline(time.Now().Add(sipTLSHandshakeTimeout))
+				if err := tlsConn.Handshake(); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> error: %s on TLS handshake with: %s",
+							utils.SIPAgent, err.Error(), conn.RemoteAddr()))
+					return
+				}
+				tlsConn.SetDeadline(time.Time{})
+				conn = tlsConn
+			}
+			addr := conn.LocalAddr().String()
+			if tlsCfg != nil {
+				addr = conn.RemoteAddr().String()
+			}
 			buf := make([]byte, bufferSize)
 			for {
 				select {
 				case <-stop:
-					conn.Close()
-					wg.Done()
 					return
 				default:
 				}
 				conn.SetReadDeadline(time.Now().Add(time.Second))
 				n, err := conn.Read(buf)
 				if err != nil {
-					continue
+					if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+						continue
+					}
+					return // connection closed by the peer
 				}
 				// echo response
 				if n < 50 {
@@ -223,7 +292,7 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 					continue
 				}
 
-				sa.answerMessage(string(buf[:n]), conn.LocalAddr().String(), func(ans []byte) (werr error) {
+				sa.answerMessage(string(buf[:n]), addr, tlsCfg != nil, func(ans []byte) (werr error) {
 					_, werr = conn.Write(ans)
 					return
 				}) // do not log the received error because is already logged in function so for now just ignore it
@@ -232,7 +301,76 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 	}
 }
 
-func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte) error) (err error) {
+// serveWS serves SIP over WebSocket as described in RFC 7118, over TLS if tlsCfg is provided
+func (sa *SIPAgent) serveWS(stop chan struct{}, tlsCfg *tls.Config) (err error) {
+	var l net.Listener
+	if l, err = net.Listen(utils.TCP, sa.cfg.SIPAgentCfg().Listen); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: %s unable to listen to: %s",
+				utils.SIPAgent, err.Error(), sa.cfg.SIPAgentCfg().Listen))
+		return
+	}
+	if tlsCfg != nil {
+		l = tls.NewListener(l, tlsCfg)
+	}
+	srv := &http.Server{
+		Handler: websocket.Server{
+			Handshake: sipWSHandshake,
+			Handler:   sa.handleWSConn(stop),
+		},
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.Serve(l)
+	}()
+	select {
+	case <-stop:
+		srv.Close() // the hijacked connections are closed by their handlers
+		return
+	case err = <-errChan:
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: %s serving on: %s",
+				utils.SIPAgent, err.Error(), sa.cfg.SIPAgentCfg().Listen))
+		return
+	}
+}
+
+// handleWSConn returns the handler of one WebSocket connection, each WebSocket message carrying one SIP message
+func (sa *SIPAgent) handleWSConn(stop chan struct{}) websocket.Handler {
+	return func(ws *websocket.Conn) {
+		ws.MaxPayloadBytes = bufferSize
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-stop:
+				ws.Close()
+			case <-done:
+			}
+		}()
+		defer ws.Close()
+		remoteAddr := ws.Request().RemoteAddr
+		for {
+			var msg string
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				if err == websocket.ErrFrameTooLarge {
+					continue
+				}
+				return
+			}
+			if strings.TrimSpace(msg) == utils.EmptyString { // keepalive
+				continue
+			}
+			sa.answerMessage(msg, remoteAddr, true, func(ans []byte) error {
+				return websocket.Message.Send(ws, string(ans))
+			}) // do not log the received error because is already logged in function so for now just ignore it
+		}
+	}
+}
+
+// answerMessage processes one SIP message, setting the received parameters of the topmost Via
+// to addr if viaReceived is true
+func (sa *SIPAgent) answerMessage(messageStr, addr string, viaReceived bool, write func(ans []byte) error) (err error) {
 	var sipMessage sipingo.Message // recreate map SIP
 	if sipMessage, err = sipingo.NewMessage(messageStr); err != nil {
 		utils.Logger.Warning(
@@ -240,6 +378,9 @@ func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte
 				utils.SIPAgent, err.Error(), messageStr))
 		return // do we need to return error in case we can't parse the message?
 	}
+	if via := sipMessage[viaHeader]; viaReceived && via != utils.EmptyString {
+		sipMessage[viaHeader] = sipViaReceived(via, addr)
+	}
 	tags := sipTagRgx.FindStringSubmatch(sipMessage[fromHeader])
 	// in case we get a wrong sip message ( without tag in the From header) the next line should panic
 	key := utils.ConcatenatedKey(sipMessage[callIDHeader], tags[1])
@@ -298,6 +439,10 @@ func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte
 }
 
 func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string) (sipAnswer sipingo.Message) {
+	reqMethod := sipMessage.MethodFrom(requestHeader)
+	if reqMethod == optionsMethod { // keepalive
+		return sipOptionsReply(sipMessage)
+	}
 	if sa.caps.IsLimited() {
 		if err := sa.caps.Allocate(); err != nil {
 			return bareSipErr(sipMessage, "SIP/2.0 503 Service Unavailable")
@@ -320,7 +465,7 @@ func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string)
 		Type: utils.NMMapType,
 		Map: map[string]*utils.DataNode{
 			utils.RemoteHost: utils.NewLeafNode(remoteHost),
-			method:           utils.NewLeafNode(sipMessage.MethodFrom(requestHeader)),
+			method:           utils.NewLeafNode(reqMethod),
 		},
 	}
 	// build the negative error answer
@@ -337,7 +482,11 @@ func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string)
 		return bareSipErr(sipMessage, sipServerErr)
 	}
 
-	for _, reqProcessor := range sa.cfg.SIPAgentCfg().RequestProcessors {
+	reqProcessors := sa.cfg.SIPAgentCfg().RequestProcessors
+	if procIDs, has := sa.cfg.SIPAgentCfg().MethodProcessors[reqMethod]; has {
+		reqProcessors = sipMethodProcessors(reqProcessors, procIDs)
+	}
+	for _, reqProcessor := range reqProcessors {
 		agReq := NewAgentRequest(dp, reqVars, cgrRplyNM, rplyNM,
 			opts, reqProcessor.Tenant, sa.cfg.GeneralCfg().DefaultTenant,
 			utils.FirstNonEmpty(reqProcessor.Timezone,
@@ -371,7 +520,17 @@ func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string)
 		return
 	}
 	if rplyNM.Empty() { // if we do not populate the reply with any field we do not send any reply back
-		return
+		if reqMethod != registerMethod && reqMethod != messageMethod {
+			return
+		}
+		// REGISTER and MESSAGE always expect a final answer
+		sipMessage[requestHeader] = sipOK
+		if errLeaf, _ := cgrRplyNM.Field([]string{utils.Error}); errLeaf != nil &&
+			errLeaf.String() != utils.EmptyString {
+			sipMessage[requestHeader] = sipForbidden
+		}
+		sipMessage.PrepareReply()
+		return sipMessage
 	}
 	if err = updateSIPMsgFromNavMap(sipMessage, rplyNM); err != nil {
 		utils.Logger.Warning(
@@ -400,8 +559,8 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 	for _, typ := range []string{
 		utils.MetaDryRun, utils.MetaAuthorize, /*
 			utils.MetaInitiate, utils.MetaUpdate,
-			utils.MetaTerminate, utils.MetaMessage,
-			utils.MetaCDRs, */utils.MetaEvent, utils.MetaNone} {
+			utils.MetaTerminate, utils.MetaCDRs, */
+		utils.MetaMessage, utils.MetaEvent, utils.MetaNone, utils.MetaSIPAuth} {
 		if reqProcessor.Flags.Has(typ) { // request type is identified through flags
 			reqType = typ
 			break
@@ -429,6 +588,7 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 			fmt.Sprintf("<%s> %s, processorID: <%s>, CGREvent: %s",
 				utils.SIPAgent, logPrefix, reqProcessor.ID, utils.ToIJSON(cgrEv)))
 	}
+	var authChallenge map[string]string // reply headers asking the client to authenticate
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
@@ -459,6 +619,45 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 		}
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		agReq.setCGRReply(rply, err)
+	case utils.MetaMessage:
+		msgArgs := sessions.NewV1ProcessMessageArgs(
+			reqProcessor.Flags.GetBool(utils.MetaAttributes),
+			reqProcessor.Flags.ParamsSlice(utils.MetaAttributes, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaThresholds),
+			reqProcessor.Flags.ParamsSlice(utils.MetaThresholds, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaStats),
+			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaResources),
+			reqProcessor.Flags.GetBool(utils.MetaIPs),
+			reqProcessor.Flags.Has(utils.MetaAccounts),
+			reqProcessor.Flags.GetBool(utils.MetaRoutes),
+			reqProcessor.Flags.Has(utils.MetaRoutesIgnoreErrors),
+			reqProcessor.Flags.Has(utils.MetaRoutesEventCost),
+			cgrEv, cgrArgs, reqProcessor.Flags.Has(utils.MetaFD),
+			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
+		)
+		rply := new(sessions.V1ProcessMessageReply)
+		err = sa.connMgr.Call(context.TODO(), sa.cfg.SIPAgentCfg().SessionSConns, utils.SessionSv1ProcessMessage,
+			msgArgs, rply)
+		if err != nil {
+			replyState = utils.ErrReplyStateMessage
+		}
+		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
+			cgrEv.Event[utils.Usage] = 0 // avoid further debits
+		} else if msgArgs.Debit {
+			cgrEv.Event[utils.Usage] = rply.MaxUsage // make sure the CDR reflects the debit
+		}
+		rply.SetMaxUsageNeeded(msgArgs.Debit)
+		agReq.setCGRReply(rply, err)
+	case utils.MetaSIPAuth:
+		var pass bool
+		if authChallenge, pass, err = sa.sipAuth(agReq); err != nil {
+			replyState = utils.ErrReplyStateSIPAuth
+			agReq.CGRReply.Map[utils.Error] = utils.NewLeafNode(err.Error())
+		} else if authChallenge == nil && !pass {
+			replyState = utils.ErrReplyStateSIPAuth
+			agReq.CGRReply.Map[utils.Error] = utils.NewLeafNode(utils.SIPAuthFailed)
+		}
 	case utils.MetaEvent:
 		evArgs := &sessions.V1ProcessEventArgs{
 			Flags:     reqProcessor.Flags.SliceFlags(),
@@ -483,6 +682,12 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 	if err := agReq.SetFields(reqProcessor.ReplyFields); err != nil {
 		return false, err
 	}
+	for hdr, val := range authChallenge { // the challenge overwrites the reply fields
+		if err := agReq.Reply.SetAsSlice(&utils.FullPath{PathSlice: []string{hdr}, Path: hdr},
+			[]*utils.DataNode{utils.NewLeafNode(val)}); err != nil {
+			return false, err
+		}
+	}
 	endTime := time.Now()
 	if reqProcessor.Flags.Has(utils.MetaLog) || reqType == utils.MetaDryRun {
 		logPrefix := "LOG"
@@ -546,3 +751,80 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 	}
 	return true, nil
 }
+
+// sipAuth verifies the digest credentials of the request against the password
+// found in *vars.UserPassword, returning the challenge headers if the client needs to (re)authenticate.
+// The digest username needs to match the charged subject and every nonce count can be used only once.
+func (sa *SIPAgent) sipAuth(agReq *AgentRequest) (challenge map[string]string, pass bool, err error) {
+	reqLine, _ := agReq.Request.FieldAsString([]string{requestHeader})
+	realm := sipRealm(reqLine)
+	authHdr, rplyStatus, chlgHdr := authorizationHeader, sipUnauthorized, wwwAuthHeader
+	if reqMethod := sipingo.MethodFrom(reqLine); reqMethod != registerMethod {
+		authHdr, rplyStatus, chlgHdr = proxyAuthHeader, sipProxyAuthReq, proxyAuthenticate
+	}
+	newChallenge := func(stale bool) map[string]string {
+		return map[string]string{
+			requestHeader: rplyStatus,
+			chlgHdr:       sipDigestChallenge(realm, sipNonce(sa.nonceKey, time.Now()), stale),
+		}
+	}
+	credentials, _ := agReq.Request.FieldAsString([]string{authHdr})
+	if credentials == utils.EmptyString {
+		return newChallenge(false), false, nil
+	}
+	var params map[string]string
+	if params, err = parseSIPDigest(credentials); err != nil {
+		return
+	}
+	if params["realm"] != realm {
+		return newChallenge(false), false, nil
+	}
+	if valid, stale := sipNonceValid(sa.nonceKey, params["nonce"], time.Now()); !valid {
+		return newChallenge(stale), false, nil
+	}
+	if params["username"] != sipChargedSubject(agReq) {
+		return nil, false, nil
+	}
+	nmItems, has := agReq.Vars.Map[utils.UserPassword]
+	if !has || len(nmItems.Slice) == 0 {
+		return nil, false, utils.ErrNotFound
+	}
+	expResp := sipDigestResponse(sipingo.MethodFrom(reqLine), nmItems.Slice[0].Value.String(), params)
+	if !hmac.Equal([]byte(expResp), []byte(strings.ToLower(params["response"]))) {
+		return nil, false, nil
+	}
+	if !sa.useNonceCount(params) { // replayed credentials
+		return newChallenge(false), false, nil
+	}
+	return nil, true, nil
+}
+
+// sipChargedSubject returns the identity charged for the request, defaulting to the user of the From header
+func sipChargedSubject(agReq *AgentRequest) string {
+	for _, fld := range []string{utils.Subject, utils.AccountField} {
+		if subj, _ := agReq.CGRRequest.FieldAsString([]string{fld}); subj != utils.EmptyString {
+			return subj
+		}
+	}
+	from, _ := agReq.Request.FieldAsString([]string{fromHeader})
+	return sipingo.UserFrom(from)
+}
+
+// useNonceCount records the nonce count of the authenticated request,
+// returning false if it is not higher than the last one used with the same nonce
+func (sa *SIPAgent) useNonceCount(params map[string]string) bool {
+	var nc uint64 // without qop the nonce can be used only once
+	if params["qop"] != utils.EmptyString {
+		var err error
+		if nc, err = strconv.ParseUint(params["nc"], 16, 64); err != nil {
+			return false
+		}
+	}
+	sa.nonceMux.Lock()
+	defer sa.nonceMux.Unlock()
+	if lastNC, has := sa.nonceCnt.Get(params["nonce"]); has && nc <= lastNC.(uint64) {
+		return false
+	}
+	sa.nonceCnt.Set(params["nonce"], nc, nil)
+	return true
+}
@@ -0,0 +1,382 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/birpc"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/sipingo"
+	"golang.org/x/net/websocket"
+)
+
+func newTestSIPAgent(t *testing.T) *SIPAgent {
+	t.Helper()
+	cfg := config.NewDefaultCGRConfig()
+	newFld := func(path, value string) *config.FCTemplate {
+		fld := &config.FCTemplate{Tag: path, Path: path, Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile(value, utils.InfieldSep)}
+		fld.ComputePath()
+		return fld
+	}
+	cfg.SIPAgentCfg().RequestProcessors = []*config.RequestProcessor{
+		{
+			ID:    "Password",
+			Flags: utils.FlagsWithParams{utils.MetaNone: {}, utils.MetaContinue: {}},
+			ReplyFields: []*config.FCTemplate{
+				newFld(utils.MetaVars+utils.NestingSep+utils.UserPassword, "CGRateS.org"),
+			},
+		},
+		{
+			ID:    "Auth",
+			Flags: utils.FlagsWithParams{utils.MetaSIPAuth: {}},
+		},
+		{
+			ID:    "Message",
+			Flags: utils.FlagsWithParams{utils.MetaNone: {}},
+		},
+		{
+			ID:    "NotAllowed",
+			Flags: utils.FlagsWithParams{utils.MetaNone: {}},
+			ReplyFields: []*config.FCTemplate{
+				newFld(utils.MetaRep+utils.NestingSep+requestHeader, "SIP/2.0 405 Method Not Allowed"),
+			},
+		},
+	}
+	cfg.TemplatesCfg()[utils.MetaErr] = []*config.FCTemplate{
+		newFld(utils.MetaRep+utils.NestingSep+requestHeader, sipServerErr),
+	}
+	cfg.SIPAgentCfg().MethodProcessors = map[string][]string{
+		registerMethod: {"Password", "Auth"},
+		messageMethod:  {"Message"},
+	}
+	idb, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fltrS := engine.NewFilterS(cfg, nil, engine.NewDataManager(idb, cfg.CacheCfg(), nil))
+	sa, err := NewSIPAgent(nil, cfg, fltrS, engine.NewCaps(0, utils.MetaBusy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sa
+}
+
+func newTestSIPMessage(t *testing.T, reqLine string, hdrs ...string) sipingo.Message {
+	t.Helper()
+	msg := reqLine + "\r\nCall-ID: a84b4c76e66710@pc33.cgrates.org\r\nCSeq: 1 " + sipingo.MethodFrom(reqLine) +
+		"\r\nFrom: <sip:1001@cgrates.org>;tag=1928301774\r\nTo: <sip:1001@cgrates.org>\r\n"
+	for _, hdr := range hdrs {
+		msg += hdr + "\r\n"
+	}
+	m, err := sipingo.NewMessage(msg + "Content-Length: 0\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSIPAgentHandleMessageOptions(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	rply := sa.handleMessage(newTestSIPMessage(t, "OPTIONS sip:cgrates.org SIP/2.0"), "127.0.0.1:5060")
+	if rply[requestHeader] != sipOK {
+		t.Errorf("Expected %q, received %q", sipOK, rply[requestHeader])
+	}
+	if rply[allowHeader] != sipAllowedMethods {
+		t.Errorf("Expected %q, received %q", sipAllowedMethods, rply[allowHeader])
+	}
+}
+
+func TestSIPAgentHandleMessageMethodProcessors(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	if rply := sa.handleMessage(newTestSIPMessage(t, "MESSAGE sip:1002@cgrates.org SIP/2.0"),
+		"127.0.0.1:5060"); rply[requestHeader] != sipOK {
+		t.Errorf("Expected %q, received %q", sipOK, rply[requestHeader])
+	}
+	// methods not listed in method_processors go through all processors
+	sa.cfg.SIPAgentCfg().RequestProcessors = sa.cfg.SIPAgentCfg().RequestProcessors[3:]
+	if rply := sa.handleMessage(newTestSIPMessage(t, "INVITE sip:1002@cgrates.org SIP/2.0"),
+		"127.0.0.1:5060"); rply[requestHeader] != "SIP/2.0 405 Method Not Allowed" {
+		t.Errorf("Expected %q, received %q", "SIP/2.0 405 Method Not Allowed", rply[requestHeader])
+	}
+}
+
+func TestSIPAgentHandleMessageRegisterDigest(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	reqLine := "REGISTER sip:cgrates.org SIP/2.0"
+	rply := sa.handleMessage(newTestSIPMessage(t, reqLine), "127.0.0.1:5060")
+	if rply[requestHeader] != sipUnauthorized {
+		t.Fatalf("Expected %q, received %q", sipUnauthorized, rply[requestHeader])
+	}
+	nonce := regexp.MustCompile(`nonce="([^"]+)"`).FindStringSubmatch(rply[wwwAuthHeader])
+	if len(nonce) != 2 {
+		t.Fatalf("Unexpected challenge: %q", rply[wwwAuthHeader])
+	}
+	if exp := sipDigestChallenge("cgrates.org", nonce[1], false); rply[wwwAuthHeader] != exp {
+		t.Fatalf("Expected %q, received %q", exp, rply[wwwAuthHeader])
+	}
+	chlg := map[string]string{"realm": "cgrates.org", "nonce": nonce[1]}
+
+	authHdr := func(username, password, nc string) string {
+		params := map[string]string{
+			"username": username,
+			"realm":    chlg["realm"],
+			"nonce":    chlg["nonce"],
+			"uri":      "sip:cgrates.org",
+			"qop":      "auth",
+			"nc":       nc,
+			"cnonce":   "0a4f113b",
+		}
+		return fmt.Sprintf(`%s: Digest username="%s",realm="%s",nonce="%s",uri="sip:cgrates.org",response="%s",algorithm=MD5,qop=auth,nc=%s,cnonce="0a4f113b"`,
+			authorizationHeader, username, params["realm"], params["nonce"], sipDigestResponse(registerMethod, password, params), nc)
+	}
+	for _, tc := range []struct {
+		username, password, nc string
+		exp                    string
+	}{
+		{"1001", "CGRateS.org", "00000001", sipOK},
+		{"1001", "CGRateS.org", "00000001", sipUnauthorized}, // replayed nonce count
+		{"1001", "wrongPassword", "00000002", sipForbidden},
+		{"1001", "CGRateS.org", "00000002", sipOK},
+		{"1002", "CGRateS.org", "00000003", sipForbidden}, // not the user from the From header
+	} {
+		if rply = sa.handleMessage(newTestSIPMessage(t, reqLine, authHdr(tc.username, tc.password, tc.nc)),
+			"127.0.0.1:5060"); rply[requestHeader] != tc.exp {
+			t.Errorf("Expected %q for %+v, received %q", tc.exp, tc, rply[requestHeader])
+		}
+	}
+	// unknown nonce is challenged again
+	if rply = sa.handleMessage(newTestSIPMessage(t, reqLine,
+		`Authorization: Digest username="1001",realm="cgrates.org",nonce="1.abc",uri="sip:cgrates.org",response="abc"`),
+		"127.0.0.1:5060"); rply[requestHeader] != sipUnauthorized {
+		t.Errorf("Expected %q, received %q", sipUnauthorized, rply[requestHeader])
+	}
+}
+
+func TestSIPAgentHandleMessageProcessMessage(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	accFld := &config.FCTemplate{Tag: utils.AccountField, Type: utils.MetaVariable,
+		Path:  utils.MetaCgreq + utils.NestingSep + utils.AccountField,
+		Value: config.NewRSRParsersMustCompile("~*req.From{*sipuri_user}", utils.InfieldSep)}
+	accFld.ComputePath()
+	sa.cfg.SIPAgentCfg().RequestProcessors[2].Flags = utils.FlagsWithParamsFromSlice([]string{utils.MetaMessage, utils.MetaAccounts})
+	sa.cfg.SIPAgentCfg().RequestProcessors[2].RequestFields = []*config.FCTemplate{accFld}
+
+	var rcvArgs *sessions.V1ProcessMessageArgs
+	sS := &testMockSessionConn{calls: map[string]func(arg any, rply any) error{
+		utils.SessionSv1ProcessMessage: func(arg any, rply any) error {
+			rcvArgs = arg.(*sessions.V1ProcessMessageArgs)
+			if rcvArgs.CGREvent.Event[utils.AccountField] != "1001" {
+				return utils.ErrInsufficientCredit
+			}
+			*rply.(*sessions.V1ProcessMessageReply) = sessions.V1ProcessMessageReply{
+				MaxUsage: utils.DurationPointer(time.Second),
+			}
+			return nil
+		},
+	}}
+	internalSessionSChan := make(chan birpc.ClientConnector, 1)
+	internalSessionSChan <- sS
+	sa.connMgr = engine.NewConnManager(sa.cfg, map[string]chan birpc.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): internalSessionSChan,
+	})
+
+	msg := newTestSIPMessage(t, "MESSAGE sip:1002@cgrates.org SIP/2.0")
+	if rply := sa.handleMessage(msg, "127.0.0.1:5060"); rply[requestHeader] != sipOK {
+		t.Errorf("Expected %q, received %q", sipOK, rply[requestHeader])
+	}
+	if rcvArgs == nil || !rcvArgs.Debit {
+		t.Errorf("Expected the message to be debited, received: %s", utils.ToJSON(rcvArgs))
+	}
+	msg = newTestSIPMessage(t, "MESSAGE sip:1002@cgrates.org SIP/2.0")
+	msg[fromHeader] = "<sip:1003@cgrates.org>;tag=1928301774"
+	if rply := sa.handleMessage(msg, "127.0.0.1:5060"); rply[requestHeader] != sipForbidden {
+		t.Errorf("Expected %q, received %q", sipForbidden, rply[requestHeader])
+	}
+}
+
+const testSIPOptionsMsg = "OPTIONS sip:cgrates.org SIP/2.0\r\n" +
+	"Via: SIP/2.0/WSS df7jal23ls0d.invalid;branch=z9hG4bK56sdasks;rport\r\n" +
+	"Call-ID: a84b4c76e66710@pc33.cgrates.org\r\nCSeq: 1 OPTIONS\r\n" +
+	"From: <sip:1001@cgrates.org>;tag=1928301774\r\nTo: <sip:cgrates.org>\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+func testSIPAgentWSOptions(t *testing.T, ts *httptest.Server, tlsCfg *tls.Config) {
+	t.Helper()
+	dial := func(protocol ...string) (*websocket.Conn, net.Conn, error) {
+		wsCfg, err := websocket.NewConfig(strings.Replace(ts.URL, "http", "ws", 1), ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wsCfg.Protocol = protocol
+		conn, err := net.Dial(utils.TCP, ts.Listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tlsCfg != nil {
+			conn = tls.Client(conn, tlsCfg)
+		}
+		ws, err := websocket.NewClient(wsCfg, conn)
+		return ws, conn, err
+	}
+	if _, _, err := dial(); err == nil {
+		t.Fatal("Expected the handshake to fail without the sip subprotocol")
+	}
+	ws, conn, err := dial(utils.SIPWSSubProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err = websocket.Message.Send(ws, testSIPOptionsMsg); err != nil {
+		t.Fatal(err)
+	}
+	var rply string
+	if err = websocket.Message.Receive(ws, &rply); err != nil {
+		t.Fatal(err)
+	}
+	rplyMsg, err := sipingo.NewMessage(rply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rplyMsg[requestHeader] != sipOK {
+		t.Errorf("Expected %q, received %q", sipOK, rplyMsg[requestHeader])
+	}
+	host, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	if exp := "SIP/2.0/WSS df7jal23ls0d.invalid;branch=z9hG4bK56sdasks;rport=" + port +
+		";received=" + host; rplyMsg[viaHeader] != exp {
+		t.Errorf("Expected %q, received %q", exp, rplyMsg[viaHeader])
+	}
+}
+
+func TestSIPAgentHandleWSConn(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	stop := make(chan struct{})
+	defer close(stop)
+	ts := httptest.NewServer(websocket.Server{
+		Handshake: sipWSHandshake,
+		Handler:   sa.handleWSConn(stop),
+	})
+	defer ts.Close()
+	testSIPAgentWSOptions(t, ts, nil)
+}
+
+func TestSIPAgentHandleWSSConn(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	stop := make(chan struct{})
+	defer close(stop)
+	ts := httptest.NewTLSServer(websocket.Server{
+		Handshake: sipWSHandshake,
+		Handler:   sa.handleWSConn(stop),
+	})
+	defer ts.Close()
+	testSIPAgentWSOptions(t, ts, &tls.Config{InsecureSkipVerify: true})
+}
+
+func TestSIPAgentTLSConfig(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	crtPath, keyPath := testRadsecCerts(t)
+	sa.cfg.TLSCfg().ServerCerificate = crtPath
+	sa.cfg.TLSCfg().ServerKey = keyPath
+	sa.cfg.TLSCfg().CaCertificate = crtPath
+	sa.cfg.TLSCfg().ServerPolicy = int(tls.RequireAndVerifyClientCert)
+	sa.cfg.TLSCfg().ServerName = "sip.cgrates.org"
+	tlsCfg, err := sa.tlsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsCfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("expected %v, received %v", tls.RequireAndVerifyClientCert, tlsCfg.ClientAuth)
+	}
+	if tlsCfg.ServerName != "sip.cgrates.org" {
+		t.Errorf("expected %q, received %q", "sip.cgrates.org", tlsCfg.ServerName)
+	}
+	if tlsCfg.ClientCAs == nil {
+		t.Error("expected the CA to be loaded")
+	}
+	sa.cfg.TLSCfg().CaCertificate = keyPath
+	if _, err = sa.tlsConfig(); err == nil {
+		t.Error("expected error for invalid CA")
+	}
+}
+
+func TestSIPAgentServeTCPTLS(t *testing.T) {
+	sa := newTestSIPAgent(t)
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sa.cfg.SIPAgentCfg().Listen = l.Addr().String()
+	l.Close()
+	ts := httptest.NewUnstartedServer(nil) // used only for its test certificate
+	ts.StartTLS()
+	ts.Close()
+	stop := make(chan struct{})
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- sa.serveTCP(stop, &tls.Config{Certificates: ts.TLS.Certificates})
+	}()
+	var conn *tls.Conn
+	for i := 0; i < 20; i++ { // wait for the listener
+		if conn, err = tls.Dial(utils.TCP, sa.cfg.SIPAgentCfg().Listen,
+			&tls.Config{InsecureSkipVerify: true}); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte(strings.Replace(testSIPOptionsMsg, "WSS", "TLS", 1))); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, bufferSize)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rplyMsg, err := sipingo.NewMessage(string(buf[:n]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rplyMsg[requestHeader] != sipOK {
+		t.Errorf("Expected %q, received %q", sipOK, rplyMsg[requestHeader])
+	}
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	if exp := "SIP/2.0/TLS df7jal23ls0d.invalid;branch=z9hG4bK56sdasks;rport=" + port +
+		";received=127.0.0.1"; rplyMsg[viaHeader] != exp {
+		t.Errorf("Expected %q, received %q", exp, rplyMsg[viaHeader])
+	}
+	close(stop)
+	if err = <-errChan; err != nil {
+		t.Error(err)
+	}
+}
@@ -911,6 +911,12 @@ func (dS *DispatcherSv1) RemoteSleep(ctx *context.Context, args *utils.DurationA
 	return dS.dS.DispatcherSv1RemoteSleep(ctx, args, reply)
 }
 
+// GetHostsHealth returns the health check and circuit breaker state of the DispatcherHosts
+func (dS *DispatcherSv1) GetHostsHealth(ctx *context.Context, args *dispatchers.ArgsGetHostsHealth,
+	reply *map[string]*dispatchers.DispatcherHostHealth) (err error) {
+	return dS.dS.DispatcherSv1GetHostsHealth(ctx, args, reply)
+}
+
 /*
 func (dSv1 DispatcherSv1) Apier(ctx *context.Context,args *utils.MethodParameters, reply *any) (err error) {
 	return dSv1.dS.V1Apier(ctx,new(APIerSv1), args, reply)
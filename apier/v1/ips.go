@@ -115,6 +115,11 @@ func (a *APIerSv1) SetIPProfile(ctx *context.Context, arg *engine.IPProfileWithA
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = a.Config.GeneralCfg().DefaultTenant
 	}
+	for _, pool := range arg.Pools {
+		if err = engine.CheckIPPoolStrategy(pool.ID, pool.Strategy); err != nil {
+			return err
+		}
+	}
 	if err = a.DataManager.SetIPProfile(arg.IPProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
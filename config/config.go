@@ -184,21 +184,27 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.coreSCfg = new(CoreSCfg)
 	cfg.ipsCfg = &IPsCfg{Opts: &IPsOpts{}}
 	cfg.dfltEvExp = &EventExporterCfg{Opts: &EventExporterOpts{
-		Els:   new(ElsOpts),
-		SQL:   new(SQLOpts),
-		AMQP:  new(AMQPOpts),
-		AWS:   new(AWSOpts),
-		NATS:  new(NATSOpts),
-		RPC:   new(RPCOpts),
-		Kafka: new(KafkaOpts),
+		Els:          new(ElsOpts),
+		SQL:          new(SQLOpts),
+		AMQP:         new(AMQPOpts),
+		AWS:          new(AWSOpts),
+		NATS:         new(NATSOpts),
+		RPC:          new(RPCOpts),
+		Kafka:        new(KafkaOpts),
+		MQTT:         new(MQTTOpts),
+		RedisStreams: new(RedisStreamsOpts),
+		Parquet:      new(ParquetOpts),
 	}}
 	cfg.dfltEvRdr = &EventReaderCfg{Opts: &EventReaderOpts{
-		SQL:   new(SQLROpts),
-		CSV:   new(CSVROpts),
-		AWS:   new(AWSROpts),
-		AMQP:  new(AMQPROpts),
-		Kafka: new(KafkaROpts),
-		NATS:  new(NATSROpts),
+		SQL:          new(SQLROpts),
+		CSV:          new(CSVROpts),
+		AWS:          new(AWSROpts),
+		AMQP:         new(AMQPROpts),
+		Kafka:        new(KafkaROpts),
+		NATS:         new(NATSROpts),
+		MQTT:         new(MQTTROpts),
+		RedisStreams: new(RedisStreamsROpts),
+		HTTP:         new(HTTPROpts),
 	}}
 
 	cfg.cacheDP = make(map[string]utils.MapStorage)
@@ -350,12 +356,14 @@ var possibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
 	utils.MetaFileJSON, utils.MetaNone, utils.MetaAMQPjsonMap, utils.MetaS3jsonMap,
-	utils.MetaSQSjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaNatsjsonMap})
+	utils.MetaSQSjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaNatsjsonMap, utils.MetaMQTTjsonMap,
+	utils.MetaRedisStreams, utils.MetaHTTP, utils.MetaFileParquet})
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.MetaNone, utils.MetaFileFWV,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
 	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL, utils.MetaNatsjsonMap,
-	utils.MetaLog, utils.MetaRPC})
+	utils.MetaLog, utils.MetaRPC, utils.MetaMQTTjsonMap, utils.MetaRedisStreams,
+	utils.MetaFileParquet})
 
 // Loads from json configuration object, will be used for defaults, config from file and reload, might need lock
 func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
@@ -504,6 +504,33 @@ const CGRATES_CFG_JSON = `
 				// "natsClientCertificate": "",			// the path to a client certificate( used by tls)
 				// "natsClientKey": "",				// the path to a client key( used by tls)
 				// "natsJetStreamMaxWait": "5s",		// the maximum amount of time to wait for a response
+
+				// mqtt
+				// "mqttTopic": "cgrates/cdrs",		// the topic filter to subscribe to, + and # wildcards are supported
+				// "mqttQoS": 1,				// the subscription QoS <0|1|2>
+				// "mqttClientID": "",				// the client identifier, defaults to cgrates_$node_id_$reader_id
+				// "mqttCleanSession": true,			// false to keep a persistent session on the broker between reconnects
+				// "mqttUsername": "",				// username for the broker authentication
+				// "mqttPassword": "",				// password for the broker authentication
+				// "mqttTLS": false,				// if true it will use TLS towards the broker
+				// "mqttCAPath": "",				// path to certificate authority pem
+				// "mqttClientCertificate": "",		// path to a client certificate( used by tls)
+				// "mqttClientKey": "",				// path to a client key( used by tls)
+				// "mqttSkipTLSVerify": false,			// if true it will skip certificate verification
+
+				// redis streams, the connection reuses the data_db credentials and redis options
+				// "redisStreamsStream": "cgrates_cdrs",		// the stream key to read from
+				// "redisStreamsGroup": "cgrates",		// the consumer group, created if missing
+				// "redisStreamsConsumer": "",			// the consumer name within the group, defaults to cgrates_$node_id_$reader_id
+				// "redisStreamsDeadLetter": "",			// the stream where the failed entries are moved, defaults to $stream_dead_letter
+				// "redisStreamsCount": 10,			// maximum number of entries read at once
+
+				// http, the source_path is the URL path registered on the http listener
+				// "httpUsername": "",				// username required through basic auth, empty disables basic auth
+				// "httpPassword": "",				// password required through basic auth
+				// "httpHMACSecret": "",			// secret verifying the HMAC-SHA256 signature of the body, empty disables the verification
+				// "httpHMACHeader": "X-Signature",		// the header carrying the hex encoded signature
+				// "httpMaxBodySize": 1048576,		// maximum size of the request body in bytes, larger requests are rejected
 			},
 			"fields":[						// import fields template, tag will match internally CDR field, in case of .csv value will be represented by index of the field value
 				{"tag": "ToR", "path": "*cgreq.ToR", "type": "*variable", "value": "~*req.2", "mandatory": true},
@@ -529,10 +556,13 @@ const CGRATES_CFG_JSON = `
 	"attributes_conns":[],				// RPC Connections IDs
 	"cache": {
 		"*file_csv": {"limit": -1, "ttl": "5s", "static_ttl": false},
+		"*file_parquet": {"limit": -1, "ttl": "5s", "static_ttl": false},
 		"*nats_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
 		"*amqp_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
 		"*amqpv1_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
 		"*kafka_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
+		"*mqtt_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
+		"*redis_streams": {"limit": -1, "ttl": "", "static_ttl": false},
 		"*s3_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
 		"*sqs_json_map": {"limit": -1, "ttl": "", "static_ttl": false},
 		"*sql": {"limit": -1, "ttl": "", "static_ttl": false},
@@ -648,6 +678,32 @@ const CGRATES_CFG_JSON = `
 				// "natsClientKey": "",			// the path to a client key( used by tls)
 				// "natsJetStreamMaxWait": "5s",	// the maximum amount of time to wait for a response
 
+				// MQTT
+				// "mqttTopic": "cgrates/cdrs",		// the topic where the events are published, can be a template built from event fields, ie: "cgrates/cdrs/;~*req.Account"
+				// "mqttQoS": 1,			// the publish QoS <0|1|2>
+				// "mqttRetain": false,			// if true the broker will keep the last message on the topic for new subscribers
+				// "mqttClientID": "",			// the client identifier, defaults to cgrates_$node_id_$exporter_id
+				// "mqttUsername": "",			// username for the broker authentication
+				// "mqttPassword": "",			// password for the broker authentication
+				// "mqttTLS": false,			// if true it will use TLS towards the broker
+				// "mqttCAPath": "",			// path to certificate authority pem
+				// "mqttClientCertificate": "",		// path to a client certificate( used by tls)
+				// "mqttClientKey": "",			// path to a client key( used by tls)
+				// "mqttSkipTLSVerify": false,		// if true it will skip certificate verification
+
+				// Redis Streams, the connection reuses the data_db credentials and redis options
+				// "redisStreamsStream": "cgrates_cdrs",	// the stream key where the events are added
+				// "redisStreamsMaxLen": 0,		// approximate maximum length of the stream, 0 disables trimming
+
+				// Parquet
+				// "parquetColumnTypes": {},		// column types by field path <*string|*int64|*float64|*bool|*timestamp|*duration|*decimal>, unlisted columns are *string
+				// "parquetDecimalScale": 6,		// number of fractional digits kept by the *decimal columns
+				// "parquetCompression": "*snappy",	// column compression codec <*snappy|*gzip|*zstd|*none>
+				// "parquetMaxRecords": 0,		// rotate the file after this number of records, 0 disables it
+				// "parquetMaxFileSize": 0,		// rotate the file after approximately this number of bytes, 0 disables it
+				// "parquetRotateInterval": "0s",	// rotate the file after it was opened for this long, 0 disables it
+				// "parquetS3Endpoint": "",		// upload the finished files to this S3 endpoint using the aws and s3 options
+
 				//RPC
 				// "rpcCodec": "",  		// for compression, encoding and decoding <internalRPC | BIRPC | JSON/HTTP/GOB>
 				// "serviceMethod": "", 	// the method that should be called trough RPC
@@ -715,6 +771,7 @@ const CGRATES_CFG_JSON = `
 	"sessions_conns": ["*birpc_internal"],
 	"create_cdr": false,			// create CDR out of events and sends it to CDRS component
 	"route_profile": false,			// attaches RouteProfileID to RouteIDs which are sent as reply to asterisk agent authorization requests
+	"low_balance_ann_file": "",		// media played when low balance is reached for prepaid calls, eg: sound:low-balance
 	"asterisk_conns":[			// instantiate connections to multiple Asterisk servers
 		{
 			"address": "127.0.0.1:8088",
@@ -761,6 +818,7 @@ const CGRATES_CFG_JSON = `
 	"create_cdr": false,				// create CDR out of events and sends them to CDRS component
 	"timezone": "",					// timezone of the Kamailio server
 	"route_profile": false,				// attaches RouteProfileID to RouteIDs which are sent as reply to kamailio agent authorization requests
+	"low_balance_ann_file": "",			// file to be played when low balance is reached for prepaid calls
 	"evapi_conns":[					// instantiate connections to multiple Kamailio servers
 		{
 			"address": "127.0.0.1:8448",
@@ -795,6 +853,23 @@ const CGRATES_CFG_JSON = `
 	"conn_status_stat_queue_ids": [],				// StatQueue IDs for connection status events
 	"conn_status_threshold_ids": [],				// Threshold IDs for connection status events
 	"conn_health_check_interval": "0",				// peer connection health check interval (0 to disable)
+	"ocs_client": {							// *diameter rpc_conns charging the sessions through an upstream OCS (Gy/Ro)
+		"ccr_template": "*ccr",					// template used to build the CCR out of the SessionS event
+		"cca_template": "*ccaReply",				// template mapping the CCA into the SessionS reply
+		"watchdog_interval": "30s",				// Tw, interval between the DWRs sent to the OCS peers
+		"tx_timer": "10s",					// Tx, time waiting for the CCA before failing over to the next peer
+		"tcc_timer": "0s"					// Tcc, terminates the sessions not updated within this interval, 0 to disable
+	},
+	"relay_watchdog_interval": "30s",				// Tw towards the peers of the realm_routes, also the interval between reconnects
+	"realm_routes": [						// relay the requests matched by the *relay request processors
+		// {
+		//	"realm": "partner.org",				// Destination-Realm of the relayed requests, *any for the default route
+		//	"application_ids": [4],				// Application-Ids matched by the route, empty for all
+		//	"peers": [					// upstream peers connected by the agent, the first one connected is used
+		//		{"address": "10.0.0.1:3868", "network": "tcp"}
+		//	]
+		// }
+	],
 	"request_processors": []					// list of processors to be applied to diameter messages
 },
 
@@ -803,7 +878,7 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,					// enables the radius agent: <true|false>
 	"listeners":[
 		{
-			"network": "udp",			// network to listen on <udp|tcp>
+			"network": "udp",			// network to listen on <udp|tcp|tcp-tls>, tcp-tls for RadSec using the tls section certificates
 			"auth_address": "127.0.0.1:1812",	// address where to listen for radius authentication requests <x.y.z.y:1234>
 			"acct_address": "127.0.0.1:1813"	// address where to listen for radius accounting requests <x.y.z.y:1234>
 		}
@@ -830,6 +905,11 @@ const CGRATES_CFG_JSON = `
 	"thresholds_conns": [],					// connections to ThresholdS, empty to disable: <""|*internal|$rpc_conns_id>
 	"dmr_template": "*dmr",					// template used to build the Disconnect-Request packet
 	"coa_template": "*coa",					// template used to build the CoA-Request packet
+	"eap_server": {						// upstream server the EAP conversations are proxied to
+		"address": "",					// address of the EAP server, empty to disable EAP proxying <""|x.y.z.y:1812>
+		"transport": "udp",				// transport towards the EAP server <udp|tcp>
+		"secret": ""					// shared secret towards the EAP server
+	},
 	"request_processors": []				// request processors to be applied to Radius messages
 },
 
@@ -843,7 +923,7 @@ const CGRATES_CFG_JSON = `
 	"listeners":[
 		{
 			"address": "127.0.0.1:53",	// address where to listen for DNS requests <x.y.z.y:1234>
-			"network": "udp"		// network to listen on <udp|tcp|tcp-tls>
+			"network": "udp"		// network to listen on <udp|tcp|tcp-tls|doh>
 		}
 	],
 	"sessions_conns": ["*internal"],
@@ -1103,6 +1183,10 @@ const CGRATES_CFG_JSON = `
 	"attributes_conns": [],		// connections to AttributeS for API authorization, empty to disable auth functionality: <""|*internal|$rpc_conns_id>
 	"any_subsystem": true,		// if we match the *any subsystem
 	"prevent_loops": false,
+	"health_check_interval": "0s",		// interval between active health checks of each DispatcherHost, 0 to disable
+	"health_check_method": "CoreSv1.Ping",	// API called on the DispatcherHosts by the health checks
+	"circuit_breaker_failures": 0,		// consecutive failed requests after which a DispatcherHost is skipped, 0 to disable (one failed health check is enough)
+	"circuit_breaker_cooldown": "30s",	// time a failed DispatcherHost is skipped before allowing trial requests towards it
 },
 
 
@@ -1143,12 +1227,13 @@ const CGRATES_CFG_JSON = `
 "sip_agent": {					// SIP Agents, only used for redirections
 	"enabled": false,			// enables the SIP agent: <true|false>
 	"listen": "127.0.0.1:5060",		// address where to listen for SIP requests <x.y.z.y:1234>
-	"listen_net": "udp",			// network to listen on <udp|tcp|tcp-tls>
+	"listen_net": "udp",			// network to listen on <udp|tcp|tcp-tls|ws|wss>
 	"sessions_conns": ["*internal"],
 	"stats_conns": [],			// connections to StatS, empty to disable: <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],			// connections to ThresholdS, empty to disable: <""|*internal|$rpc_conns_id>
 	"timezone": "",				// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
 	"retransmission_timer": "1s",		// the duration to wait to receive an ACK before resending the reply
+	"method_processors": {},		// request processors IDs used per SIP method, all processors for methods not listed here <{"MESSAGE": ["$processor_id"]}>
 	"request_processors": []		// request processors to be applied to SIP messages
 },
 
@@ -1163,6 +1248,7 @@ const CGRATES_CFG_JSON = `
 		"admin_address": "localhost:7188",	// janus admin address used to retrive more information for sessions and handles
 		"admin_password": "",			// secret to pass restriction to communicate to the endpoint
 	}],
+	"debit_interval": "0s",				// interval between UpdateSession debits for the active sessions, 0s to disable
 	"request_processors": [],			// request processors to be applied to Janus messages
 },
 
@@ -1222,6 +1308,38 @@ const CGRATES_CFG_JSON = `
 		{"tag": "ReAuthRequestType", "path": "*diamreq.Re-Auth-Request-Type", "type": "*constant",
 			"value": "0"},
 	],
+	"*ccr": [ // used by the *diameter rpc_conns when sending CCRs towards the upstream OCS
+		{"tag": "SessionId", "path": "*diamreq.Session-Id", "type": "*variable",
+			"value": "~*vars.SessionId", "mandatory": true},
+		{"tag": "OriginHost", "path": "*diamreq.Origin-Host", "type": "*variable",
+			"value": "~*vars.OriginHost", "mandatory": true},
+		{"tag": "OriginRealm", "path": "*diamreq.Origin-Realm", "type": "*variable",
+			"value": "~*vars.OriginRealm", "mandatory": true},
+		{"tag": "DestinationRealm", "path": "*diamreq.Destination-Realm", "type": "*variable",
+			"value": "~*vars.DestinationRealm", "mandatory": true},
+		{"tag": "AuthApplicationId", "path": "*diamreq.Auth-Application-Id", "type": "*constant",
+			"value": "4", "mandatory": true},
+		{"tag": "ServiceContextId", "path": "*diamreq.Service-Context-Id", "type": "*constant",
+			"value": "32260@3gpp.org", "mandatory": true},
+		{"tag": "CCRequestType", "path": "*diamreq.CC-Request-Type", "type": "*variable",
+			"value": "~*vars.CCRequestType", "mandatory": true},
+		{"tag": "CCRequestNumber", "path": "*diamreq.CC-Request-Number", "type": "*variable",
+			"value": "~*vars.CCRequestNumber", "mandatory": true},
+		{"tag": "RequestedAction", "path": "*diamreq.Requested-Action", "type": "*variable",
+			"value": "~*vars.RequestedAction"},
+		{"tag": "SubscriptionIdType", "path": "*diamreq.Subscription-Id.Subscription-Id-Type", "type": "*constant",
+			"value": "0"},
+		{"tag": "SubscriptionIdData", "path": "*diamreq.Subscription-Id.Subscription-Id-Data", "type": "*variable",
+			"value": "~*req.Account"},
+		{"tag": "RequestedServiceUnit", "path": "*diamreq.Requested-Service-Unit.CC-Time", "type": "*variable",
+			"value": "~*vars.RequestedUsage{*duration_seconds}"},
+		{"tag": "UsedServiceUnit", "path": "*diamreq.Used-Service-Unit.CC-Time", "type": "*variable",
+			"value": "~*vars.UsedUsage{*duration_seconds}"},
+	],
+	"*ccaReply": [ // maps the CCA received by the *diameter rpc_conns into the SessionS reply
+		{"tag": "MaxUsage", "path": "*cgrep.MaxUsage", "type": "*variable",
+			"value": "~*req.Granted-Service-Unit.CC-Time:s/(.*)/${1}s/"},
+	],
 	"*dmr": [  // used by RadiusAgent when sending Disconnect message towards the client
 		{"tag": "User-Name", "path": "*radDAReq.User-Name", "type": "*variable", 
 			"value": "~*oreq.User-Name"},
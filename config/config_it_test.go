@@ -635,6 +635,9 @@ func testCGRConfigReloadERs(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -668,6 +671,9 @@ func testCGRConfigReloadERs(t *testing.T) {
 					AMQP:               &AMQPROpts{},
 					AWS:                &AWSROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					SQL:                &SQLROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
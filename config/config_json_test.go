@@ -1035,7 +1035,8 @@ func TestKamAgentJsonCfg(t *testing.T) {
 				Max_reconnect_interval: utils.StringPointer(utils.EmptyString),
 			},
 		},
-		Timezone: utils.StringPointer(utils.EmptyString),
+		Timezone:             utils.StringPointer(utils.EmptyString),
+		Low_balance_ann_file: utils.StringPointer(utils.EmptyString),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1051,10 +1052,11 @@ func TestKamAgentJsonCfg(t *testing.T) {
 
 func TestAsteriskAgentJsonCfg(t *testing.T) {
 	eCfg := &AsteriskAgentJsonCfg{
-		Enabled:        utils.BoolPointer(false),
-		Sessions_conns: &[]string{rpcclient.BiRPCInternal},
-		Create_cdr:     utils.BoolPointer(false),
-		Route_profile:  utils.BoolPointer(false),
+		Enabled:              utils.BoolPointer(false),
+		Sessions_conns:       &[]string{rpcclient.BiRPCInternal},
+		Create_cdr:           utils.BoolPointer(false),
+		Route_profile:        utils.BoolPointer(false),
+		Low_balance_ann_file: utils.StringPointer(utils.EmptyString),
 		Asterisk_conns: &[]*AstConnJsonCfg{
 			{
 				Address:                utils.StringPointer("127.0.0.1:8088"),
@@ -1101,7 +1103,16 @@ func TestDiameterAgentJsonCfg(t *testing.T) {
 		RARTemplate:             utils.StringPointer(""),
 		ForcedDisconnect:        utils.StringPointer(utils.MetaNone),
 		ConnHealthCheckInterval: utils.StringPointer("0"),
-		RequestProcessors:       &[]*ReqProcessorJsnCfg{},
+		OCSClient: &DiamOCSClientJsonCfg{
+			CCRTemplate:      utils.StringPointer("*ccr"),
+			CCATemplate:      utils.StringPointer("*ccaReply"),
+			WatchdogInterval: utils.StringPointer("30s"),
+			TxTimer:          utils.StringPointer("10s"),
+			TccTimer:         utils.StringPointer("0s"),
+		},
+		RelayWatchdogInterval: utils.StringPointer("30s"),
+		RealmRoutes:           &[]*DiamRealmRouteJsonCfg{},
+		RequestProcessors:     &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1136,6 +1147,11 @@ func TestRadiusAgentJsonCfg(t *testing.T) {
 		RequestProcessors: &[]*ReqProcessorJsnCfg{},
 		DMRTemplate:       utils.StringPointer("*dmr"),
 		CoATemplate:       utils.StringPointer("*coa"),
+		EAPServer: &EAPServerJsonCfg{
+			Address:   utils.StringPointer(""),
+			Transport: utils.StringPointer(utils.UDP),
+			Secret:    utils.StringPointer(""),
+		},
 		RequestsCacheKey:  utils.StringPointer(""),
 		ClientDaAddresses: map[string]DAClientOptsJson{},
 	}
@@ -1486,6 +1502,11 @@ func TestDfDispatcherSJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
 		Any_subsystem:         utils.BoolPointer(true),
+
+		Health_check_interval:    utils.StringPointer("0s"),
+		Health_check_method:      utils.StringPointer(utils.CoreSv1Ping),
+		Circuit_breaker_failures: utils.IntPointer(0),
+		Circuit_breaker_cooldown: utils.StringPointer("30s"),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1717,6 +1738,11 @@ func TestDfEventExporterCfg(t *testing.T) {
 				Ttl:        utils.StringPointer("5s"),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.MetaFileParquet: {
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer("5s"),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.MetaSQL: {
 				Limit:      utils.IntPointer(-1),
 				Ttl:        utils.StringPointer(""),
@@ -1752,6 +1778,16 @@ func TestDfEventExporterCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(""),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.MetaRedisStreams: {
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(""),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.MetaMQTTjsonMap: {
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(""),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.MetaKafkajsonMap: {
 				Limit:      utils.IntPointer(-1),
 				Ttl:        utils.StringPointer(""),
@@ -1949,6 +1985,102 @@ func TestDfTemplateSJsonCfg(t *testing.T) {
 				Value: utils.StringPointer("0"),
 			},
 		},
+		"*ccr": {
+			{
+				Tag:       utils.StringPointer("SessionId"),
+				Path:      utils.StringPointer("*diamreq.Session-Id"),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*vars.SessionId"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("OriginHost"),
+				Path:      utils.StringPointer("*diamreq.Origin-Host"),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*vars.OriginHost"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("OriginRealm"),
+				Path:      utils.StringPointer("*diamreq.Origin-Realm"),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*vars.OriginRealm"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("DestinationRealm"),
+				Path:      utils.StringPointer("*diamreq.Destination-Realm"),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*vars.DestinationRealm"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("AuthApplicationId"),
+				Path:      utils.StringPointer("*diamreq.Auth-Application-Id"),
+				Type:      utils.StringPointer(utils.MetaConstant),
+				Value:     utils.StringPointer("4"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("ServiceContextId"),
+				Path:      utils.StringPointer("*diamreq.Service-Context-Id"),
+				Type:      utils.StringPointer(utils.MetaConstant),
+				Value:     utils.StringPointer("32260@3gpp.org"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("CCRequestType"),
+				Path:      utils.StringPointer("*diamreq.CC-Request-Type"),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*vars.CCRequestType"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:       utils.StringPointer("CCRequestNumber"),
+				Path:      utils.StringPointer("*diamreq.CC-Request-Number"),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*vars.CCRequestNumber"),
+				Mandatory: utils.BoolPointer(true),
+			},
+			{
+				Tag:   utils.StringPointer("RequestedAction"),
+				Path:  utils.StringPointer("*diamreq.Requested-Action"),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*vars.RequestedAction"),
+			},
+			{
+				Tag:   utils.StringPointer("SubscriptionIdType"),
+				Path:  utils.StringPointer("*diamreq.Subscription-Id.Subscription-Id-Type"),
+				Type:  utils.StringPointer(utils.MetaConstant),
+				Value: utils.StringPointer("0"),
+			},
+			{
+				Tag:   utils.StringPointer("SubscriptionIdData"),
+				Path:  utils.StringPointer("*diamreq.Subscription-Id.Subscription-Id-Data"),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.Account"),
+			},
+			{
+				Tag:   utils.StringPointer("RequestedServiceUnit"),
+				Path:  utils.StringPointer("*diamreq.Requested-Service-Unit.CC-Time"),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*vars.RequestedUsage{*duration_seconds}"),
+			},
+			{
+				Tag:   utils.StringPointer("UsedServiceUnit"),
+				Path:  utils.StringPointer("*diamreq.Used-Service-Unit.CC-Time"),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*vars.UsedUsage{*duration_seconds}"),
+			},
+		},
+		"*ccaReply": {
+			{
+				Tag:   utils.StringPointer("MaxUsage"),
+				Path:  utils.StringPointer("*cgrep.MaxUsage"),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.Granted-Service-Unit.CC-Time:s/(.*)/${1}s/"),
+			},
+		},
 		utils.MetaDMR: {
 			{
 				Tag:   utils.StringPointer("User-Name"),
@@ -580,6 +580,9 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 
 	//SIP Agent
 	if cfg.sipAgentCfg.Enabled {
+		if !slices.Contains([]string{utils.UDP, utils.TCP, utils.TCPTLS, utils.WS, utils.WSS}, cfg.sipAgentCfg.ListenNet) {
+			return fmt.Errorf("<%s> unsupported listen_net %s", utils.SIPAgent, cfg.sipAgentCfg.ListenNet)
+		}
 		if len(cfg.sipAgentCfg.SessionSConns) == 0 {
 			return fmt.Errorf("<%s> no %s connections defined",
 				utils.SIPAgent, utils.SessionS)
@@ -645,6 +648,16 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> %s for %s at %s", utils.SIPAgent, err, req.Filters, utils.RequestProcessorsCfg)
 			}
 		}
+		for method, procIDs := range cfg.sipAgentCfg.MethodProcessors {
+			for _, procID := range procIDs {
+				if !slices.ContainsFunc(cfg.sipAgentCfg.RequestProcessors, func(rp *RequestProcessor) bool {
+					return rp.ID == procID
+				}) {
+					return fmt.Errorf("<%s> request processor with id: <%s> not defined for method <%s> at %s",
+						utils.SIPAgent, procID, method, utils.MethodProcessorsCfg)
+				}
+			}
+		}
 	}
 
 	if cfg.attributeSCfg.Enabled {
@@ -879,11 +892,26 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 					*rdr.Opts.CSV.FieldSeparator == utils.EmptyString {
 					return fmt.Errorf("<%s> empty %s for reader with ID: %s", utils.ERs, utils.CSVFieldSepOpt, rdr.ID)
 				}
-			case utils.MetaKafkajsonMap:
+			case utils.MetaKafkajsonMap, utils.MetaMQTTjsonMap, utils.MetaRedisStreams:
+				if rdr.RunDelay > 0 {
+					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
+				}
+			case utils.MetaHTTP:
 				if rdr.RunDelay > 0 {
 					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
 				}
-			case utils.MetaFileXML, utils.MetaFileFWV, utils.MetaFileJSON:
+				if !strings.HasPrefix(rdr.SourcePath, utils.Slash) {
+					return fmt.Errorf("<%s> the source_path must be an URL path starting with %q for reader with ID: %s", utils.ERs, utils.Slash, rdr.ID)
+				}
+				if rdr.Opts.HTTP != nil && rdr.Opts.HTTP.MaxBodySize != nil &&
+					*rdr.Opts.HTTP.MaxBodySize <= 0 {
+					return fmt.Errorf("<%s> %s must be bigger than zero for reader with ID: %s", utils.ERs, utils.HTTPMaxBodySize, rdr.ID)
+				}
+				if rdr.Opts.CSV.FieldSeparator != nil &&
+					*rdr.Opts.CSV.FieldSeparator == utils.EmptyString {
+					return fmt.Errorf("<%s> empty %s for reader with ID: %s", utils.ERs, utils.CSVFieldSepOpt, rdr.ID)
+				}
+			case utils.MetaFileXML, utils.MetaFileFWV, utils.MetaFileJSON, utils.MetaFileParquet:
 				for _, dir := range []string{rdr.ProcessedPath, rdr.SourcePath} {
 					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
 						return fmt.Errorf("<%s> nonexistent folder: %s for reader with ID: %s", utils.ERs, dir, rdr.ID)
@@ -996,7 +1024,7 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		// Check cache TTL for file exporters which require positive TTL as
 		// content is flushed only upon cache expiration.
 		for eeType, cacheCfg := range cfg.eesCfg.Cache {
-			if slices.Contains([]string{utils.MetaFileCSV, utils.MetaFileFWV}, eeType) {
+			if slices.Contains([]string{utils.MetaFileCSV, utils.MetaFileFWV, utils.MetaFileParquet}, eeType) {
 				if cacheCfg.TTL <= 0 {
 					return fmt.Errorf("<%s> exporter type %q requires positive cache TTL, got %v",
 						utils.EEs, eeType, cacheCfg.TTL)
@@ -1035,6 +1063,33 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 						return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, dir, exp.ID)
 					}
 				}
+			case utils.MetaFileParquet:
+				for _, dir := range []string{exp.ExportPath} {
+					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
+						return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, dir, exp.ID)
+					}
+				}
+				if len(exp.ContentFields()) == 0 {
+					return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
+				}
+				parquetOpts := exp.Opts.Parquet
+				for column, colType := range parquetOpts.ColumnTypes {
+					if !slices.Contains([]string{utils.MetaString, utils.MetaInt64, utils.MetaFloat64, utils.MetaBool,
+						utils.MetaTimestamp, utils.MetaDuration, utils.MetaDecimal}, colType) {
+						return fmt.Errorf("<%s> unsupported %s value: %q for column %q for exporter with ID: %s",
+							utils.EEs, utils.ParquetColumnTypes, colType, column, exp.ID)
+					}
+				}
+				if parquetOpts.Compression != nil &&
+					!slices.Contains([]string{utils.MetaSnappy, utils.MetaGzip, utils.MetaZstd, utils.MetaNone}, *parquetOpts.Compression) {
+					return fmt.Errorf("<%s> unsupported %s value: %q for exporter with ID: %s",
+						utils.EEs, utils.ParquetCompression, *parquetOpts.Compression, exp.ID)
+				}
+				if parquetOpts.DecimalScale != nil &&
+					(*parquetOpts.DecimalScale < 0 || *parquetOpts.DecimalScale > 18) {
+					return fmt.Errorf("<%s> %s must be between 0 and 18 for exporter with ID: %s",
+						utils.EEs, utils.ParquetDecimalScale, exp.ID)
+				}
 			case utils.MetaElastic:
 				elsOpts := exp.Opts.Els
 				if elsOpts.Logger != nil {
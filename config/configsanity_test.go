@@ -843,7 +843,8 @@ func TestConfigSanitySipAgent(t *testing.T) {
 	cfg := NewDefaultCGRConfig()
 
 	cfg.sipAgentCfg = &SIPAgentCfg{
-		Enabled: true,
+		Enabled:   true,
+		ListenNet: "sctp",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -860,7 +861,13 @@ func TestConfigSanitySipAgent(t *testing.T) {
 		},
 	}
 
-	expected := "<SIPAgent> no SessionS connections defined"
+	expected := "<SIPAgent> unsupported listen_net sctp"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
+	cfg.sipAgentCfg.ListenNet = utils.WSS
+	expected = "<SIPAgent> no SessionS connections defined"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
@@ -957,6 +964,13 @@ func TestConfigSanitySipAgent(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.sipAgentCfg.RequestProcessors[0].Filters = nil
+
+	cfg.sipAgentCfg.MethodProcessors = map[string][]string{"MESSAGE": {"cgrates", "SMS"}}
+	expected = "<SIPAgent> request processor with id: <SMS> not defined for method <MESSAGE> at method_processors"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityAttributesCfg(t *testing.T) {
@@ -1196,6 +1210,26 @@ func TestConfigSanityEventReader(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.ersCfg.Readers[0] = &EventReaderCfg{
+		ID:         "test4",
+		Type:       utils.MetaHTTP,
+		RunDelay:   -1,
+		SourcePath: "ers/cdrs",
+		Opts: &EventReaderOpts{
+			CSV:                &CSVROpts{},
+			PartialCacheAction: utils.StringPointer(utils.MetaNone),
+		},
+	}
+	expected = `<ERs> the source_path must be an URL path starting with "/" for reader with ID: test4`
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ersCfg.Readers[0].SourcePath = "/ers/cdrs"
+	cfg.ersCfg.Readers[0].Opts.HTTP = &HTTPROpts{MaxBodySize: utils.Int64Pointer(0)}
+	expected = `<ERs> httpMaxBodySize must be bigger than zero for reader with ID: test4`
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 	cfg.ersCfg.Readers[0] = &EventReaderCfg{
 		ID:            "test5",
 		Type:          utils.MetaFileXML,
@@ -1390,6 +1424,40 @@ func TestConfigSanityEventExporter(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.eesCfg.Exporters[0].Type = utils.MetaFileParquet
+	expected = "<EEs> nonexistent folder: randomPath for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].ExportPath = "/"
+	cfg.eesCfg.Exporters[0].Opts.Parquet = &ParquetOpts{
+		ColumnTypes: map[string]string{utils.Cost: "*money"},
+	}
+	expected = "<EEs> empty content fields for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].contentFields = cfg.eesCfg.Exporters[0].Fields
+	expected = "<EEs> unsupported parquetColumnTypes value: \"*money\" for column \"Cost\" for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.Parquet = &ParquetOpts{
+		Compression: utils.StringPointer("*lzo"),
+	}
+	expected = "<EEs> unsupported parquetCompression value: \"*lzo\" for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].Opts.Parquet = &ParquetOpts{
+		DecimalScale: utils.IntPointer(19),
+	}
+	expected = "<EEs> parquetDecimalScale must be between 0 and 18 for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].ExportPath = "randomPath"
+
 	cfg.eesCfg.Exporters[0].Type = utils.MetaHTTPPost
 	cfg.eesCfg.Exporters[0].Fields[0].Path = "~Field1..Field2[0]"
 	expected = "<EEs> Empty field path  for ~Field1..Field2[0] at Path"
@@ -51,9 +51,116 @@ type DiameterAgentCfg struct {
 	ConnStatusStatQueueIDs  []string
 	ConnStatusThresholdIDs  []string
 	ConnHealthCheckInterval time.Duration // peer connection health check interval (0 to disable)
+	OCSClient               DiamOCSClientCfg
+	RelayWatchdogInterval   time.Duration // Tw towards the peers of the realm routes
+	RealmRoutes             []*DiamRealmRoute
 	RequestProcessors       []*RequestProcessor
 }
 
+// DiamRealmRoute relays the requests matched by the *relay request processors
+// towards the first connected peer of the route
+type DiamRealmRoute struct {
+	Realm          string             // Destination-Realm, *any for the default route
+	ApplicationIDs []uint32           // empty to match all the applications
+	Peers          []DiameterListener // upstream peers, in order of preference
+}
+
+func (rr *DiamRealmRoute) loadFromJSONCfg(jc *DiamRealmRouteJsonCfg) {
+	if jc == nil {
+		return
+	}
+	if jc.Realm != nil {
+		rr.Realm = *jc.Realm
+	}
+	if jc.ApplicationIDs != nil {
+		rr.ApplicationIDs = slices.Clone(*jc.ApplicationIDs)
+	}
+	if jc.Peers != nil {
+		rr.Peers = make([]DiameterListener, 0, len(*jc.Peers))
+		for _, peer := range *jc.Peers {
+			var p DiameterListener
+			if peer.Address != nil {
+				p.Address = *peer.Address
+			}
+			if peer.Network != nil {
+				p.Network = *peer.Network
+			}
+			rr.Peers = append(rr.Peers, p)
+		}
+	}
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (rr *DiamRealmRoute) AsMapInterface() map[string]any {
+	peers := make([]map[string]any, len(rr.Peers))
+	for i, peer := range rr.Peers {
+		peers[i] = peer.AsMapInterface()
+	}
+	appIDs := make([]uint32, len(rr.ApplicationIDs))
+	copy(appIDs, rr.ApplicationIDs)
+	return map[string]any{
+		utils.RealmCfg:          rr.Realm,
+		utils.ApplicationIDsCfg: appIDs,
+		utils.PeersCfg:          peers,
+	}
+}
+
+// Clone returns a deep copy of DiamRealmRoute
+func (rr *DiamRealmRoute) Clone() *DiamRealmRoute {
+	return &DiamRealmRoute{
+		Realm:          rr.Realm,
+		ApplicationIDs: slices.Clone(rr.ApplicationIDs),
+		Peers:          slices.Clone(rr.Peers),
+	}
+}
+
+// DiamOCSClientCfg configures the *diameter connections, charging the sessions
+// through an upstream OCS over Gy/Ro (RFC 4006)
+type DiamOCSClientCfg struct {
+	CCRTemplate      string        // template building the CCR out of the SessionS event
+	CCATemplate      string        // template mapping the CCA into the SessionS reply
+	WatchdogInterval time.Duration // Tw, interval between the DWRs
+	TxTimer          time.Duration // Tx, time waiting for the CCA before failing over to the next peer
+	TccTimer         time.Duration // Tcc, terminates the sessions not updated in time, 0 to disable
+}
+
+func (oc *DiamOCSClientCfg) loadFromJSONCfg(jc *DiamOCSClientJsonCfg) (err error) {
+	if jc == nil {
+		return
+	}
+	if jc.CCRTemplate != nil {
+		oc.CCRTemplate = *jc.CCRTemplate
+	}
+	if jc.CCATemplate != nil {
+		oc.CCATemplate = *jc.CCATemplate
+	}
+	if jc.WatchdogInterval != nil {
+		if oc.WatchdogInterval, err = utils.ParseDurationWithNanosecs(*jc.WatchdogInterval); err != nil {
+			return
+		}
+	}
+	if jc.TxTimer != nil {
+		if oc.TxTimer, err = utils.ParseDurationWithNanosecs(*jc.TxTimer); err != nil {
+			return
+		}
+	}
+	if jc.TccTimer != nil {
+		oc.TccTimer, err = utils.ParseDurationWithNanosecs(*jc.TccTimer)
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]any
+func (oc *DiamOCSClientCfg) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.CCRTemplateCfg:      oc.CCRTemplate,
+		utils.CCATemplateCfg:      oc.CCATemplate,
+		utils.WatchdogIntervalCfg: oc.WatchdogInterval.String(),
+		utils.TxTimerCfg:          oc.TxTimer.String(),
+		utils.TccTimerCfg:         oc.TccTimer.String(),
+	}
+}
+
 func (da *DiameterAgentCfg) loadFromJSONCfg(jc *DiameterAgentJsonCfg, separator string) (err error) {
 	if jc == nil {
 		return nil
@@ -134,6 +241,23 @@ func (da *DiameterAgentCfg) loadFromJSONCfg(jc *DiameterAgentJsonCfg, separator
 			return
 		}
 	}
+	if err = da.OCSClient.loadFromJSONCfg(jc.OCSClient); err != nil {
+		return
+	}
+	if jc.RelayWatchdogInterval != nil {
+		da.RelayWatchdogInterval, err = utils.ParseDurationWithNanosecs(*jc.RelayWatchdogInterval)
+		if err != nil {
+			return
+		}
+	}
+	if jc.RealmRoutes != nil {
+		da.RealmRoutes = make([]*DiamRealmRoute, 0, len(*jc.RealmRoutes))
+		for _, rrJsn := range *jc.RealmRoutes {
+			rr := new(DiamRealmRoute)
+			rr.loadFromJSONCfg(rrJsn)
+			da.RealmRoutes = append(da.RealmRoutes, rr)
+		}
+	}
 	if jc.RequestProcessors != nil {
 		for _, reqProcJsn := range *jc.RequestProcessors {
 			rp := new(RequestProcessor)
@@ -184,6 +308,8 @@ func (da *DiameterAgentCfg) AsMapInterface(separator string) map[string]any {
 		utils.RARTemplateCfg:             da.RARTemplate,
 		utils.ForcedDisconnectCfg:        da.ForcedDisconnect,
 		utils.ConnHealthCheckIntervalCfg: da.ConnHealthCheckInterval.String(),
+		utils.OCSClientCfg:               da.OCSClient.AsMapInterface(),
+		utils.RelayWatchdogIntervalCfg:   da.RelayWatchdogInterval.String(),
 		utils.StatSConnsCfg:              stripInternalConns(da.StatSConns),
 		utils.ThresholdSConnsCfg:         stripInternalConns(da.ThresholdSConns),
 		utils.ConnStatusStatQueueIDsCfg:  da.ConnStatusStatQueueIDs,
@@ -196,6 +322,12 @@ func (da *DiameterAgentCfg) AsMapInterface(separator string) map[string]any {
 		m[utils.CeApplicationsCfg] = apps
 	}
 
+	realmRoutes := make([]map[string]any, len(da.RealmRoutes))
+	for i, rr := range da.RealmRoutes {
+		realmRoutes[i] = rr.AsMapInterface()
+	}
+	m[utils.RealmRoutesCfg] = realmRoutes
+
 	requestProcessors := make([]map[string]any, len(da.RequestProcessors))
 	for i, item := range da.RequestProcessors {
 		requestProcessors[i] = item.AsMapInterface(separator)
@@ -238,6 +370,14 @@ func (da DiameterAgentCfg) Clone() *DiameterAgentCfg {
 		ConnStatusStatQueueIDs:  slices.Clone(da.ConnStatusStatQueueIDs),
 		ConnStatusThresholdIDs:  slices.Clone(da.ConnStatusThresholdIDs),
 		ConnHealthCheckInterval: da.ConnHealthCheckInterval,
+		OCSClient:               da.OCSClient,
+		RelayWatchdogInterval:   da.RelayWatchdogInterval,
+	}
+	if da.RealmRoutes != nil {
+		clone.RealmRoutes = make([]*DiamRealmRoute, len(da.RealmRoutes))
+		for i, rr := range da.RealmRoutes {
+			clone.RealmRoutes[i] = rr.Clone()
+		}
 	}
 	if da.RequestProcessors != nil {
 		clone.RequestProcessors = make([]*RequestProcessor, len(da.RequestProcessors))
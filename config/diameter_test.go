@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -77,6 +78,14 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		ASRTemplate:            "randomTemplate",
 		RARTemplate:            "randomTemplate",
 		ForcedDisconnect:       "forced",
+		OCSClient: DiamOCSClientCfg{
+			CCRTemplate:      "*ccr",
+			CCATemplate:      "*ccaReply",
+			WatchdogInterval: 30 * time.Second,
+			TxTimer:          10 * time.Second,
+		},
+		RelayWatchdogInterval: 30 * time.Second,
+		RealmRoutes:           []*DiamRealmRoute{},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -188,6 +197,15 @@ func TestDiameterAgentCfgAsMapInterface(t *testing.T) {
 		utils.SyncedConnReqsCfg:          true,
 		utils.VendorIDCfg:                0,
 		utils.ConnHealthCheckIntervalCfg: "0s",
+		utils.OCSClientCfg: map[string]any{
+			utils.CCRTemplateCfg:      "*ccr",
+			utils.CCATemplateCfg:      "*ccaReply",
+			utils.WatchdogIntervalCfg: "30s",
+			utils.TxTimerCfg:          "10s",
+			utils.TccTimerCfg:         "0s",
+		},
+		utils.RelayWatchdogIntervalCfg: "30s",
+		utils.RealmRoutesCfg:           []map[string]any{},
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
 				utils.IDCfg:       utils.CGRateSLwr,
@@ -263,7 +281,16 @@ func TestDiameterAgentCfgAsMapInterface1(t *testing.T) {
 		utils.SyncedConnReqsCfg:          false,
 		utils.VendorIDCfg:                0,
 		utils.ConnHealthCheckIntervalCfg: "0s",
-		utils.RequestProcessorsCfg:       []map[string]any{},
+		utils.OCSClientCfg: map[string]any{
+			utils.CCRTemplateCfg:      "*ccr",
+			utils.CCATemplateCfg:      "*ccaReply",
+			utils.WatchdogIntervalCfg: "30s",
+			utils.TxTimerCfg:          "10s",
+			utils.TccTimerCfg:         "0s",
+		},
+		utils.RelayWatchdogIntervalCfg: "30s",
+		utils.RealmRoutesCfg:           []map[string]any{},
+		utils.RequestProcessorsCfg:     []map[string]any{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -290,6 +317,13 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 		ASRTemplate:      "randomTemplate",
 		RARTemplate:      "randomTemplate",
 		ForcedDisconnect: "forced",
+		RealmRoutes: []*DiamRealmRoute{
+			{
+				Realm:          "partner.org",
+				ApplicationIDs: []uint32{4},
+				Peers:          []DiameterListener{{Address: "10.0.0.1:3868", Network: "tcp"}},
+			},
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -307,4 +341,70 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "cgrates" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.RealmRoutes[0].Peers[0].Address = ""; ban.RealmRoutes[0].Peers[0].Address != "10.0.0.1:3868" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
+
+func TestDiameterAgentCfgRealmRoutes(t *testing.T) {
+	cfgJSONStr := `{
+	"diameter_agent": {
+		"relay_watchdog_interval": "10s",
+		"realm_routes": [
+			{
+				"realm": "partner.org",
+				"application_ids": [4, 16777238],
+				"peers": [
+					{"address": "10.0.0.1:3868", "network": "tcp"},
+					{"address": "10.0.0.2:3868", "network": "sctp"},
+				],
+			},
+			{"realm": "*any", "peers": [{"address": "10.0.0.3:3868"}]},
+		],
+	},
+}`
+	expRoutes := []*DiamRealmRoute{
+		{
+			Realm:          "partner.org",
+			ApplicationIDs: []uint32{4, 16777238},
+			Peers: []DiameterListener{
+				{Address: "10.0.0.1:3868", Network: "tcp"},
+				{Address: "10.0.0.2:3868", Network: "sctp"},
+			},
+		},
+		{
+			Realm: utils.MetaAny,
+			Peers: []DiameterListener{{Address: "10.0.0.3:3868"}},
+		},
+	}
+	expMap := []map[string]any{
+		{
+			utils.RealmCfg:          "partner.org",
+			utils.ApplicationIDsCfg: []uint32{4, 16777238},
+			utils.PeersCfg: []map[string]any{
+				{utils.AddressCfg: "10.0.0.1:3868", utils.NetworkCfg: "tcp"},
+				{utils.AddressCfg: "10.0.0.2:3868", utils.NetworkCfg: "sctp"},
+			},
+		},
+		{
+			utils.RealmCfg:          utils.MetaAny,
+			utils.ApplicationIDsCfg: []uint32{},
+			utils.PeersCfg: []map[string]any{
+				{utils.AddressCfg: "10.0.0.3:3868", utils.NetworkCfg: ""},
+			},
+		},
+	}
+	cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := cgrCfg.DiameterAgentCfg().RelayWatchdogInterval; rcv != 10*time.Second {
+		t.Errorf("Expected 10s, received %v", rcv)
+	}
+	if rcv := cgrCfg.DiameterAgentCfg().RealmRoutes; !reflect.DeepEqual(rcv, expRoutes) {
+		t.Errorf("Expected %s \n, received %s", utils.ToJSON(expRoutes), utils.ToJSON(rcv))
+	}
+	if rcv := cgrCfg.DiameterAgentCfg().AsMapInterface(utils.InfieldSep)[utils.RealmRoutesCfg]; !reflect.DeepEqual(rcv, expMap) {
+		t.Errorf("Expected %s \n, received %s", utils.ToJSON(expMap), utils.ToJSON(rcv))
+	}
 }
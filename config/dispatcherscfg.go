@@ -20,6 +20,7 @@ package config
 
 import (
 	"slices"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -36,6 +37,11 @@ type DispatcherSCfg struct {
 	NestedFields        bool
 	AnySubsystem        bool
 	PreventLoop         bool
+
+	HealthCheckInterval    time.Duration // interval between active health checks of each DispatcherHost
+	HealthCheckMethod      string        // API called by the health checks
+	CircuitBreakerFailures int           // consecutive failures after which a DispatcherHost is skipped
+	CircuitBreakerCooldown time.Duration // time a failed DispatcherHost is skipped before trial requests
 }
 
 func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err error) {
@@ -86,6 +92,22 @@ func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err erro
 	if jsnCfg.Prevent_loop != nil {
 		dps.PreventLoop = *jsnCfg.Prevent_loop
 	}
+	if jsnCfg.Health_check_interval != nil {
+		if dps.HealthCheckInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Health_check_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Health_check_method != nil {
+		dps.HealthCheckMethod = *jsnCfg.Health_check_method
+	}
+	if jsnCfg.Circuit_breaker_failures != nil {
+		dps.CircuitBreakerFailures = *jsnCfg.Circuit_breaker_failures
+	}
+	if jsnCfg.Circuit_breaker_cooldown != nil {
+		if dps.CircuitBreakerCooldown, err = utils.ParseDurationWithNanosecs(*jsnCfg.Circuit_breaker_cooldown); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
@@ -97,6 +119,11 @@ func (dps *DispatcherSCfg) AsMapInterface() (mp map[string]any) {
 		utils.NestedFieldsCfg:   dps.NestedFields,
 		utils.AnySubsystemCfg:   dps.AnySubsystem,
 		utils.PreventLoopCfg:    dps.PreventLoop,
+
+		utils.HealthCheckIntervalCfg:    dps.HealthCheckInterval.String(),
+		utils.HealthCheckMethodCfg:      dps.HealthCheckMethod,
+		utils.CircuitBreakerFailuresCfg: dps.CircuitBreakerFailures,
+		utils.CircuitBreakerCooldownCfg: dps.CircuitBreakerCooldown.String(),
 	}
 	if dps.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*dps.StringIndexedFields))
@@ -138,6 +165,11 @@ func (dps DispatcherSCfg) Clone() (cln *DispatcherSCfg) {
 		NestedFields:   dps.NestedFields,
 		AnySubsystem:   dps.AnySubsystem,
 		PreventLoop:    dps.PreventLoop,
+
+		HealthCheckInterval:    dps.HealthCheckInterval,
+		HealthCheckMethod:      dps.HealthCheckMethod,
+		CircuitBreakerFailures: dps.CircuitBreakerFailures,
+		CircuitBreakerCooldown: dps.CircuitBreakerCooldown,
 	}
 
 	if dps.AttributeSConns != nil {
@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -36,6 +37,11 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{utils.MetaInternal, "*conn1"},
 		Nested_fields:         utils.BoolPointer(true),
 		Any_subsystem:         utils.BoolPointer(true),
+
+		Health_check_interval:    utils.StringPointer("10s"),
+		Health_check_method:      utils.StringPointer(utils.CoreSv1Ping),
+		Circuit_breaker_failures: utils.IntPointer(3),
+		Circuit_breaker_cooldown: utils.StringPointer("1m"),
 	}
 	expected := &DispatcherSCfg{
 		Enabled:             true,
@@ -47,6 +53,11 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
 		AnySubsystem:        true,
+
+		HealthCheckInterval:    10 * time.Second,
+		HealthCheckMethod:      utils.CoreSv1Ping,
+		CircuitBreakerFailures: 3,
+		CircuitBreakerCooldown: time.Minute,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err := jsnCfg.dispatcherSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -79,6 +90,11 @@ func TestDispatcherSCfgAsMapInterface(t *testing.T) {
 		utils.AttributeSConnsCfg:     []string{},
 		utils.AnySubsystemCfg:        true,
 		utils.PreventLoopCfg:         false,
+
+		utils.HealthCheckIntervalCfg:    "0s",
+		utils.HealthCheckMethodCfg:      utils.CoreSv1Ping,
+		utils.CircuitBreakerFailuresCfg: 0,
+		utils.CircuitBreakerCooldownCfg: "30s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -98,7 +114,9 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
             "exists_indexed_fields": ["*req.exists"],
 			"nested_fields": false,
 			"attributes_conns": ["*internal:*attributes", "*conn1"],
-			"prevent_loop": true
+			"prevent_loop": true,
+			"health_check_interval": "5s",
+			"circuit_breaker_failures": 2,
 		},
 		
 }`
@@ -113,6 +131,11 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
 		utils.AttributeSConnsCfg:     []string{"*internal", "*conn1"},
 		utils.AnySubsystemCfg:        true,
 		utils.PreventLoopCfg:         true,
+
+		utils.HealthCheckIntervalCfg:    "5s",
+		utils.HealthCheckMethodCfg:      utils.CoreSv1Ping,
+		utils.CircuitBreakerFailuresCfg: 2,
+		utils.CircuitBreakerCooldownCfg: "30s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -135,6 +158,11 @@ func TestDispatcherSCfgAsMapInterface2(t *testing.T) {
 		utils.AttributeSConnsCfg:     []string{},
 		utils.AnySubsystemCfg:        true,
 		utils.PreventLoopCfg:         false,
+
+		utils.HealthCheckIntervalCfg:    "0s",
+		utils.HealthCheckMethodCfg:      utils.CoreSv1Ping,
+		utils.CircuitBreakerFailuresCfg: 0,
+		utils.CircuitBreakerCooldownCfg: "30s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
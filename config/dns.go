@@ -26,7 +26,7 @@ import (
 
 type DnsListener struct {
 	Address string
-	Network string // udp or tcp
+	Network string // udp, tcp, tcp-tls or doh
 }
 
 // DNSAgentCfg the config section that describes the DNS Agent
@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package config
 
 import (
+	"maps"
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
@@ -266,6 +267,35 @@ type KafkaOpts struct {
 	SkipTLSVerify *bool
 }
 
+type MQTTOpts struct {
+	Topic             *string
+	QoS               *int
+	Retain            *bool
+	ClientID          *string
+	Username          *string
+	Password          *string
+	TLS               *bool
+	CAPath            *string
+	ClientCertificate *string
+	ClientKey         *string
+	SkipTLSVerify     *bool
+}
+
+type RedisStreamsOpts struct {
+	Stream *string
+	MaxLen *int
+}
+
+type ParquetOpts struct {
+	ColumnTypes    map[string]string
+	DecimalScale   *int
+	Compression    *string
+	MaxRecords     *int
+	MaxFileSize    *int
+	RotateInterval *time.Duration
+	S3Endpoint     *string
+}
+
 type EventExporterOpts struct {
 	CSVFieldSeparator *string
 	Els               *ElsOpts
@@ -275,6 +305,9 @@ type EventExporterOpts struct {
 	NATS              *NATSOpts
 	RPC               *RPCOpts
 	Kafka             *KafkaOpts
+	MQTT              *MQTTOpts
+	RedisStreams      *RedisStreamsOpts
+	Parquet           *ParquetOpts
 }
 
 // EventExporterCfg the config for a Event Exporter
@@ -539,6 +572,80 @@ func (natsOpts *NATSOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err er
 	}
 	return
 }
+func (mqttOpts *MQTTOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
+	if jsnCfg.MQTTTopic != nil {
+		mqttOpts.Topic = jsnCfg.MQTTTopic
+	}
+	if jsnCfg.MQTTQoS != nil {
+		mqttOpts.QoS = jsnCfg.MQTTQoS
+	}
+	if jsnCfg.MQTTRetain != nil {
+		mqttOpts.Retain = jsnCfg.MQTTRetain
+	}
+	if jsnCfg.MQTTClientID != nil {
+		mqttOpts.ClientID = jsnCfg.MQTTClientID
+	}
+	if jsnCfg.MQTTUsername != nil {
+		mqttOpts.Username = jsnCfg.MQTTUsername
+	}
+	if jsnCfg.MQTTPassword != nil {
+		mqttOpts.Password = jsnCfg.MQTTPassword
+	}
+	if jsnCfg.MQTTTLS != nil {
+		mqttOpts.TLS = jsnCfg.MQTTTLS
+	}
+	if jsnCfg.MQTTCAPath != nil {
+		mqttOpts.CAPath = jsnCfg.MQTTCAPath
+	}
+	if jsnCfg.MQTTClientCertificate != nil {
+		mqttOpts.ClientCertificate = jsnCfg.MQTTClientCertificate
+	}
+	if jsnCfg.MQTTClientKey != nil {
+		mqttOpts.ClientKey = jsnCfg.MQTTClientKey
+	}
+	if jsnCfg.MQTTSkipTLSVerify != nil {
+		mqttOpts.SkipTLSVerify = jsnCfg.MQTTSkipTLSVerify
+	}
+	return
+}
+func (redisOpts *RedisStreamsOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
+	if jsnCfg.RedisStreamsStream != nil {
+		redisOpts.Stream = jsnCfg.RedisStreamsStream
+	}
+	if jsnCfg.RedisStreamsMaxLen != nil {
+		redisOpts.MaxLen = jsnCfg.RedisStreamsMaxLen
+	}
+	return
+}
+func (parquetOpts *ParquetOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
+	if jsnCfg.ParquetColumnTypes != nil {
+		parquetOpts.ColumnTypes = make(map[string]string)
+		maps.Copy(parquetOpts.ColumnTypes, jsnCfg.ParquetColumnTypes)
+	}
+	if jsnCfg.ParquetDecimalScale != nil {
+		parquetOpts.DecimalScale = jsnCfg.ParquetDecimalScale
+	}
+	if jsnCfg.ParquetCompression != nil {
+		parquetOpts.Compression = jsnCfg.ParquetCompression
+	}
+	if jsnCfg.ParquetMaxRecords != nil {
+		parquetOpts.MaxRecords = jsnCfg.ParquetMaxRecords
+	}
+	if jsnCfg.ParquetMaxFileSize != nil {
+		parquetOpts.MaxFileSize = jsnCfg.ParquetMaxFileSize
+	}
+	if jsnCfg.ParquetRotateInterval != nil {
+		var rotateInterval time.Duration
+		if rotateInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.ParquetRotateInterval); err != nil {
+			return
+		}
+		parquetOpts.RotateInterval = utils.DurationPointer(rotateInterval)
+	}
+	if jsnCfg.ParquetS3Endpoint != nil {
+		parquetOpts.S3Endpoint = jsnCfg.ParquetS3Endpoint
+	}
+	return
+}
 func (rpcOpts *RPCOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson) (err error) {
 	if jsnCfg.RPCCodec != nil {
 		rpcOpts.RPCCodec = jsnCfg.RPCCodec
@@ -611,6 +718,15 @@ func (eeOpts *EventExporterOpts) loadFromJSONCfg(jsnCfg *EventExporterOptsJson)
 	if err = eeOpts.RPC.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
+	if err = eeOpts.MQTT.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = eeOpts.RedisStreams.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = eeOpts.Parquet.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
 
 	return
 }
@@ -987,6 +1103,100 @@ func (natsOpts *NATSOpts) Clone() *NATSOpts {
 	return cln
 }
 
+func (mqttOpts *MQTTOpts) Clone() *MQTTOpts {
+	cln := &MQTTOpts{}
+	if mqttOpts.Topic != nil {
+		cln.Topic = new(string)
+		*cln.Topic = *mqttOpts.Topic
+	}
+	if mqttOpts.QoS != nil {
+		cln.QoS = new(int)
+		*cln.QoS = *mqttOpts.QoS
+	}
+	if mqttOpts.Retain != nil {
+		cln.Retain = new(bool)
+		*cln.Retain = *mqttOpts.Retain
+	}
+	if mqttOpts.ClientID != nil {
+		cln.ClientID = new(string)
+		*cln.ClientID = *mqttOpts.ClientID
+	}
+	if mqttOpts.Username != nil {
+		cln.Username = new(string)
+		*cln.Username = *mqttOpts.Username
+	}
+	if mqttOpts.Password != nil {
+		cln.Password = new(string)
+		*cln.Password = *mqttOpts.Password
+	}
+	if mqttOpts.TLS != nil {
+		cln.TLS = new(bool)
+		*cln.TLS = *mqttOpts.TLS
+	}
+	if mqttOpts.CAPath != nil {
+		cln.CAPath = new(string)
+		*cln.CAPath = *mqttOpts.CAPath
+	}
+	if mqttOpts.ClientCertificate != nil {
+		cln.ClientCertificate = new(string)
+		*cln.ClientCertificate = *mqttOpts.ClientCertificate
+	}
+	if mqttOpts.ClientKey != nil {
+		cln.ClientKey = new(string)
+		*cln.ClientKey = *mqttOpts.ClientKey
+	}
+	if mqttOpts.SkipTLSVerify != nil {
+		cln.SkipTLSVerify = new(bool)
+		*cln.SkipTLSVerify = *mqttOpts.SkipTLSVerify
+	}
+	return cln
+}
+
+func (redisOpts *RedisStreamsOpts) Clone() *RedisStreamsOpts {
+	cln := &RedisStreamsOpts{}
+	if redisOpts.Stream != nil {
+		cln.Stream = new(string)
+		*cln.Stream = *redisOpts.Stream
+	}
+	if redisOpts.MaxLen != nil {
+		cln.MaxLen = new(int)
+		*cln.MaxLen = *redisOpts.MaxLen
+	}
+	return cln
+}
+
+func (parquetOpts *ParquetOpts) Clone() *ParquetOpts {
+	cln := &ParquetOpts{}
+	if parquetOpts.ColumnTypes != nil {
+		cln.ColumnTypes = maps.Clone(parquetOpts.ColumnTypes)
+	}
+	if parquetOpts.DecimalScale != nil {
+		cln.DecimalScale = new(int)
+		*cln.DecimalScale = *parquetOpts.DecimalScale
+	}
+	if parquetOpts.Compression != nil {
+		cln.Compression = new(string)
+		*cln.Compression = *parquetOpts.Compression
+	}
+	if parquetOpts.MaxRecords != nil {
+		cln.MaxRecords = new(int)
+		*cln.MaxRecords = *parquetOpts.MaxRecords
+	}
+	if parquetOpts.MaxFileSize != nil {
+		cln.MaxFileSize = new(int)
+		*cln.MaxFileSize = *parquetOpts.MaxFileSize
+	}
+	if parquetOpts.RotateInterval != nil {
+		cln.RotateInterval = new(time.Duration)
+		*cln.RotateInterval = *parquetOpts.RotateInterval
+	}
+	if parquetOpts.S3Endpoint != nil {
+		cln.S3Endpoint = new(string)
+		*cln.S3Endpoint = *parquetOpts.S3Endpoint
+	}
+	return cln
+}
+
 func (rpcOpts *RPCOpts) Clone() *RPCOpts {
 	cln := &RPCOpts{}
 	if rpcOpts.RPCCodec != nil {
@@ -1059,6 +1269,15 @@ func (eeOpts *EventExporterOpts) Clone() *EventExporterOpts {
 	if eeOpts.RPC != nil {
 		cln.RPC = eeOpts.RPC.Clone()
 	}
+	if eeOpts.MQTT != nil {
+		cln.MQTT = eeOpts.MQTT.Clone()
+	}
+	if eeOpts.RedisStreams != nil {
+		cln.RedisStreams = eeOpts.RedisStreams.Clone()
+	}
+	if eeOpts.Parquet != nil {
+		cln.Parquet = eeOpts.Parquet.Clone()
+	}
 	return cln
 }
 
@@ -1310,6 +1529,72 @@ func (eeC *EventExporterCfg) AsMapInterface(separator string) (initialMP map[str
 			opts[utils.NatsJetStreamMaxWait] = natOpts.JetStreamMaxWait.String()
 		}
 	}
+	if mqttOpts := eeC.Opts.MQTT; mqttOpts != nil {
+		if mqttOpts.Topic != nil {
+			opts[utils.MQTTTopic] = *mqttOpts.Topic
+		}
+		if mqttOpts.QoS != nil {
+			opts[utils.MQTTQoS] = *mqttOpts.QoS
+		}
+		if mqttOpts.Retain != nil {
+			opts[utils.MQTTRetain] = *mqttOpts.Retain
+		}
+		if mqttOpts.ClientID != nil {
+			opts[utils.MQTTClientID] = *mqttOpts.ClientID
+		}
+		if mqttOpts.Username != nil {
+			opts[utils.MQTTUsername] = *mqttOpts.Username
+		}
+		if mqttOpts.Password != nil {
+			opts[utils.MQTTPassword] = *mqttOpts.Password
+		}
+		if mqttOpts.TLS != nil {
+			opts[utils.MQTTTLS] = *mqttOpts.TLS
+		}
+		if mqttOpts.CAPath != nil {
+			opts[utils.MQTTCAPath] = *mqttOpts.CAPath
+		}
+		if mqttOpts.ClientCertificate != nil {
+			opts[utils.MQTTClientCertificate] = *mqttOpts.ClientCertificate
+		}
+		if mqttOpts.ClientKey != nil {
+			opts[utils.MQTTClientKey] = *mqttOpts.ClientKey
+		}
+		if mqttOpts.SkipTLSVerify != nil {
+			opts[utils.MQTTSkipTLSVerify] = *mqttOpts.SkipTLSVerify
+		}
+	}
+	if redisOpts := eeC.Opts.RedisStreams; redisOpts != nil {
+		if redisOpts.Stream != nil {
+			opts[utils.RedisStreamsStream] = *redisOpts.Stream
+		}
+		if redisOpts.MaxLen != nil {
+			opts[utils.RedisStreamsMaxLen] = *redisOpts.MaxLen
+		}
+	}
+	if parquetOpts := eeC.Opts.Parquet; parquetOpts != nil {
+		if parquetOpts.ColumnTypes != nil {
+			opts[utils.ParquetColumnTypes] = parquetOpts.ColumnTypes
+		}
+		if parquetOpts.DecimalScale != nil {
+			opts[utils.ParquetDecimalScale] = *parquetOpts.DecimalScale
+		}
+		if parquetOpts.Compression != nil {
+			opts[utils.ParquetCompression] = *parquetOpts.Compression
+		}
+		if parquetOpts.MaxRecords != nil {
+			opts[utils.ParquetMaxRecords] = *parquetOpts.MaxRecords
+		}
+		if parquetOpts.MaxFileSize != nil {
+			opts[utils.ParquetMaxFileSize] = *parquetOpts.MaxFileSize
+		}
+		if parquetOpts.RotateInterval != nil {
+			opts[utils.ParquetRotateInterval] = parquetOpts.RotateInterval.String()
+		}
+		if parquetOpts.S3Endpoint != nil {
+			opts[utils.ParquetS3Endpoint] = *parquetOpts.S3Endpoint
+		}
+	}
 	if rpcOpts := eeC.Opts.RPC; rpcOpts != nil {
 		if rpcOpts.RPCCodec != nil {
 			opts[utils.RpcCodec] = *rpcOpts.RPCCodec
@@ -70,6 +70,16 @@ func TestEESClone(t *testing.T) {
 			"natsClientKey":"key",
 			"natsJetStreamMaxWait":"1m",
 			"kafkaTopic":"kafka",
+			"mqttTopic":"cgrates/;~*req.Account",
+			"mqttQoS":2,
+			"mqttRetain":true,
+			"redisStreamsStream":"cdrs",
+			"redisStreamsMaxLen":1000,
+			"parquetColumnTypes":{"AnswerTime":"*timestamp","Cost":"*decimal"},
+			"parquetDecimalScale":4,
+			"parquetCompression":"*zstd",
+			"parquetMaxRecords":10000,
+			"parquetRotateInterval":"1h",
 			"amqpQueueID":"id",
 			"amqpRoutingKey":"key",
 			"amqpExchangeType":"type",
@@ -126,6 +136,13 @@ func TestEESClone(t *testing.T) {
 				Precache:  false,
 				Replicate: false,
 			},
+			utils.MetaFileParquet: {
+				Limit:     -1,
+				TTL:       5 * time.Second,
+				StaticTTL: false,
+				Precache:  false,
+				Replicate: false,
+			},
 			utils.MetaAMQPV1jsonMap: {
 				Limit: -1,
 
@@ -175,6 +192,20 @@ func TestEESClone(t *testing.T) {
 				Precache:  false,
 				Replicate: false,
 			},
+			utils.MetaRedisStreams: {
+				Limit: -1,
+
+				StaticTTL: false,
+				Precache:  false,
+				Replicate: false,
+			},
+			utils.MetaMQTTjsonMap: {
+				Limit: -1,
+
+				StaticTTL: false,
+				Precache:  false,
+				Replicate: false,
+			},
 			utils.MetaKafkajsonMap: {
 				Limit: -1,
 
@@ -205,13 +236,16 @@ func TestEESClone(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					NATS:  &NATSOpts{},
-					SQL:   &SQLOpts{},
+					AMQP:         &AMQPOpts{},
+					AWS:          &AWSOpts{},
+					RPC:          &RPCOpts{},
+					Els:          &ElsOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					NATS:         &NATSOpts{},
+					SQL:          &SQLOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -318,6 +352,25 @@ func TestEESClone(t *testing.T) {
 					Kafka: &KafkaOpts{
 						Topic: utils.StringPointer("kafka"),
 					},
+					MQTT: &MQTTOpts{
+						Topic:  utils.StringPointer("cgrates/;~*req.Account"),
+						QoS:    utils.IntPointer(2),
+						Retain: utils.BoolPointer(true),
+					},
+					RedisStreams: &RedisStreamsOpts{
+						Stream: utils.StringPointer("cdrs"),
+						MaxLen: utils.IntPointer(1000),
+					},
+					Parquet: &ParquetOpts{
+						ColumnTypes: map[string]string{
+							"AnswerTime": utils.MetaTimestamp,
+							"Cost":       utils.MetaDecimal,
+						},
+						DecimalScale:   utils.IntPointer(4),
+						Compression:    utils.StringPointer(utils.MetaZstd),
+						MaxRecords:     utils.IntPointer(10000),
+						RotateInterval: utils.DurationPointer(time.Hour),
+					},
 					AWS: &AWSOpts{
 						Token:             utils.StringPointer("token"),
 						S3FolderPath:      utils.StringPointer("s3"),
@@ -487,9 +540,12 @@ func TestEventExporterOptsloadFromJsonCfg(t *testing.T) {
 			Token:        utils.StringPointer("token"),
 			S3FolderPath: utils.StringPointer("s3"),
 		},
-		AMQP:  &AMQPOpts{},
-		Kafka: &KafkaOpts{},
-		RPC:   &RPCOpts{},
+		AMQP:         &AMQPOpts{},
+		Kafka:        &KafkaOpts{},
+		MQTT:         &MQTTOpts{},
+		RedisStreams: &RedisStreamsOpts{},
+		Parquet:      &ParquetOpts{},
+		RPC:          &RPCOpts{},
 		NATS: &NATSOpts{
 			JetStream:            utils.BoolPointer(true),
 			Subject:              utils.StringPointer("nat"),
@@ -503,13 +559,16 @@ func TestEventExporterOptsloadFromJsonCfg(t *testing.T) {
 	}
 	eventExporter := &EventExporterCfg{
 		Opts: &EventExporterOpts{
-			Els:   &ElsOpts{},
-			Kafka: &KafkaOpts{},
-			AMQP:  &AMQPOpts{},
-			NATS:  &NATSOpts{},
-			SQL:   &SQLOpts{},
-			RPC:   &RPCOpts{},
-			AWS:   &AWSOpts{},
+			Els:          &ElsOpts{},
+			Kafka:        &KafkaOpts{},
+			MQTT:         &MQTTOpts{},
+			RedisStreams: &RedisStreamsOpts{},
+			Parquet:      &ParquetOpts{},
+			AMQP:         &AMQPOpts{},
+			NATS:         &NATSOpts{},
+			SQL:          &SQLOpts{},
+			RPC:          &RPCOpts{},
+			AWS:          &AWSOpts{},
 		},
 	}
 	if err := eventExporter.Opts.loadFromJSONCfg(eventExporterOptsJSON); err != nil {
@@ -569,6 +628,11 @@ func TestEventExporterSameID(t *testing.T) {
 				TTL:       5 * time.Second,
 				StaticTTL: false,
 			},
+			utils.MetaFileParquet: {
+				Limit:     -1,
+				TTL:       5 * time.Second,
+				StaticTTL: false,
+			},
 			utils.MetaSQL: {
 				Limit:     -1,
 				StaticTTL: false,
@@ -597,6 +661,14 @@ func TestEventExporterSameID(t *testing.T) {
 				Limit:     -1,
 				StaticTTL: false,
 			},
+			utils.MetaRedisStreams: {
+				Limit:     -1,
+				StaticTTL: false,
+			},
+			utils.MetaMQTTjsonMap: {
+				Limit:     -1,
+				StaticTTL: false,
+			},
 			utils.MetaKafkajsonMap: {
 				Limit:     -1,
 				StaticTTL: false,
@@ -622,13 +694,16 @@ func TestEventExporterSameID(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					NATS:  &NATSOpts{},
-					SQL:   &SQLOpts{},
+					AMQP:         &AMQPOpts{},
+					AWS:          &AWSOpts{},
+					RPC:          &RPCOpts{},
+					Els:          &ElsOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					NATS:         &NATSOpts{},
+					SQL:          &SQLOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -652,13 +727,16 @@ func TestEventExporterSameID(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					NATS:  &NATSOpts{},
-					SQL:   &SQLOpts{},
+					AMQP:         &AMQPOpts{},
+					AWS:          &AWSOpts{},
+					RPC:          &RPCOpts{},
+					Els:          &ElsOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					NATS:         &NATSOpts{},
+					SQL:          &SQLOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -749,6 +827,11 @@ func TestEEsCfgloadFromJsonCfgCase1(t *testing.T) {
 				TTL:       time.Second,
 				StaticTTL: false,
 			},
+			utils.MetaFileParquet: {
+				Limit:     -1,
+				TTL:       5 * time.Second,
+				StaticTTL: false,
+			},
 			utils.MetaSQL: {
 				Limit:     -1,
 				StaticTTL: false,
@@ -777,6 +860,14 @@ func TestEEsCfgloadFromJsonCfgCase1(t *testing.T) {
 				Limit:     -1,
 				StaticTTL: false,
 			},
+			utils.MetaRedisStreams: {
+				Limit:     -1,
+				StaticTTL: false,
+			},
+			utils.MetaMQTTjsonMap: {
+				Limit:     -1,
+				StaticTTL: false,
+			},
 			utils.MetaKafkajsonMap: {
 				Limit:     -1,
 				StaticTTL: false,
@@ -802,13 +893,16 @@ func TestEEsCfgloadFromJsonCfgCase1(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					SQL:   &SQLOpts{},
-					Kafka: &KafkaOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					NATS:  &NATSOpts{},
+					AMQP:         &AMQPOpts{},
+					AWS:          &AWSOpts{},
+					SQL:          &SQLOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					RPC:          &RPCOpts{},
+					Els:          &ElsOpts{},
+					NATS:         &NATSOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -834,13 +928,16 @@ func TestEEsCfgloadFromJsonCfgCase1(t *testing.T) {
 					},
 				},
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					SQL:   &SQLOpts{},
-					Kafka: &KafkaOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					NATS:  &NATSOpts{},
+					AMQP:         &AMQPOpts{},
+					AWS:          &AWSOpts{},
+					SQL:          &SQLOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					RPC:          &RPCOpts{},
+					Els:          &ElsOpts{},
+					NATS:         &NATSOpts{},
 				},
 				Fields: []*FCTemplate{
 					{Tag: utils.CGRID, Path: "*exp.CGRID", Type: utils.MetaVariable, Value: NewRSRParsersMustCompile("~*req.CGRID", utils.InfieldSep), Layout: time.RFC3339},
@@ -915,6 +1012,11 @@ func TestEEsCfgloadFromJsonCfgCase2(t *testing.T) {
 				TTL:       time.Second,
 				StaticTTL: false,
 			},
+			utils.MetaFileParquet: {
+				Limit:     -1,
+				TTL:       5 * time.Second,
+				StaticTTL: false,
+			},
 			utils.MetaSQL: {
 				Limit:     -1,
 				StaticTTL: false,
@@ -943,6 +1045,14 @@ func TestEEsCfgloadFromJsonCfgCase2(t *testing.T) {
 				Limit:     -1,
 				StaticTTL: false,
 			},
+			utils.MetaRedisStreams: {
+				Limit:     -1,
+				StaticTTL: false,
+			},
+			utils.MetaMQTTjsonMap: {
+				Limit:     -1,
+				StaticTTL: false,
+			},
 			utils.MetaKafkajsonMap: {
 				Limit:     -1,
 				StaticTTL: false,
@@ -968,13 +1078,16 @@ func TestEEsCfgloadFromJsonCfgCase2(t *testing.T) {
 				headerFields:  []*FCTemplate{},
 				trailerFields: []*FCTemplate{},
 				Opts: &EventExporterOpts{
-					Els:   &ElsOpts{},
-					Kafka: &KafkaOpts{},
-					AMQP:  &AMQPOpts{},
-					SQL:   &SQLOpts{},
-					AWS:   &AWSOpts{},
-					NATS:  &NATSOpts{},
-					RPC:   &RPCOpts{},
+					Els:          &ElsOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					AMQP:         &AMQPOpts{},
+					SQL:          &SQLOpts{},
+					AWS:          &AWSOpts{},
+					NATS:         &NATSOpts{},
+					RPC:          &RPCOpts{},
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 			},
@@ -1001,13 +1114,16 @@ func TestEEsCfgloadFromJsonCfgCase2(t *testing.T) {
 				},
 				FailedPostsDir: "/var/spool/cgrates/failed_posts",
 				Opts: &EventExporterOpts{
-					AMQP:  &AMQPOpts{},
-					AWS:   &AWSOpts{},
-					SQL:   &SQLOpts{},
-					Kafka: &KafkaOpts{},
-					RPC:   &RPCOpts{},
-					Els:   &ElsOpts{},
-					NATS:  &NATSOpts{},
+					AMQP:         &AMQPOpts{},
+					AWS:          &AWSOpts{},
+					SQL:          &SQLOpts{},
+					Kafka:        &KafkaOpts{},
+					MQTT:         &MQTTOpts{},
+					RedisStreams: &RedisStreamsOpts{},
+					Parquet:      &ParquetOpts{},
+					RPC:          &RPCOpts{},
+					Els:          &ElsOpts{},
+					NATS:         &NATSOpts{},
 				},
 				Fields: []*FCTemplate{
 					{
@@ -1085,6 +1201,8 @@ func TestEEsCfgAsMapInterface(t *testing.T) {
                     "elsIndex": "test",
                     "elsRefresh": "true",
                     "kafkaTopic": "test",
+                    "mqttTopic": "cgrates/thresholds",
+                    "mqttRetain": true,
                     "elsOpType": "test2",
                     "elsPipeline": "test3",
                     "elsRouting": "test4",
@@ -1176,6 +1294,14 @@ func TestEEsCfgAsMapInterface(t *testing.T) {
 				utils.TTLCfg:       "1s",
 				utils.StaticTTLCfg: false,
 			},
+			utils.MetaFileParquet: map[string]any{
+				utils.LimitCfg:     -1,
+				utils.PrecacheCfg:  false,
+				utils.ReplicateCfg: false,
+				utils.RemoteCfg:    false,
+				utils.TTLCfg:       "5s",
+				utils.StaticTTLCfg: false,
+			},
 			utils.MetaAMQPjsonMap: map[string]any{
 				utils.LimitCfg:     -1,
 				utils.PrecacheCfg:  false,
@@ -1197,6 +1323,20 @@ func TestEEsCfgAsMapInterface(t *testing.T) {
 				utils.RemoteCfg:    false,
 				utils.StaticTTLCfg: false,
 			},
+			utils.MetaRedisStreams: map[string]any{
+				utils.LimitCfg:     -1,
+				utils.PrecacheCfg:  false,
+				utils.ReplicateCfg: false,
+				utils.RemoteCfg:    false,
+				utils.StaticTTLCfg: false,
+			},
+			utils.MetaMQTTjsonMap: map[string]any{
+				utils.LimitCfg:     -1,
+				utils.PrecacheCfg:  false,
+				utils.ReplicateCfg: false,
+				utils.RemoteCfg:    false,
+				utils.StaticTTLCfg: false,
+			},
 			utils.MetaSQSjsonMap: map[string]any{
 				utils.LimitCfg:     -1,
 				utils.PrecacheCfg:  false,
@@ -1245,6 +1385,8 @@ func TestEEsCfgAsMapInterface(t *testing.T) {
 				utils.ExportPathCfg: "/tmp/testCSV",
 				utils.OptsCfg: map[string]any{
 					utils.KafkaTopic:                "test",
+					utils.MQTTTopic:                 "cgrates/thresholds",
+					utils.MQTTRetain:                true,
 					utils.ElsIndex:                  "test",
 					utils.ElsRefresh:                "true",
 					utils.ElsOpType:                 "test2",
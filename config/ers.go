@@ -358,6 +358,111 @@ func (natsOpts *NATSROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err err
 	return
 }
 
+type MQTTROpts struct {
+	Topic             *string
+	QoS               *int
+	ClientID          *string
+	CleanSession      *bool
+	Username          *string
+	Password          *string
+	TLS               *bool
+	CAPath            *string
+	ClientCertificate *string
+	ClientKey         *string
+	SkipTLSVerify     *bool
+}
+
+func (mqttROpts *MQTTROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err error) {
+	if jsnCfg.MQTTTopic != nil {
+		mqttROpts.Topic = jsnCfg.MQTTTopic
+	}
+	if jsnCfg.MQTTQoS != nil {
+		mqttROpts.QoS = jsnCfg.MQTTQoS
+	}
+	if jsnCfg.MQTTClientID != nil {
+		mqttROpts.ClientID = jsnCfg.MQTTClientID
+	}
+	if jsnCfg.MQTTCleanSession != nil {
+		mqttROpts.CleanSession = jsnCfg.MQTTCleanSession
+	}
+	if jsnCfg.MQTTUsername != nil {
+		mqttROpts.Username = jsnCfg.MQTTUsername
+	}
+	if jsnCfg.MQTTPassword != nil {
+		mqttROpts.Password = jsnCfg.MQTTPassword
+	}
+	if jsnCfg.MQTTTLS != nil {
+		mqttROpts.TLS = jsnCfg.MQTTTLS
+	}
+	if jsnCfg.MQTTCAPath != nil {
+		mqttROpts.CAPath = jsnCfg.MQTTCAPath
+	}
+	if jsnCfg.MQTTClientCertificate != nil {
+		mqttROpts.ClientCertificate = jsnCfg.MQTTClientCertificate
+	}
+	if jsnCfg.MQTTClientKey != nil {
+		mqttROpts.ClientKey = jsnCfg.MQTTClientKey
+	}
+	if jsnCfg.MQTTSkipTLSVerify != nil {
+		mqttROpts.SkipTLSVerify = jsnCfg.MQTTSkipTLSVerify
+	}
+	return
+}
+
+type RedisStreamsROpts struct {
+	Stream     *string
+	Group      *string
+	Consumer   *string
+	DeadLetter *string
+	Count      *int
+}
+
+func (redisROpts *RedisStreamsROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err error) {
+	if jsnCfg.RedisStreamsStream != nil {
+		redisROpts.Stream = jsnCfg.RedisStreamsStream
+	}
+	if jsnCfg.RedisStreamsGroup != nil {
+		redisROpts.Group = jsnCfg.RedisStreamsGroup
+	}
+	if jsnCfg.RedisStreamsConsumer != nil {
+		redisROpts.Consumer = jsnCfg.RedisStreamsConsumer
+	}
+	if jsnCfg.RedisStreamsDeadLetter != nil {
+		redisROpts.DeadLetter = jsnCfg.RedisStreamsDeadLetter
+	}
+	if jsnCfg.RedisStreamsCount != nil {
+		redisROpts.Count = jsnCfg.RedisStreamsCount
+	}
+	return
+}
+
+type HTTPROpts struct {
+	Username    *string
+	Password    *string
+	HMACSecret  *string
+	HMACHeader  *string
+	MaxBodySize *int64
+}
+
+func (httpROpts *HTTPROpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err error) {
+	if jsnCfg.HTTPUsername != nil {
+		httpROpts.Username = jsnCfg.HTTPUsername
+	}
+	if jsnCfg.HTTPPassword != nil {
+		httpROpts.Password = jsnCfg.HTTPPassword
+	}
+	if jsnCfg.HTTPHMACSecret != nil {
+		httpROpts.HMACSecret = jsnCfg.HTTPHMACSecret
+	}
+	if jsnCfg.HTTPHMACHeader != nil {
+		httpROpts.HMACHeader = jsnCfg.HTTPHMACHeader
+	}
+	if jsnCfg.HTTPMaxBodySize != nil {
+		httpROpts.MaxBodySize = jsnCfg.HTTPMaxBodySize
+	}
+	return
+}
+
 type CSVROpts struct {
 	PartialCSVFieldSeparator *string
 	RowLength                *int
@@ -396,6 +501,9 @@ type EventReaderOpts struct {
 	NATS               *NATSROpts
 	Kafka              *KafkaROpts
 	SQL                *SQLROpts
+	MQTT               *MQTTROpts
+	RedisStreams       *RedisStreamsROpts
+	HTTP               *HTTPROpts
 }
 
 // EventReaderCfg the event for the Event Reader
@@ -445,6 +553,15 @@ func (erOpts *EventReaderOpts) loadFromJSONCfg(jsnCfg *EventReaderOptsJson) (err
 	if err = erOpts.NATS.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
+	if err = erOpts.MQTT.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = erOpts.RedisStreams.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
+	if err = erOpts.HTTP.loadFromJSONCfg(jsnCfg); err != nil {
+		return
+	}
 	if err = erOpts.SQL.loadFromJSONCfg(jsnCfg); err != nil {
 		return
 	}
@@ -755,6 +872,105 @@ func (natOpts *NATSROpts) Clone() *NATSROpts {
 	return cln
 }
 
+func (mqttOpts *MQTTROpts) Clone() *MQTTROpts {
+	cln := &MQTTROpts{}
+	if mqttOpts.Topic != nil {
+		cln.Topic = new(string)
+		*cln.Topic = *mqttOpts.Topic
+	}
+	if mqttOpts.QoS != nil {
+		cln.QoS = new(int)
+		*cln.QoS = *mqttOpts.QoS
+	}
+	if mqttOpts.ClientID != nil {
+		cln.ClientID = new(string)
+		*cln.ClientID = *mqttOpts.ClientID
+	}
+	if mqttOpts.CleanSession != nil {
+		cln.CleanSession = new(bool)
+		*cln.CleanSession = *mqttOpts.CleanSession
+	}
+	if mqttOpts.Username != nil {
+		cln.Username = new(string)
+		*cln.Username = *mqttOpts.Username
+	}
+	if mqttOpts.Password != nil {
+		cln.Password = new(string)
+		*cln.Password = *mqttOpts.Password
+	}
+	if mqttOpts.TLS != nil {
+		cln.TLS = new(bool)
+		*cln.TLS = *mqttOpts.TLS
+	}
+	if mqttOpts.CAPath != nil {
+		cln.CAPath = new(string)
+		*cln.CAPath = *mqttOpts.CAPath
+	}
+	if mqttOpts.ClientCertificate != nil {
+		cln.ClientCertificate = new(string)
+		*cln.ClientCertificate = *mqttOpts.ClientCertificate
+	}
+	if mqttOpts.ClientKey != nil {
+		cln.ClientKey = new(string)
+		*cln.ClientKey = *mqttOpts.ClientKey
+	}
+	if mqttOpts.SkipTLSVerify != nil {
+		cln.SkipTLSVerify = new(bool)
+		*cln.SkipTLSVerify = *mqttOpts.SkipTLSVerify
+	}
+	return cln
+}
+
+func (redisROpts *RedisStreamsROpts) Clone() *RedisStreamsROpts {
+	cln := &RedisStreamsROpts{}
+	if redisROpts.Stream != nil {
+		cln.Stream = new(string)
+		*cln.Stream = *redisROpts.Stream
+	}
+	if redisROpts.Group != nil {
+		cln.Group = new(string)
+		*cln.Group = *redisROpts.Group
+	}
+	if redisROpts.Consumer != nil {
+		cln.Consumer = new(string)
+		*cln.Consumer = *redisROpts.Consumer
+	}
+	if redisROpts.DeadLetter != nil {
+		cln.DeadLetter = new(string)
+		*cln.DeadLetter = *redisROpts.DeadLetter
+	}
+	if redisROpts.Count != nil {
+		cln.Count = new(int)
+		*cln.Count = *redisROpts.Count
+	}
+	return cln
+}
+
+func (httpROpts *HTTPROpts) Clone() *HTTPROpts {
+	cln := &HTTPROpts{}
+	if httpROpts.Username != nil {
+		cln.Username = new(string)
+		*cln.Username = *httpROpts.Username
+	}
+	if httpROpts.Password != nil {
+		cln.Password = new(string)
+		*cln.Password = *httpROpts.Password
+	}
+	if httpROpts.HMACSecret != nil {
+		cln.HMACSecret = new(string)
+		*cln.HMACSecret = *httpROpts.HMACSecret
+	}
+	if httpROpts.HMACHeader != nil {
+		cln.HMACHeader = new(string)
+		*cln.HMACHeader = *httpROpts.HMACHeader
+	}
+	if httpROpts.MaxBodySize != nil {
+		cln.MaxBodySize = new(int64)
+		*cln.MaxBodySize = *httpROpts.MaxBodySize
+	}
+	return cln
+}
+
 func (erOpts *EventReaderOpts) Clone() *EventReaderOpts {
 	cln := &EventReaderOpts{}
 	if erOpts.PartialPath != nil {
@@ -791,6 +1007,15 @@ func (erOpts *EventReaderOpts) Clone() *EventReaderOpts {
 	if erOpts.AWS != nil {
 		cln.AWS = erOpts.AWS.Clone()
 	}
+	if erOpts.MQTT != nil {
+		cln.MQTT = erOpts.MQTT.Clone()
+	}
+	if erOpts.RedisStreams != nil {
+		cln.RedisStreams = erOpts.RedisStreams.Clone()
+	}
+	if erOpts.HTTP != nil {
+		cln.HTTP = erOpts.HTTP.Clone()
+	}
 
 	return cln
 }
@@ -992,6 +1217,76 @@ func (er *EventReaderCfg) AsMapInterface(separator string) (initialMP map[string
 			opts[utils.NatsJetStreamMaxWait] = natsOpts.JetStreamMaxWait.String()
 		}
 	}
+
+	if mqttOpts := er.Opts.MQTT; mqttOpts != nil {
+		if mqttOpts.Topic != nil {
+			opts[utils.MQTTTopic] = *mqttOpts.Topic
+		}
+		if mqttOpts.QoS != nil {
+			opts[utils.MQTTQoS] = *mqttOpts.QoS
+		}
+		if mqttOpts.ClientID != nil {
+			opts[utils.MQTTClientID] = *mqttOpts.ClientID
+		}
+		if mqttOpts.CleanSession != nil {
+			opts[utils.MQTTCleanSession] = *mqttOpts.CleanSession
+		}
+		if mqttOpts.Username != nil {
+			opts[utils.MQTTUsername] = *mqttOpts.Username
+		}
+		if mqttOpts.Password != nil {
+			opts[utils.MQTTPassword] = *mqttOpts.Password
+		}
+		if mqttOpts.TLS != nil {
+			opts[utils.MQTTTLS] = *mqttOpts.TLS
+		}
+		if mqttOpts.CAPath != nil {
+			opts[utils.MQTTCAPath] = *mqttOpts.CAPath
+		}
+		if mqttOpts.ClientCertificate != nil {
+			opts[utils.MQTTClientCertificate] = *mqttOpts.ClientCertificate
+		}
+		if mqttOpts.ClientKey != nil {
+			opts[utils.MQTTClientKey] = *mqttOpts.ClientKey
+		}
+		if mqttOpts.SkipTLSVerify != nil {
+			opts[utils.MQTTSkipTLSVerify] = *mqttOpts.SkipTLSVerify
+		}
+	}
+	if redisOpts := er.Opts.RedisStreams; redisOpts != nil {
+		if redisOpts.Stream != nil {
+			opts[utils.RedisStreamsStream] = *redisOpts.Stream
+		}
+		if redisOpts.Group != nil {
+			opts[utils.RedisStreamsGroup] = *redisOpts.Group
+		}
+		if redisOpts.Consumer != nil {
+			opts[utils.RedisStreamsConsumer] = *redisOpts.Consumer
+		}
+		if redisOpts.DeadLetter != nil {
+			opts[utils.RedisStreamsDeadLetter] = *redisOpts.DeadLetter
+		}
+		if redisOpts.Count != nil {
+			opts[utils.RedisStreamsCount] = *redisOpts.Count
+		}
+	}
+	if httpOpts := er.Opts.HTTP; httpOpts != nil {
+		if httpOpts.Username != nil {
+			opts[utils.HTTPUsername] = *httpOpts.Username
+		}
+		if httpOpts.Password != nil {
+			opts[utils.HTTPPassword] = *httpOpts.Password
+		}
+		if httpOpts.HMACSecret != nil {
+			opts[utils.HTTPHMACSecret] = *httpOpts.HMACSecret
+		}
+		if httpOpts.HMACHeader != nil {
+			opts[utils.HTTPHMACHeader] = *httpOpts.HMACHeader
+		}
+		if httpOpts.MaxBodySize != nil {
+			opts[utils.HTTPMaxBodySize] = *httpOpts.MaxBodySize
+		}
+	}
 	initialMP = map[string]any{
 		utils.IDCfg:                   er.ID,
 		utils.TypeCfg:                 er.Type,
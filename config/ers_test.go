@@ -122,6 +122,9 @@ func TestERSClone(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -166,6 +169,9 @@ func TestERSClone(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -301,6 +307,9 @@ func TestERSLoadFromjsonCfg(t *testing.T) {
 					AMQP:               &AMQPROpts{},
 					AWS:                &AWSROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					SQL:                &SQLROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
@@ -361,6 +370,9 @@ func TestERSLoadFromjsonCfg(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -564,6 +576,9 @@ func TestERSloadFromJsonCase3(t *testing.T) {
 					AMQP:               &AMQPROpts{},
 					AWS:                &AWSROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					SQL:                &SQLROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
@@ -608,6 +623,9 @@ func TestERSloadFromJsonCase3(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -729,6 +747,9 @@ func TestERSloadFromJsonCase4(t *testing.T) {
 					AMQP:               &AMQPROpts{},
 					AWS:                &AWSROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					SQL:                &SQLROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
@@ -773,6 +794,9 @@ func TestERSloadFromJsonCase4(t *testing.T) {
 					AMQP:               &AMQPROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -885,6 +909,9 @@ func TestEventReaderSameID(t *testing.T) {
 						RowLength:        utils.IntPointer(0),
 					},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					AMQP:               &AMQPROpts{},
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
@@ -927,6 +954,9 @@ func TestEventReaderSameID(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -1154,6 +1184,18 @@ func TestERSCfgAsMapInterfaceCase2(t *testing.T) {
 					"natsSeedFile":"seed",
 					"natsClientKey":"clientkey",
 					"natsJetStreamMaxWait":"1m",
+					"mqttTopic":"meters/+/usage",
+					"mqttQoS":2,
+					"mqttClientID":"cgrates_meters",
+					"mqttCleanSession":false,
+					"mqttTLS":true,
+					"mqttCAPath":"/etc/ca.pem",
+					"redisStreamsStream":"cdrs",
+					"redisStreamsGroup":"billing",
+					"redisStreamsDeadLetter":"cdrs_failed",
+					"redisStreamsCount":50,
+					"httpUsername":"partner1",
+					"httpHMACSecret":"s3cr3t",
 				},
 			},
 		],
@@ -1274,6 +1316,18 @@ func TestERSCfgAsMapInterfaceCase2(t *testing.T) {
 					utils.NatsClientCertificate:      "certificate",
 					utils.NatsClientKey:              "clientkey",
 					utils.NatsJetStreamMaxWait:       "1m0s",
+					utils.MQTTTopic:                  "meters/+/usage",
+					utils.MQTTQoS:                    2,
+					utils.MQTTClientID:               "cgrates_meters",
+					utils.MQTTCleanSession:           false,
+					utils.MQTTTLS:                    true,
+					utils.MQTTCAPath:                 "/etc/ca.pem",
+					utils.RedisStreamsStream:         "cdrs",
+					utils.RedisStreamsGroup:          "billing",
+					utils.RedisStreamsDeadLetter:     "cdrs_failed",
+					utils.RedisStreamsCount:          50,
+					utils.HTTPUsername:               "partner1",
+					utils.HTTPHMACSecret:             "s3cr3t",
 				},
 			},
 		},
@@ -1374,6 +1428,9 @@ func TestERsloadFromJsonCfg(t *testing.T) {
 						RowLength:        utils.IntPointer(0),
 					},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					AMQP:               &AMQPROpts{},
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
@@ -1422,6 +1479,9 @@ func TestERsloadFromJsonCfg(t *testing.T) {
 					AWS:                &AWSROpts{},
 					SQL:                &SQLROpts{},
 					Kafka:              &KafkaROpts{},
+					MQTT:               &MQTTROpts{},
+					RedisStreams:       &RedisStreamsROpts{},
+					HTTP:               &HTTPROpts{},
 					PartialOrderField:  utils.StringPointer("~*req.AnswerTime"),
 					PartialCacheAction: utils.StringPointer(utils.MetaNone),
 					NATS: &NATSROpts{
@@ -1487,12 +1547,15 @@ func TestEventReaderOptsCfg(t *testing.T) {
 	}
 	eventReader := &EventReaderCfg{
 		Opts: &EventReaderOpts{
-			CSV:   &CSVROpts{},
-			AMQP:  &AMQPROpts{},
-			AWS:   &AWSROpts{},
-			NATS:  &NATSROpts{},
-			Kafka: &KafkaROpts{},
-			SQL:   &SQLROpts{},
+			CSV:          &CSVROpts{},
+			AMQP:         &AMQPROpts{},
+			AWS:          &AWSROpts{},
+			NATS:         &NATSROpts{},
+			Kafka:        &KafkaROpts{},
+			MQTT:         &MQTTROpts{},
+			RedisStreams: &RedisStreamsROpts{},
+			HTTP:         &HTTPROpts{},
+			SQL:          &SQLROpts{},
 		},
 	}
 	if err := eventReader.Opts.loadFromJSONCfg(eventReaderOptsJson); err != nil {
@@ -1577,6 +1640,28 @@ func TestEventReaderCfgClone(t *testing.T) {
 				MaxWait: utils.DurationPointer(1 * time.Minute),
 				GroupID: utils.StringPointer("groupId"),
 			},
+			MQTT: &MQTTROpts{
+				Topic:        utils.StringPointer("meters/+/usage"),
+				QoS:          utils.IntPointer(2),
+				ClientID:     utils.StringPointer("cgrates_meters"),
+				CleanSession: utils.BoolPointer(false),
+				TLS:          utils.BoolPointer(true),
+				CAPath:       utils.StringPointer("/etc/ca.pem"),
+			},
+			RedisStreams: &RedisStreamsROpts{
+				Stream:     utils.StringPointer("cdrs"),
+				Group:      utils.StringPointer("billing"),
+				Consumer:   utils.StringPointer("cgrates_1"),
+				DeadLetter: utils.StringPointer("cdrs_failed"),
+				Count:      utils.IntPointer(50),
+			},
+			HTTP: &HTTPROpts{
+				Username:    utils.StringPointer("partner1"),
+				Password:    utils.StringPointer("pass"),
+				HMACSecret:  utils.StringPointer("s3cr3t"),
+				HMACHeader:  utils.StringPointer("X-Hub-Signature-256"),
+				MaxBodySize: utils.Int64Pointer(4096),
+			},
 		},
 	}
 	rcv := ban.Clone()
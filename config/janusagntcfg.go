@@ -19,6 +19,8 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
 )
@@ -73,7 +75,8 @@ type JanusAgentCfg struct {
 	Enabled           bool
 	URL               string
 	SessionSConns     []string
-	JanusConns        []*JanusConn // connections towards Janus
+	JanusConns        []*JanusConn  // connections towards Janus
+	DebitInterval     time.Duration // interval between session updates, 0 to disable
 	RequestProcessors []*RequestProcessor
 }
 
@@ -112,6 +115,12 @@ func (jaCfg *JanusAgentCfg) loadFromJSONCfg(jsnCfg *JanusAgentJsonCfg, separator
 		}
 	}
 
+	if jsnCfg.Debit_interval != nil {
+		if jaCfg.DebitInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Debit_interval); err != nil {
+			return
+		}
+	}
+
 	if jsnCfg.RequestProcessors != nil {
 		for _, reqProcJsn := range *jsnCfg.RequestProcessors {
 			rp := new(RequestProcessor)
@@ -139,8 +148,12 @@ func (jaCfg *JanusAgentCfg) loadFromJSONCfg(jsnCfg *JanusAgentJsonCfg, separator
 func (jaCfg *JanusAgentCfg) AsMapInterface(separator string) (initialMP map[string]any) {
 
 	initialMP = map[string]any{
-		utils.EnabledCfg: jaCfg.Enabled,
-		utils.URLCfg:     jaCfg.URL,
+		utils.EnabledCfg:       jaCfg.Enabled,
+		utils.URLCfg:           jaCfg.URL,
+		utils.DebitIntervalCfg: "0",
+	}
+	if jaCfg.DebitInterval != 0 {
+		initialMP[utils.DebitIntervalCfg] = jaCfg.DebitInterval.String()
 	}
 
 	if jaCfg.SessionSConns != nil {
@@ -176,8 +189,9 @@ func (jaCfg *JanusAgentCfg) AsMapInterface(separator string) (initialMP map[stri
 
 func (jaCfg *JanusAgentCfg) Clone() *JanusAgentCfg {
 	cln := &JanusAgentCfg{
-		Enabled: jaCfg.Enabled,
-		URL:     jaCfg.URL,
+		Enabled:       jaCfg.Enabled,
+		URL:           jaCfg.URL,
+		DebitInterval: jaCfg.DebitInterval,
 	}
 
 	if jaCfg.SessionSConns != nil {
@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -51,3 +52,26 @@ func TestJanusConnAsMapInterface(t *testing.T) {
 	}
 
 }
+
+func TestJanusAgentCfgDebitInterval(t *testing.T) {
+	jaCfg := new(JanusAgentCfg)
+	if err := jaCfg.loadFromJSONCfg(&JanusAgentJsonCfg{
+		Debit_interval: utils.StringPointer("30s"),
+	}, utils.InfieldSep); err != nil {
+		t.Fatal(err)
+	}
+	if jaCfg.DebitInterval != 30*time.Second {
+		t.Errorf("expected %v received %v", 30*time.Second, jaCfg.DebitInterval)
+	}
+	if rcv := jaCfg.AsMapInterface(utils.InfieldSep)[utils.DebitIntervalCfg]; rcv != "30s" {
+		t.Errorf("expected %q received %v", "30s", rcv)
+	}
+	if cln := jaCfg.Clone(); cln.DebitInterval != jaCfg.DebitInterval {
+		t.Errorf("expected %v received %v", jaCfg.DebitInterval, cln.DebitInterval)
+	}
+	if err := jaCfg.loadFromJSONCfg(&JanusAgentJsonCfg{
+		Debit_interval: utils.StringPointer("invalid"),
+	}, utils.InfieldSep); err == nil {
+		t.Error("expected error for invalid debit_interval")
+	}
+}
@@ -85,12 +85,13 @@ func (kamCfg KamConnCfg) Clone() *KamConnCfg {
 
 // KamAgentCfg is the Kamailio config section
 type KamAgentCfg struct {
-	Enabled       bool
-	SessionSConns []string
-	CreateCdr     bool
-	EvapiConns    []*KamConnCfg
-	Timezone      string
-	RouteProfile  bool
+	Enabled           bool
+	SessionSConns     []string
+	CreateCdr         bool
+	EvapiConns        []*KamConnCfg
+	Timezone          string
+	RouteProfile      bool
+	LowBalanceAnnFile string
 }
 
 func (ka *KamAgentCfg) loadFromJSONCfg(jsnCfg *KamAgentJsonCfg) error {
@@ -127,16 +128,20 @@ func (ka *KamAgentCfg) loadFromJSONCfg(jsnCfg *KamAgentJsonCfg) error {
 	if jsnCfg.Route_profile != nil {
 		ka.RouteProfile = *jsnCfg.Route_profile
 	}
+	if jsnCfg.Low_balance_ann_file != nil {
+		ka.LowBalanceAnnFile = *jsnCfg.Low_balance_ann_file
+	}
 	return nil
 }
 
 // AsMapInterface returns the config as a map[string]any
 func (ka *KamAgentCfg) AsMapInterface() (initialMP map[string]any) {
 	initialMP = map[string]any{
-		utils.EnabledCfg:      ka.Enabled,
-		utils.CreateCdrCfg:    ka.CreateCdr,
-		utils.TimezoneCfg:     ka.Timezone,
-		utils.RouteProfileCfg: ka.RouteProfile,
+		utils.EnabledCfg:           ka.Enabled,
+		utils.CreateCdrCfg:         ka.CreateCdr,
+		utils.TimezoneCfg:          ka.Timezone,
+		utils.RouteProfileCfg:      ka.RouteProfile,
+		utils.LowBalanceAnnFileCfg: ka.LowBalanceAnnFile,
 	}
 	if ka.EvapiConns != nil {
 		evapiConns := make([]map[string]any, len(ka.EvapiConns))
@@ -163,10 +168,11 @@ func (ka *KamAgentCfg) AsMapInterface() (initialMP map[string]any) {
 // Clone returns a deep copy of KamAgentCfg
 func (ka KamAgentCfg) Clone() (cln *KamAgentCfg) {
 	cln = &KamAgentCfg{
-		Enabled:      ka.Enabled,
-		CreateCdr:    ka.CreateCdr,
-		Timezone:     ka.Timezone,
-		RouteProfile: ka.RouteProfile,
+		Enabled:           ka.Enabled,
+		CreateCdr:         ka.CreateCdr,
+		Timezone:          ka.Timezone,
+		RouteProfile:      ka.RouteProfile,
+		LowBalanceAnnFile: ka.LowBalanceAnnFile,
 	}
 	if ka.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(ka.SessionSConns))
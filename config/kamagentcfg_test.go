@@ -38,15 +38,17 @@ func TestKamAgentCfgloadFromJsonCfg(t *testing.T) {
 				Reconnects: utils.IntPointer(10),
 			},
 		},
-		Timezone: utils.StringPointer("Local"),
+		Timezone:             utils.StringPointer("Local"),
+		Low_balance_ann_file: utils.StringPointer("low_balance.wav"),
 	}
 	expected := &KamAgentCfg{
-		Enabled:       true,
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
-		CreateCdr:     true,
-		RouteProfile:  true,
-		EvapiConns:    []*KamConnCfg{{Address: "127.0.0.1:8448", Reconnects: 10, Alias: "randomAlias"}},
-		Timezone:      "Local",
+		Enabled:           true,
+		SessionSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		CreateCdr:         true,
+		RouteProfile:      true,
+		EvapiConns:        []*KamConnCfg{{Address: "127.0.0.1:8448", Reconnects: 10, Alias: "randomAlias"}},
+		Timezone:          "Local",
+		LowBalanceAnnFile: "low_balance.wav",
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err := jsnCfg.kamAgentCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -103,17 +105,19 @@ func TestKamAgentCfgAsMapInterface(t *testing.T) {
 			"create_cdr": true,
 			"route_profile": true,
 			"timezone": "UTC",
+			"low_balance_ann_file": "low_balance.wav",
 			"evapi_conns":[
 				{"address": "127.0.0.1:8448", "reconnects": 5, "alias": ""}
 			],
 		},
 	}`
 	eMap := map[string]any{
-		utils.EnabledCfg:       false,
-		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2", utils.MetaInternal},
-		utils.CreateCdrCfg:     true,
-		utils.RouteProfileCfg:  true,
-		utils.TimezoneCfg:      "UTC",
+		utils.EnabledCfg:           false,
+		utils.SessionSConnsCfg:     []string{rpcclient.BiRPCInternal, "*conn1", "*conn2", utils.MetaInternal},
+		utils.CreateCdrCfg:         true,
+		utils.RouteProfileCfg:      true,
+		utils.TimezoneCfg:          "UTC",
+		utils.LowBalanceAnnFileCfg: "low_balance.wav",
 		utils.EvapiConnsCfg: []map[string]any{
 			{utils.AddressCfg: "127.0.0.1:8448", utils.ReconnectsCfg: 5, utils.MaxReconnectIntervalCfg: "0s", utils.AliasCfg: ""},
 		},
@@ -130,11 +134,12 @@ func TestKamAgentCfgAsMapInterface1(t *testing.T) {
 	"kamailio_agent": {},
 }`
 	eMap := map[string]any{
-		utils.EnabledCfg:       false,
-		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal},
-		utils.CreateCdrCfg:     false,
-		utils.RouteProfileCfg:  false,
-		utils.TimezoneCfg:      "",
+		utils.EnabledCfg:           false,
+		utils.SessionSConnsCfg:     []string{rpcclient.BiRPCInternal},
+		utils.CreateCdrCfg:         false,
+		utils.RouteProfileCfg:      false,
+		utils.TimezoneCfg:          "",
+		utils.LowBalanceAnnFileCfg: "",
 		utils.EvapiConnsCfg: []map[string]any{
 			{utils.AddressCfg: "127.0.0.1:8448", utils.ReconnectsCfg: 5, utils.MaxReconnectIntervalCfg: "0s", utils.AliasCfg: ""},
 		},
@@ -148,12 +153,13 @@ func TestKamAgentCfgAsMapInterface1(t *testing.T) {
 
 func TestKamAgentCfgClone(t *testing.T) {
 	ban := &KamAgentCfg{
-		Enabled:       true,
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
-		CreateCdr:     true,
-		RouteProfile:  true,
-		EvapiConns:    []*KamConnCfg{{Address: "127.0.0.1:8448", Reconnects: 10, Alias: "randomAlias"}},
-		Timezone:      "Local",
+		Enabled:           true,
+		SessionSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		CreateCdr:         true,
+		RouteProfile:      true,
+		EvapiConns:        []*KamConnCfg{{Address: "127.0.0.1:8448", Reconnects: 10, Alias: "randomAlias"}},
+		Timezone:          "Local",
+		LowBalanceAnnFile: "low_balance.wav",
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -282,6 +282,27 @@ type EventReaderOptsJson struct {
 	NATSClientCertificate    *string   `json:"natsClientCertificate"`
 	NATSClientKey            *string   `json:"natsClientKey"`
 	NATSJetStreamMaxWait     *string   `json:"natsJetStreamMaxWait"`
+	MQTTTopic                *string   `json:"mqttTopic"`
+	MQTTQoS                  *int      `json:"mqttQoS"`
+	MQTTClientID             *string   `json:"mqttClientID"`
+	MQTTCleanSession         *bool     `json:"mqttCleanSession"`
+	MQTTUsername             *string   `json:"mqttUsername"`
+	MQTTPassword             *string   `json:"mqttPassword"`
+	MQTTTLS                  *bool     `json:"mqttTLS"`
+	MQTTCAPath               *string   `json:"mqttCAPath"`
+	MQTTClientCertificate    *string   `json:"mqttClientCertificate"`
+	MQTTClientKey            *string   `json:"mqttClientKey"`
+	MQTTSkipTLSVerify        *bool     `json:"mqttSkipTLSVerify"`
+	RedisStreamsStream       *string   `json:"redisStreamsStream"`
+	RedisStreamsGroup        *string   `json:"redisStreamsGroup"`
+	RedisStreamsConsumer     *string   `json:"redisStreamsConsumer"`
+	RedisStreamsDeadLetter   *string   `json:"redisStreamsDeadLetter"`
+	RedisStreamsCount        *int      `json:"redisStreamsCount"`
+	HTTPUsername             *string   `json:"httpUsername"`
+	HTTPPassword             *string   `json:"httpPassword"`
+	HTTPHMACSecret           *string   `json:"httpHMACSecret"`
+	HTTPHMACHeader           *string   `json:"httpHMACHeader"`
+	HTTPMaxBodySize          *int64    `json:"httpMaxBodySize"`
 }
 
 // EventReaderSJsonCfg is the configuration of a single EventReader
@@ -386,6 +407,26 @@ type EventExporterOptsJson struct {
 	NATSClientCertificate       *string           `json:"natsClientCertificate"`
 	NATSClientKey               *string           `json:"natsClientKey"`
 	NATSJetStreamMaxWait        *string           `json:"natsJetStreamMaxWait"`
+	MQTTTopic                   *string           `json:"mqttTopic"`
+	MQTTQoS                     *int              `json:"mqttQoS"`
+	MQTTRetain                  *bool             `json:"mqttRetain"`
+	MQTTClientID                *string           `json:"mqttClientID"`
+	MQTTUsername                *string           `json:"mqttUsername"`
+	MQTTPassword                *string           `json:"mqttPassword"`
+	MQTTTLS                     *bool             `json:"mqttTLS"`
+	MQTTCAPath                  *string           `json:"mqttCAPath"`
+	MQTTClientCertificate       *string           `json:"mqttClientCertificate"`
+	MQTTClientKey               *string           `json:"mqttClientKey"`
+	MQTTSkipTLSVerify           *bool             `json:"mqttSkipTLSVerify"`
+	RedisStreamsStream          *string           `json:"redisStreamsStream"`
+	RedisStreamsMaxLen          *int              `json:"redisStreamsMaxLen"`
+	ParquetColumnTypes          map[string]string `json:"parquetColumnTypes"`
+	ParquetDecimalScale         *int              `json:"parquetDecimalScale"`
+	ParquetCompression          *string           `json:"parquetCompression"`
+	ParquetMaxRecords           *int              `json:"parquetMaxRecords"`
+	ParquetMaxFileSize          *int              `json:"parquetMaxFileSize"`
+	ParquetRotateInterval       *string           `json:"parquetRotateInterval"`
+	ParquetS3Endpoint           *string           `json:"parquetS3Endpoint"`
 	RPCCodec                    *string           `json:"rpcCodec"`
 	ServiceMethod               *string           `json:"serviceMethod"`
 	KeyPath                     *string           `json:"keyPath"`
@@ -512,11 +553,12 @@ type AstConnJsonCfg struct {
 }
 
 type AsteriskAgentJsonCfg struct {
-	Enabled        *bool
-	Sessions_conns *[]string
-	Create_cdr     *bool
-	Route_profile  *bool
-	Asterisk_conns *[]*AstConnJsonCfg
+	Enabled              *bool
+	Sessions_conns       *[]string
+	Create_cdr           *bool
+	Route_profile        *bool
+	Low_balance_ann_file *string
+	Asterisk_conns       *[]*AstConnJsonCfg
 }
 
 type CacheParamJsonCfg struct {
@@ -536,12 +578,13 @@ type CacheJsonCfg struct {
 
 // SM-Kamailio config section
 type KamAgentJsonCfg struct {
-	Enabled        *bool
-	Sessions_conns *[]string
-	Create_cdr     *bool
-	Evapi_conns    *[]*KamConnJsonCfg
-	Timezone       *string
-	Route_profile  *bool
+	Enabled              *bool
+	Sessions_conns       *[]string
+	Create_cdr           *bool
+	Evapi_conns          *[]*KamConnJsonCfg
+	Timezone             *string
+	Route_profile        *bool
+	Low_balance_ann_file *string
 }
 
 // Represents one connection instance towards Kamailio
@@ -565,25 +608,44 @@ type DiamListenerJsnCfg struct {
 
 // DiameterAgent configuration
 type DiameterAgentJsonCfg struct {
-	Enabled                 *bool                  `json:"enabled"`
-	Listeners               *[]*DiamListenerJsnCfg `json:"listeners"`
-	DictionariesPath        *string                `json:"dictionaries_path"`
-	CeApplications          *[]string              `json:"ce_applications"`
-	SessionSConns           *[]string              `json:"sessions_conns"`
-	StatSConns              *[]string              `json:"stats_conns"`
-	ThresholdSConns         *[]string              `json:"thresholds_conns"`
-	OriginHost              *string                `json:"origin_host"`
-	OriginRealm             *string                `json:"origin_realm"`
-	VendorID                *int                   `json:"vendor_id"`
-	ProductName             *string                `json:"product_name"`
-	SyncedConnRequests      *bool                  `json:"synced_conn_requests"`
-	ASRTemplate             *string                `json:"asr_template"`
-	RARTemplate             *string                `json:"rar_template"`
-	ForcedDisconnect        *string                `json:"forced_disconnect"`
-	StatQueueIDs            *[]string              `json:"conn_status_stat_queue_ids"`
-	ThresholdIDs            *[]string              `json:"conn_status_threshold_ids"`
-	ConnHealthCheckInterval *string                `json:"conn_health_check_interval"`
-	RequestProcessors       *[]*ReqProcessorJsnCfg `json:"request_processors"`
+	Enabled                 *bool                     `json:"enabled"`
+	Listeners               *[]*DiamListenerJsnCfg    `json:"listeners"`
+	DictionariesPath        *string                   `json:"dictionaries_path"`
+	CeApplications          *[]string                 `json:"ce_applications"`
+	SessionSConns           *[]string                 `json:"sessions_conns"`
+	StatSConns              *[]string                 `json:"stats_conns"`
+	ThresholdSConns         *[]string                 `json:"thresholds_conns"`
+	OriginHost              *string                   `json:"origin_host"`
+	OriginRealm             *string                   `json:"origin_realm"`
+	VendorID                *int                      `json:"vendor_id"`
+	ProductName             *string                   `json:"product_name"`
+	SyncedConnRequests      *bool                     `json:"synced_conn_requests"`
+	ASRTemplate             *string                   `json:"asr_template"`
+	RARTemplate             *string                   `json:"rar_template"`
+	ForcedDisconnect        *string                   `json:"forced_disconnect"`
+	StatQueueIDs            *[]string                 `json:"conn_status_stat_queue_ids"`
+	ThresholdIDs            *[]string                 `json:"conn_status_threshold_ids"`
+	ConnHealthCheckInterval *string                   `json:"conn_health_check_interval"`
+	OCSClient               *DiamOCSClientJsonCfg     `json:"ocs_client"`
+	RelayWatchdogInterval   *string                   `json:"relay_watchdog_interval"`
+	RealmRoutes             *[]*DiamRealmRouteJsonCfg `json:"realm_routes"`
+	RequestProcessors       *[]*ReqProcessorJsnCfg    `json:"request_processors"`
+}
+
+// DiamRealmRouteJsonCfg is one entry of the DiameterAgent realm routing table
+type DiamRealmRouteJsonCfg struct {
+	Realm          *string                `json:"realm"`
+	ApplicationIDs *[]uint32              `json:"application_ids"`
+	Peers          *[]*DiamListenerJsnCfg `json:"peers"`
+}
+
+// DiamOCSClientJsonCfg configures the *diameter connections towards upstream OCSs
+type DiamOCSClientJsonCfg struct {
+	CCRTemplate      *string `json:"ccr_template"`
+	CCATemplate      *string `json:"cca_template"`
+	WatchdogInterval *string `json:"watchdog_interval"`
+	TxTimer          *string `json:"tx_timer"`
+	TccTimer         *string `json:"tcc_timer"`
 }
 
 type RadiListenerJsnCfg struct {
@@ -612,9 +674,17 @@ type RadiusAgentJsonCfg struct {
 	RequestsCacheKey   *string                     `json:"requests_cache_key"`
 	DMRTemplate        *string                     `json:"dmr_template"`
 	CoATemplate        *string                     `json:"coa_template"`
+	EAPServer          *EAPServerJsonCfg           `json:"eap_server"`
 	RequestProcessors  *[]*ReqProcessorJsnCfg      `json:"request_processors"`
 }
 
+// EAPServerJsonCfg is the upstream server the RadiusAgent proxies the EAP conversations to
+type EAPServerJsonCfg struct {
+	Address   *string `json:"address"`
+	Transport *string `json:"transport"`
+	Secret    *string `json:"secret"`
+}
+
 // Conecto Agent configuration section
 type HttpAgentJsonCfg struct {
 	ID                *string                `json:"id"`
@@ -839,16 +909,20 @@ type SureTaxJsonCfg struct {
 }
 
 type DispatcherSJsonCfg struct {
-	Enabled               *bool
-	Indexed_selects       *bool
-	String_indexed_fields *[]string
-	Prefix_indexed_fields *[]string
-	Suffix_indexed_fields *[]string
-	ExistsIndexedFields   *[]string `json:"exists_indexed_fields"`
-	Nested_fields         *bool     // applies when indexed fields is not defined
-	Attributes_conns      *[]string
-	Any_subsystem         *bool
-	Prevent_loop          *bool
+	Enabled                  *bool
+	Indexed_selects          *bool
+	String_indexed_fields    *[]string
+	Prefix_indexed_fields    *[]string
+	Suffix_indexed_fields    *[]string
+	ExistsIndexedFields      *[]string `json:"exists_indexed_fields"`
+	Nested_fields            *bool     // applies when indexed fields is not defined
+	Attributes_conns         *[]string
+	Any_subsystem            *bool
+	Prevent_loop             *bool
+	Health_check_interval    *string
+	Health_check_method      *string
+	Circuit_breaker_failures *int
+	Circuit_breaker_cooldown *string
 }
 
 type RegistrarCJsonCfg struct {
@@ -948,6 +1022,7 @@ type SIPAgentJsonCfg struct {
 	ThresholdSConns     *[]string              `json:"thresholds_conns"`
 	Timezone            *string                `json:"timezone"`
 	RetransmissionTimer *string                `json:"retransmission_timer"`
+	MethodProcessors    *map[string][]string   `json:"method_processors"`
 	RequestProcessors   *[]*ReqProcessorJsnCfg `json:"request_processors"`
 }
 
@@ -956,6 +1031,7 @@ type JanusAgentJsonCfg struct {
 	Url               *string                `json:"url"`
 	Sessions_conns    *[]string              `json:"sessions_conns"`
 	Janus_conns       *[]*JanusConnJsonCfg   `json:"janus_conns"`
+	Debit_interval    *string                `json:"debit_interval"`
 	RequestProcessors *[]*ReqProcessorJsnCfg `json:"request_processors"`
 }
 
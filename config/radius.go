@@ -30,7 +30,7 @@ import (
 type RadiusListener struct {
 	AuthAddr string
 	AcctAddr string
-	Network  string // udp or tcp
+	Network  string // udp, tcp or tcp-tls (RadSec)
 }
 
 // RadiusAgentCfg the config section that describes the Radius Agent
@@ -46,6 +46,7 @@ type RadiusAgentCfg struct {
 	RequestsCacheKey   RSRParsers
 	DMRTemplate        string
 	CoATemplate        string
+	EAPServer          EAPServerOpts
 	RequestProcessors  []*RequestProcessor
 }
 
@@ -126,6 +127,7 @@ func (ra *RadiusAgentCfg) loadFromJSONCfg(jsnCfg *RadiusAgentJsonCfg, separator
 	if jsnCfg.CoATemplate != nil {
 		ra.CoATemplate = *jsnCfg.CoATemplate
 	}
+	ra.EAPServer.loadFromJSONCfg(jsnCfg.EAPServer)
 	if jsnCfg.RequestProcessors != nil {
 		for _, reqProcJsn := range *jsnCfg.RequestProcessors {
 			rp := new(RequestProcessor)
@@ -176,6 +178,7 @@ func (ra *RadiusAgentCfg) AsMapInterface(separator string) map[string]any {
 		utils.RequestsCacheKeyCfg:   ra.RequestsCacheKey.GetRule(separator),
 		utils.DMRTemplateCfg:        ra.DMRTemplate,
 		utils.CoATemplateCfg:        ra.CoATemplate,
+		utils.EAPServerCfg:          ra.EAPServer.AsMapInterface(),
 		utils.StatSConnsCfg:         stripInternalConns(ra.StatSConns),
 		utils.ThresholdSConnsCfg:    stripInternalConns(ra.ThresholdSConns),
 		utils.RequestProcessorsCfg:  requestProcessors,
@@ -216,6 +219,7 @@ func (ra RadiusAgentCfg) Clone() *RadiusAgentCfg {
 		RequestsCacheKey: ra.RequestsCacheKey.Clone(),
 		DMRTemplate:      ra.DMRTemplate,
 		CoATemplate:      ra.CoATemplate,
+		EAPServer:        ra.EAPServer,
 	}
 
 	if len(ra.ClientDaAddresses) != 0 {
@@ -283,6 +287,36 @@ func (cda *DAClientOpts) AsMapInterface() map[string]any {
 	return mp
 }
 
+// EAPServerOpts describes the upstream server the EAP conversations are proxied to
+type EAPServerOpts struct {
+	Address   string // empty to disable the EAP proxying
+	Transport string // <udp|tcp>
+	Secret    string // shared secret towards the EAP server
+}
+
+func (eap *EAPServerOpts) loadFromJSONCfg(jsnCfg *EAPServerJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Address != nil {
+		eap.Address = *jsnCfg.Address
+	}
+	if jsnCfg.Transport != nil {
+		eap.Transport = *jsnCfg.Transport
+	}
+	if jsnCfg.Secret != nil {
+		eap.Secret = *jsnCfg.Secret
+	}
+}
+
+func (eap *EAPServerOpts) AsMapInterface() map[string]any {
+	return map[string]any{
+		utils.AddressCfg:   eap.Address,
+		utils.TransportCfg: eap.Transport,
+		utils.SecretCfg:    eap.Secret,
+	}
+}
+
 func diffMapStringSlice(d, v1, v2 map[string][]string) map[string][]string {
 	if d == nil {
 		d = make(map[string][]string)
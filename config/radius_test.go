@@ -86,6 +86,7 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ThresholdSConns:    []string{},
 		DMRTemplate:        "*dmr",
 		CoATemplate:        "*coa",
+		EAPServer:          EAPServerOpts{Transport: utils.UDP},
 		ClientDaAddresses: map[string]DAClientOpts{
 			"fsfdsz": {
 				Transport: "http",
@@ -198,6 +199,7 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	     "thresholds_conns": ["*internal", "*conn1","*conn2"],
 		 "dmr_template": "*dmr",
 		 "coa_template": "*coa",
+		 "eap_server": {"address": "127.0.0.1:18120", "secret": "CGRateS.org"},
 		 "requests_cache_key": "~*req.Acc-Session-Id",
          "request_processors": [
 			{
@@ -228,11 +230,16 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string][]string{
 			utils.MetaDefault: {"/usr/share/cgrates/"},
 		},
-		utils.SessionSConnsCfg:    []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
-		utils.StatSConnsCfg:       []string{rpcclient.InternalRPC, "*conn1", "*conn2"},
-		utils.ThresholdSConnsCfg:  []string{rpcclient.InternalRPC, "*conn1", "*conn2"},
-		utils.DMRTemplateCfg:      "*dmr",
-		utils.CoATemplateCfg:      "*coa",
+		utils.SessionSConnsCfg:   []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.StatSConnsCfg:      []string{rpcclient.InternalRPC, "*conn1", "*conn2"},
+		utils.ThresholdSConnsCfg: []string{rpcclient.InternalRPC, "*conn1", "*conn2"},
+		utils.DMRTemplateCfg:     "*dmr",
+		utils.CoATemplateCfg:     "*coa",
+		utils.EAPServerCfg: map[string]any{
+			utils.AddressCfg:   "127.0.0.1:18120",
+			utils.TransportCfg: utils.UDP,
+			utils.SecretCfg:    "CGRateS.org",
+		},
 		utils.RequestsCacheKeyCfg: "~*req.Acc-Session-Id",
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
@@ -279,6 +286,7 @@ func TestRadiusAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ThresholdSConnsCfg:   []string{},
 		utils.DMRTemplateCfg:       "*dmr",
 		utils.CoATemplateCfg:       "*coa",
+		utils.EAPServerCfg:         map[string]any{utils.AddressCfg: "", utils.TransportCfg: utils.UDP, utils.SecretCfg: ""},
 		utils.RequestsCacheKeyCfg:  "",
 		utils.RequestProcessorsCfg: []map[string]any{},
 	}
@@ -686,11 +686,12 @@ func (aConnCfg AsteriskConnCfg) Clone() *AsteriskConnCfg {
 
 // AsteriskAgentCfg the config section that describes the Asterisk Agent
 type AsteriskAgentCfg struct {
-	Enabled       bool
-	SessionSConns []string
-	CreateCDR     bool
-	RouteProfile  bool
-	AsteriskConns []*AsteriskConnCfg
+	Enabled           bool
+	SessionSConns     []string
+	CreateCDR         bool
+	RouteProfile      bool
+	LowBalanceAnnFile string
+	AsteriskConns     []*AsteriskConnCfg
 }
 
 func (aCfg *AsteriskAgentCfg) loadFromJSONCfg(jsnCfg *AsteriskAgentJsonCfg) (err error) {
@@ -717,6 +718,9 @@ func (aCfg *AsteriskAgentCfg) loadFromJSONCfg(jsnCfg *AsteriskAgentJsonCfg) (err
 	if jsnCfg.Route_profile != nil {
 		aCfg.RouteProfile = *jsnCfg.Route_profile
 	}
+	if jsnCfg.Low_balance_ann_file != nil {
+		aCfg.LowBalanceAnnFile = *jsnCfg.Low_balance_ann_file
+	}
 
 	if jsnCfg.Asterisk_conns != nil {
 		aCfg.AsteriskConns = make([]*AsteriskConnCfg, len(*jsnCfg.Asterisk_conns))
@@ -731,9 +735,10 @@ func (aCfg *AsteriskAgentCfg) loadFromJSONCfg(jsnCfg *AsteriskAgentJsonCfg) (err
 // AsMapInterface returns the config as a map[string]any
 func (aCfg *AsteriskAgentCfg) AsMapInterface() (initialMP map[string]any) {
 	initialMP = map[string]any{
-		utils.EnabledCfg:      aCfg.Enabled,
-		utils.CreateCDRCfg:    aCfg.CreateCDR,
-		utils.RouteProfileCfg: aCfg.RouteProfile,
+		utils.EnabledCfg:           aCfg.Enabled,
+		utils.CreateCDRCfg:         aCfg.CreateCDR,
+		utils.RouteProfileCfg:      aCfg.RouteProfile,
+		utils.LowBalanceAnnFileCfg: aCfg.LowBalanceAnnFile,
 	}
 	if aCfg.AsteriskConns != nil {
 		conns := make([]map[string]any, len(aCfg.AsteriskConns))
@@ -760,9 +765,10 @@ func (aCfg *AsteriskAgentCfg) AsMapInterface() (initialMP map[string]any) {
 // Clone returns a deep copy of AsteriskAgentCfg
 func (aCfg AsteriskAgentCfg) Clone() (cln *AsteriskAgentCfg) {
 	cln = &AsteriskAgentCfg{
-		Enabled:      aCfg.Enabled,
-		CreateCDR:    aCfg.CreateCDR,
-		RouteProfile: aCfg.RouteProfile,
+		Enabled:           aCfg.Enabled,
+		CreateCDR:         aCfg.CreateCDR,
+		RouteProfile:      aCfg.RouteProfile,
+		LowBalanceAnnFile: aCfg.LowBalanceAnnFile,
 	}
 	if aCfg.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(aCfg.SessionSConns))
@@ -757,10 +757,11 @@ func TestRemoteHostloadFromJsonCfg(t *testing.T) {
 
 func TestAsteriskAgentCfgloadFromJsonCfg(t *testing.T) {
 	cfgJSON := &AsteriskAgentJsonCfg{
-		Enabled:        utils.BoolPointer(true),
-		Sessions_conns: &[]string{utils.MetaInternal},
-		Create_cdr:     utils.BoolPointer(true),
-		Route_profile:  utils.BoolPointer(true),
+		Enabled:              utils.BoolPointer(true),
+		Sessions_conns:       &[]string{utils.MetaInternal},
+		Create_cdr:           utils.BoolPointer(true),
+		Route_profile:        utils.BoolPointer(true),
+		Low_balance_ann_file: utils.StringPointer("sound:low-balance"),
 		Asterisk_conns: &[]*AstConnJsonCfg{
 			{
 				Alias:            utils.StringPointer("127.0.0.1:8448"),
@@ -773,10 +774,11 @@ func TestAsteriskAgentCfgloadFromJsonCfg(t *testing.T) {
 		},
 	}
 	expected := &AsteriskAgentCfg{
-		Enabled:       true,
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
-		CreateCDR:     true,
-		RouteProfile:  true,
+		Enabled:           true,
+		SessionSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		CreateCDR:         true,
+		RouteProfile:      true,
+		LowBalanceAnnFile: "sound:low-balance",
 		AsteriskConns: []*AsteriskConnCfg{{
 			Alias:           "127.0.0.1:8448",
 			Address:         "127.0.0.1:8088",
@@ -801,10 +803,11 @@ func TestAsteriskAgentCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]any{
-		utils.EnabledCfg:       false,
-		utils.SessionSConnsCfg: []string{utils.MetaInternal},
-		utils.CreateCdrCfg:     false,
-		utils.RouteProfileCfg:  false,
+		utils.EnabledCfg:           false,
+		utils.SessionSConnsCfg:     []string{utils.MetaInternal},
+		utils.CreateCdrCfg:         false,
+		utils.RouteProfileCfg:      false,
+		utils.LowBalanceAnnFileCfg: "",
 		utils.AsteriskConnsCfg: []map[string]any{
 			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8088", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 3, utils.ReconnectsCfg: 5, utils.MaxReconnectIntervalCfg: "0s", utils.AriWebSocketCfg: false},
 		},
@@ -823,16 +826,18 @@ func TestAsteriskAgentCfgAsMapInterface1(t *testing.T) {
 		"sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
 		"create_cdr": true,
 		"route_profile": true,
+		"low_balance_ann_file": "sound:low-balance",
 		"asterisk_conns":[
 			{"address": "127.0.0.1:8089","connect_attempts": 5,"reconnects": 8}
 		],
 	},
 }`
 	eMap := map[string]any{
-		utils.EnabledCfg:       true,
-		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
-		utils.CreateCdrCfg:     true,
-		utils.RouteProfileCfg:  true,
+		utils.EnabledCfg:           true,
+		utils.SessionSConnsCfg:     []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.CreateCdrCfg:         true,
+		utils.RouteProfileCfg:      true,
+		utils.LowBalanceAnnFileCfg: "sound:low-balance",
 		utils.AsteriskConnsCfg: []map[string]any{
 			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8089", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 5, utils.ReconnectsCfg: 8, utils.MaxReconnectIntervalCfg: "0s", utils.AriWebSocketCfg: false},
 		},
@@ -885,10 +890,11 @@ func TestAsteriskConnCfgloadFromJsonCfg(t *testing.T) {
 
 func TestAsteriskAgentCfgClone(t *testing.T) {
 	ban := &AsteriskAgentCfg{
-		Enabled:       true,
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
-		CreateCDR:     true,
-		RouteProfile:  true,
+		Enabled:           true,
+		SessionSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		CreateCDR:         true,
+		RouteProfile:      true,
+		LowBalanceAnnFile: "sound:low-balance",
 		AsteriskConns: []*AsteriskConnCfg{{
 			Alias:           "127.0.0.1:8448",
 			Address:         "127.0.0.1:8088",
@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package config
 
 import (
+	"maps"
 	"slices"
 	"time"
 
@@ -29,12 +30,13 @@ import (
 type SIPAgentCfg struct {
 	Enabled             bool
 	Listen              string
-	ListenNet           string // udp or tcp
+	ListenNet           string // udp, tcp, tcp-tls, ws or wss
 	SessionSConns       []string
 	StatSConns          []string
 	ThresholdSConns     []string
 	Timezone            string
-	RetransmissionTimer time.Duration // timeout replies if not reaching back
+	RetransmissionTimer time.Duration       // timeout replies if not reaching back
+	MethodProcessors    map[string][]string // request processor IDs used for the given SIP method
 	RequestProcessors   []*RequestProcessor
 }
 
@@ -75,6 +77,14 @@ func (sa *SIPAgentCfg) loadFromJSONCfg(jsnCfg *SIPAgentJsonCfg, sep string) (err
 			return err
 		}
 	}
+	if jsnCfg.MethodProcessors != nil {
+		if sa.MethodProcessors == nil {
+			sa.MethodProcessors = make(map[string][]string)
+		}
+		for method, procIDs := range *jsnCfg.MethodProcessors {
+			sa.MethodProcessors[method] = slices.Clone(procIDs)
+		}
+	}
 	if jsnCfg.RequestProcessors != nil {
 		for _, reqProcJsn := range *jsnCfg.RequestProcessors {
 			rp := new(RequestProcessor)
@@ -113,6 +123,11 @@ func (sa *SIPAgentCfg) AsMapInterface(separator string) map[string]any {
 		utils.RetransmissionTimerCfg: sa.RetransmissionTimer,
 		utils.RequestProcessorsCfg:   requestProcessors,
 	}
+	methodProcessors := make(map[string][]string, len(sa.MethodProcessors))
+	for method, procIDs := range sa.MethodProcessors {
+		methodProcessors[method] = slices.Clone(procIDs)
+	}
+	m[utils.MethodProcessorsCfg] = methodProcessors
 	if sa.SessionSConns != nil {
 		sessionSConns := make([]string, len(sa.SessionSConns))
 		for i, item := range sa.SessionSConns {
@@ -138,6 +153,12 @@ func (sa SIPAgentCfg) Clone() *SIPAgentCfg {
 		Timezone:            sa.Timezone,
 		RetransmissionTimer: sa.RetransmissionTimer,
 	}
+	if sa.MethodProcessors != nil {
+		clone.MethodProcessors = maps.Clone(sa.MethodProcessors)
+		for method, procIDs := range clone.MethodProcessors {
+			clone.MethodProcessors[method] = slices.Clone(procIDs)
+		}
+	}
 	if sa.RequestProcessors != nil {
 		clone.RequestProcessors = make([]*RequestProcessor, len(sa.RequestProcessors))
 		for i, rp := range sa.RequestProcessors {
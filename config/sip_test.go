@@ -35,6 +35,7 @@ func TestSIPAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ThresholdSConns:     &[]string{utils.MetaInternal},
 		Timezone:            utils.StringPointer("local"),
 		RetransmissionTimer: utils.StringPointer("1"),
+		MethodProcessors:    &map[string][]string{"MESSAGE": {"OutboundAUTHDryRun"}},
 		RequestProcessors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -62,6 +63,7 @@ func TestSIPAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
 		Timezone:            "local",
 		RetransmissionTimer: 1,
+		MethodProcessors:    map[string][]string{"MESSAGE": {"OutboundAUTHDryRun"}},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -171,6 +173,7 @@ func TestSIPAgentCfgAsMapInterface(t *testing.T) {
 		utils.ThresholdSConnsCfg:     []string{"*internal"},
 		utils.TimezoneCfg:            "",
 		utils.RetransmissionTimerCfg: 2 * time.Second,
+		utils.MethodProcessorsCfg:    map[string][]string{},
 		utils.RequestProcessorsCfg:   []map[string]any{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -225,6 +228,7 @@ func TestSIPAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ThresholdSConnsCfg:     []string{"*internal"},
 		utils.TimezoneCfg:            "UTC",
 		utils.RetransmissionTimerCfg: 5 * time.Second,
+		utils.MethodProcessorsCfg:    map[string][]string{},
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -283,6 +287,7 @@ func TestSIPAgentCfgAsMapInterface2(t *testing.T) {
 		utils.ThresholdSConnsCfg:     []string{"*conn1", "*conn2"},
 		utils.TimezoneCfg:            "",
 		utils.RetransmissionTimerCfg: time.Second,
+		utils.MethodProcessorsCfg:    map[string][]string{},
 		utils.RequestProcessorsCfg: []map[string]any{
 			{
 				utils.IDCfg:            "Register",
@@ -314,6 +319,7 @@ func TestSIPAgentCfgClone(t *testing.T) {
 		ThresholdSConns:     []string{},
 		Timezone:            "UTC",
 		RetransmissionTimer: 1,
+		MethodProcessors:    map[string][]string{"MESSAGE": {"OutboundAUTHDryRun"}},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -343,4 +349,7 @@ func TestSIPAgentCfgClone(t *testing.T) {
 	if rcv.SessionSConns[0] = ""; sa.SessionSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.MethodProcessors["MESSAGE"][0] = ""; sa.MethodProcessors["MESSAGE"][0] != "OutboundAUTHDryRun" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/dispatchers"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetDispatcherHostsHealth{
+		name:      "dispatchers_hosts_health",
+		rpcMethod: utils.DispatcherSv1GetHostsHealth,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdGetDispatcherHostsHealth struct {
+	name      string
+	rpcMethod string
+	rpcParams *dispatchers.ArgsGetHostsHealth
+	*CommandExecuter
+}
+
+func (self *CmdGetDispatcherHostsHealth) Name() string {
+	return self.name
+}
+
+func (self *CmdGetDispatcherHostsHealth) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetDispatcherHostsHealth) RpcParams(reset bool) any {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = new(dispatchers.ArgsGetHostsHealth)
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetDispatcherHostsHealth) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetDispatcherHostsHealth) RpcResult() any {
+	var s map[string]*dispatchers.DispatcherHostHealth
+	return &s
+}
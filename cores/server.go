@@ -21,15 +21,12 @@ package cores
 import (
 	"bytes"
 	"crypto/tls"
-	"crypto/x509"
-	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
 	"net/http/pprof"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -373,45 +370,6 @@ func (r *rpcRequest) Call() io.Reader {
 	return r.rw
 }
 
-func loadTLSConfig(serverCrt, serverKey, caCert string, serverPolicy int,
-	serverName string) (config *tls.Config, err error) {
-	cert, err := tls.LoadX509KeyPair(serverCrt, serverKey)
-	if err != nil {
-		utils.Logger.Crit(fmt.Sprintf("Error: %s when load server keys", err))
-		return nil, err
-	}
-
-	rootCAs, err := x509.SystemCertPool()
-	//This will only happen on windows
-	if err != nil {
-		utils.Logger.Crit(fmt.Sprintf("Error: %s when load SystemCertPool", err))
-		return nil, err
-	}
-
-	if caCert != "" {
-		ca, err := os.ReadFile(caCert)
-		if err != nil {
-			utils.Logger.Crit(fmt.Sprintf("Error: %s when read CA", err))
-			return config, err
-		}
-
-		if ok := rootCAs.AppendCertsFromPEM(ca); !ok {
-			utils.Logger.Crit("Cannot append certificate authority")
-			return config, errors.New("Cannot append certificate authority")
-		}
-	}
-
-	config = &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientAuth:   tls.ClientAuthType(serverPolicy),
-		ClientCAs:    rootCAs,
-	}
-	if serverName != "" {
-		config.ServerName = serverName
-	}
-	return
-}
-
 func (s *Server) serveCodecTLS(addr, codecName, serverCrt, serverKey, caCert string,
 	serverPolicy int, serverName string, newCodec func(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) birpc.ServerCodec,
 	shdChan *utils.SyncedChan) {
@@ -421,7 +379,7 @@ func (s *Server) serveCodecTLS(addr, codecName, serverCrt, serverKey, caCert str
 	if !enabled {
 		return
 	}
-	config, err := loadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
+	config, err := utils.LoadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
 	if err != nil {
 		shdChan.CloseOnce()
 		return
@@ -510,7 +468,7 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 	if useBasicAuth {
 		utils.Logger.Info("<HTTPS> enabling basic auth")
 	}
-	config, err := loadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
+	config, err := utils.LoadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
 	if err != nil {
 		shdChan.CloseOnce()
 		return
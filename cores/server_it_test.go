@@ -536,7 +536,7 @@ TEST
 	server.RpcRegister(new(mockRegister))
 
 	expectedErr := "Cannot append certificate authority"
-	if _, err := loadTLSConfig(
+	if _, err := utils.LoadTLSConfig(
 		"/usr/share/cgrates/tls/server.crt",
 		"/usr/share/cgrates/tls/server.key",
 		path.Join(flPath, "file.txt"),
@@ -546,7 +546,7 @@ TEST
 	}
 
 	expectedErr = "open /tmp/testLoadTLSConfigErr1/file1.txt: no such file or directory"
-	if _, err := loadTLSConfig(
+	if _, err := utils.LoadTLSConfig(
 		"/usr/share/cgrates/tls/server.crt",
 		"/usr/share/cgrates/tls/server.key",
 		path.Join(flPath, "file1.txt"),
@@ -257,6 +257,9 @@ CREATE TABLE tp_resources (
   "stored" BOOLEAN NOT NULL,
   "weight" NUMERIC(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "rate_interval" varchar(32) NOT NULL DEFAULT '',
+  "burst" varchar(64) NOT NULL DEFAULT '',
+  "parent_id" varchar(64) NOT NULL DEFAULT '',
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_resources_idx ON tp_resources (tpid);
@@ -308,6 +311,7 @@ CREATE TABLE tp_stats (
   "blocker" BOOLEAN NOT NULL,
   "weight" decimal(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "bucket_interval" varchar(32) NOT NULL DEFAULT '',
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -0,0 +1,13 @@
+--
+-- Upgrades the tariff plan tables of an existing database
+--
+
+USE `cgrates`;
+
+ALTER TABLE `tp_resources`
+	ADD COLUMN `rate_interval` varchar(32) NOT NULL DEFAULT '' AFTER `threshold_ids`,
+	ADD COLUMN `burst` varchar(64) NOT NULL DEFAULT '' AFTER `rate_interval`,
+	ADD COLUMN `parent_id` varchar(64) NOT NULL DEFAULT '' AFTER `burst`;
+
+ALTER TABLE `tp_stats`
+	ADD COLUMN `bucket_interval` varchar(32) NOT NULL DEFAULT '' AFTER `threshold_ids`;
@@ -261,6 +261,9 @@ CREATE TABLE tp_resources (
   `stored` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `rate_interval` varchar(32) NOT NULL DEFAULT '',
+  `burst` varchar(64) NOT NULL DEFAULT '',
+  `parent_id` varchar(64) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -312,6 +315,7 @@ CREATE TABLE tp_stats (
   `blocker` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `bucket_interval` varchar(32) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
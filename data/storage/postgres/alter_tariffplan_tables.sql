@@ -0,0 +1,11 @@
+--
+-- Upgrades the tariff plan tables of an existing database
+--
+
+ALTER TABLE tp_resources
+  ADD COLUMN IF NOT EXISTS "rate_interval" varchar(32) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS "burst" varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS "parent_id" varchar(64) NOT NULL DEFAULT '';
+
+ALTER TABLE tp_stats
+  ADD COLUMN IF NOT EXISTS "bucket_interval" varchar(32) NOT NULL DEFAULT '';
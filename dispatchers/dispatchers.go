@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/birpc/context"
@@ -53,14 +54,96 @@ type DispatcherService struct {
 	cfg     *config.CGRConfig
 	fltrS   *engine.FilterS
 	connMgr *engine.ConnManager
+
+	healthMux        sync.Mutex    // protects stopHealthChecks
+	stopHealthChecks chan struct{} // stops the health check loop
 }
 
 // Shutdown is called to shutdown the service
 func (dS *DispatcherService) Shutdown() {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.DispatcherS))
+	dS.healthMux.Lock()
+	if dS.stopHealthChecks != nil {
+		close(dS.stopHealthChecks)
+		dS.stopHealthChecks = nil
+	}
+	dS.healthMux.Unlock()
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.DispatcherS))
 }
 
+// StartHealthChecks starts probing the DispatcherHosts periodically
+// if health_check_interval is configured
+func (dS *DispatcherService) StartHealthChecks() {
+	interval := dS.cfg.DispatcherSCfg().HealthCheckInterval
+	dS.healthMux.Lock()
+	defer dS.healthMux.Unlock()
+	if interval <= 0 || dS.stopHealthChecks != nil {
+		return
+	}
+	dS.stopHealthChecks = make(chan struct{})
+	go dS.runHealthChecks(interval, dS.stopHealthChecks)
+}
+
+// runHealthChecks probes all DispatcherHosts every interval until stopped
+func (dS *DispatcherService) runHealthChecks(interval time.Duration, stop chan struct{}) {
+	for {
+		dS.checkHostsHealth()
+		select {
+		case <-stop:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// checkHostsHealth probes all the DispatcherHosts in parallel, updating
+// their circuit breaker with the result
+func (dS *DispatcherService) checkHostsHealth() {
+	keys, err := dS.dm.DataDB().GetKeysForPrefix(utils.DispatcherHostPrefix, utils.EmptyString)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed listing hosts for health checks: %s",
+			utils.DispatcherS, err.Error()))
+		return
+	}
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		tnt, hostID, has := strings.Cut(key[len(utils.DispatcherHostPrefix):], utils.ConcatenatedKeySep)
+		if !has {
+			continue
+		}
+		var dH *engine.DispatcherHost
+		if dH, err = dS.dm.GetDispatcherHost(tnt, hostID, true, true, utils.NonTransactional); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving host <%s:%s> for health checks: %s",
+				utils.DispatcherS, tnt, hostID, err.Error()))
+			continue
+		}
+		wg.Add(1)
+		go func(dH *engine.DispatcherHost) {
+			dS.checkHostHealth(dH)
+			wg.Done()
+		}(dH)
+	}
+	wg.Wait()
+}
+
+// checkHostHealth sends one probe to the host
+func (dS *DispatcherService) checkHostHealth(dH *engine.DispatcherHost) {
+	hs := dspHostsStats.get(dH.TenantID())
+	var reply string
+	err := dH.Call(context.Background(), dS.cfg.DispatcherSCfg().HealthCheckMethod,
+		&utils.CGREvent{
+			Tenant:  dH.Tenant,
+			ID:      utils.GenUUID(),
+			APIOpts: map[string]any{utils.MetaSubsys: utils.MetaDispatchers},
+		}, &reply)
+	if err != nil && !rpcclient.ShouldFailover(err) {
+		// the host answered, only network errors mark it down
+		utils.Logger.Warning(fmt.Sprintf("<%s> health check of host <%s> returned error: %s",
+			utils.DispatcherS, dH.TenantID(), err.Error()))
+	}
+	hs.probeResult(err)
+}
+
 func (dS *DispatcherService) authorizeEvent(ev *utils.CGREvent,
 	reply *engine.AttrSProcessEventReply) (err error) {
 	ev.APIOpts[utils.OptsContext] = utils.MetaAuth
@@ -457,6 +540,43 @@ func (dS *DispatcherService) Call(ctx *context.Context, serviceMethod string, //
 	return err
 }
 
+// ArgsGetHostsHealth are the arguments of DispatcherSv1.GetHostsHealth
+type ArgsGetHostsHealth struct {
+	Tenant  string
+	HostIDs []string // all the hosts of the tenant if empty
+	APIOpts map[string]any
+}
+
+// DispatcherSv1GetHostsHealth returns the health of the DispatcherHosts as seen by this node
+func (dS *DispatcherService) DispatcherSv1GetHostsHealth(ctx *context.Context, args *ArgsGetHostsHealth,
+	reply *map[string]*DispatcherHostHealth) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	hostIDs := args.HostIDs
+	if len(hostIDs) == 0 {
+		prfx := utils.DispatcherHostPrefix + tnt + utils.ConcatenatedKeySep
+		var keys []string
+		if keys, err = dS.dm.DataDB().GetKeysForPrefix(prfx, utils.EmptyString); err != nil {
+			return
+		}
+		hostIDs = make([]string, len(keys))
+		for i, key := range keys {
+			hostIDs[i] = key[len(prfx):]
+		}
+	}
+	if len(hostIDs) == 0 {
+		return utils.ErrNotFound
+	}
+	hostsHealth := make(map[string]*DispatcherHostHealth, len(hostIDs))
+	for _, hostID := range hostIDs {
+		hostsHealth[hostID] = dspHostsStats.get(utils.ConcatenatedKey(tnt, hostID)).health()
+	}
+	*reply = hostsHealth
+	return
+}
+
 func (dS *DispatcherService) DispatcherSv1RemoteStatus(ctx *context.Context, args *cores.V1StatusParams,
 	reply *map[string]any) (err error) {
 	tnt := dS.cfg.GeneralCfg().DefaultTenant
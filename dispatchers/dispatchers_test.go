@@ -20,6 +20,7 @@ package dispatchers
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -146,6 +147,33 @@ func TestDispatcherCall2(t *testing.T) {
 	dS.Shutdown()
 }
 
+func TestDispatcherHealthChecksStartShutdown(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().HealthCheckInterval = time.Hour
+	dataDB, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dS := NewDispatcherService(engine.NewDataManager(dataDB, nil, nil), cfg, nil, nil)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			dS.StartHealthChecks()
+		}()
+		go func() {
+			defer wg.Done()
+			dS.Shutdown()
+		}()
+	}
+	wg.Wait()
+	dS.Shutdown()
+	if dS.stopHealthChecks != nil {
+		t.Error("Expected the health checks to be stopped")
+	}
+}
+
 func TestDispatcherauthorizeEvent(t *testing.T) {
 	dm := &engine.DataManager{}
 	cfg := config.NewDefaultCGRConfig()
@@ -1845,3 +1873,51 @@ func TestDispatcherServiceDispatcherProfilesForEventBoolOptsErr(t *testing.T) {
 	}
 
 }
+
+func TestDispatcherSv1GetHostsHealth(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB, derr := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if derr != nil {
+		t.Fatal(derr)
+	}
+	dm := engine.NewDataManager(dataDB, nil, nil)
+	dsp := NewDispatcherService(dm, cfg, nil, nil)
+	var reply map[string]*DispatcherHostHealth
+	if err := dsp.DispatcherSv1GetHostsHealth(context.Background(),
+		&ArgsGetHostsHealth{Tenant: "cgrates.org_health"}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	for _, hostID := range []string{"HOST1", "HOST2"} {
+		if err := dm.SetDispatcherHost(&engine.DispatcherHost{
+			Tenant: "cgrates.org_health",
+			RemoteHost: &config.RemoteHost{
+				ID:      hostID,
+				Address: "127.0.0.1:2012",
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hs := dspHostsStats.get(utils.ConcatenatedKey("cgrates.org_health", "HOST2"))
+	hs.callStarted()
+	hs.callEnded(time.Millisecond, nil)
+	exp := map[string]*DispatcherHostHealth{
+		"HOST1": {Status: utils.MetaUp},
+		"HOST2": {Status: utils.MetaUp, Latency: time.Millisecond},
+	}
+	if err := dsp.DispatcherSv1GetHostsHealth(context.Background(),
+		&ArgsGetHostsHealth{Tenant: "cgrates.org_health"}, &reply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	exp = map[string]*DispatcherHostHealth{
+		"HOST2": {Status: utils.MetaUp, Latency: time.Millisecond},
+	}
+	if err := dsp.DispatcherSv1GetHostsHealth(context.Background(),
+		&ArgsGetHostsHealth{Tenant: "cgrates.org_health", HostIDs: []string{"HOST2"}}, &reply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+}
@@ -19,11 +19,16 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"cmp"
 	"encoding/gob"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
+	"slices"
 	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cgrates/birpc/context"
@@ -95,6 +100,16 @@ func newDispatcher(pfl *engine.DispatcherProfile) (d Dispatcher, err error) {
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(randomSort))
 	case utils.MetaRoundRobin:
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(roundRobinSort))
+	case utils.MetaLeastLoad:
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(leastLoadSort))
+	case utils.MetaLeastLatency:
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(leastLatencySort))
+	case utils.MetaHash:
+		var sorter *hashSort
+		if sorter, err = newHashSort(hosts, pfl.StrategyParams); err != nil {
+			return
+		}
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), sorter)
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -117,6 +132,9 @@ func getDispatcherHosts(fltrs *engine.FilterS, ev utils.DataProvider, tnt string
 			return
 		}
 		if pass {
+			if !dspHostsStats.get(utils.ConcatenatedKey(tnt, host.ID)).isAvailable() {
+				continue // circuit open, skip the host
+			}
 			hostIDs = append(hostIDs, host.ID)
 			if host.Blocker {
 				break
@@ -169,6 +187,329 @@ func (rs *roundRobinSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt
 	return getDispatcherHosts(fltrs, ev, tnt, dh)
 }
 
+// leastLoadSort will sort the matching hosts for the event by the number of
+// requests currently in flight towards them
+type leastLoadSort struct{}
+
+func (leastLoadSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	return getDispatcherHosts(fltrs, ev, tnt,
+		sortHostsByStats(tnt, hosts, func(hs *hostStats) int64 { return hs.inFlight.Load() }))
+}
+
+// leastLatencySort will sort the matching hosts for the event by their
+// average response time, hosts without measurements being tried first
+type leastLatencySort struct{}
+
+func (leastLatencySort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	return getDispatcherHosts(fltrs, ev, tnt,
+		sortHostsByStats(tnt, hosts, func(hs *hostStats) int64 { return int64(hs.getLatency()) }))
+}
+
+// sortHostsByStats returns a copy of hosts ordered ascending by the given
+// metric, keeping the weight order for equal values. Hosts whose last call
+// failed are moved to the end so they are only used as fallback.
+func sortHostsByStats(tnt string, hosts engine.DispatcherHostProfiles,
+	metric func(*hostStats) int64) engine.DispatcherHostProfiles {
+	type hostMetric struct {
+		failed bool
+		value  int64
+	}
+	metrics := make(map[string]hostMetric, len(hosts))
+	for _, host := range hosts {
+		hs := dspHostsStats.get(utils.ConcatenatedKey(tnt, host.ID))
+		metrics[host.ID] = hostMetric{
+			failed: hs.failed.Load(),
+			value:  metric(hs),
+		}
+	}
+	sorted := slices.Clone(hosts)
+	slices.SortStableFunc(sorted, func(a, b *engine.DispatcherHostProfile) int {
+		ma, mb := metrics[a.ID], metrics[b.ID]
+		if ma.failed != mb.failed {
+			if ma.failed {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(ma.value, mb.value)
+	})
+	return sorted
+}
+
+// defaultHashKey is the template building the *hash strategy key when
+// *hash_key is missing from StrategyParams
+const defaultHashKey = "~*req.Account"
+
+// defaultVirtualNodes is the number of points each host receives on the
+// *hash ring when *virtual_nodes is missing from StrategyParams
+const defaultVirtualNodes = 160
+
+// hashRingNode is one point of a host on the consistent hashing ring
+type hashRingNode struct {
+	hash   uint64
+	hostID string
+}
+
+// hashSort will sort the matching hosts for the event by walking a
+// consistent hashing ring from the point of the key built out of the event,
+// so the same key lands on the same host while the host is available and
+// only the keys of a removed host move to other hosts
+type hashSort struct {
+	keyTpl config.RSRParsers
+	ring   []hashRingNode // sorted by hash
+}
+
+// newHashSort builds the ring out of the profile hosts
+func newHashSort(hosts engine.DispatcherHostProfiles, params map[string]any) (hs *hashSort, err error) {
+	keyTpl := defaultHashKey
+	if tpl, has := params[utils.MetaHashKey]; has {
+		keyTpl = utils.IfaceAsString(tpl)
+	}
+	vNodes := int64(defaultVirtualNodes)
+	if nodes, has := params[utils.MetaVirtualNodes]; has {
+		if vNodes, err = utils.IfaceAsTInt64(nodes); err != nil {
+			return
+		}
+		if vNodes <= 0 {
+			return nil, fmt.Errorf("invalid %s: <%d>", utils.MetaVirtualNodes, vNodes)
+		}
+	}
+	hs = &hashSort{
+		ring: make([]hashRingNode, 0, len(hosts)*int(vNodes)),
+	}
+	if hs.keyTpl, err = config.NewRSRParsers(keyTpl,
+		config.CgrConfig().GeneralCfg().RSRSep); err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		for i := range vNodes {
+			hs.ring = append(hs.ring, hashRingNode{
+				hash:   hashKey(utils.ConcatenatedKey(host.ID, strconv.FormatInt(i, 10))),
+				hostID: host.ID,
+			})
+		}
+	}
+	slices.SortFunc(hs.ring, func(a, b hashRingNode) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), cmp.Compare(a.hostID, b.hostID))
+	})
+	return
+}
+
+// hashKey returns the position of the key on the ring
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+func (hs *hashSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	var key string
+	if key, err = hs.keyTpl.ParseDataProvider(ev); err != nil &&
+		err != utils.ErrNotFound {
+		return
+	}
+	if key == utils.EmptyString || len(hs.ring) == 0 { // no key to hash on, fallback on weights
+		return getDispatcherHosts(fltrs, ev, tnt, hosts)
+	}
+	available := make(map[string]*engine.DispatcherHostProfile, len(hosts))
+	for _, host := range hosts {
+		available[host.ID] = host
+	}
+	start, _ := slices.BinarySearchFunc(hs.ring, hashKey(key), func(n hashRingNode, h uint64) int {
+		return cmp.Compare(n.hash, h)
+	})
+	sorted := make(engine.DispatcherHostProfiles, 0, len(hosts))
+	for i := range hs.ring {
+		node := hs.ring[(start+i)%len(hs.ring)]
+		host, has := available[node.hostID]
+		if !has {
+			continue
+		}
+		sorted = append(sorted, host)
+		delete(available, node.hostID) // only the first point of a host counts
+		if len(available) == 0 {
+			break
+		}
+	}
+	return getDispatcherHosts(fltrs, ev, tnt, sorted)
+}
+
+// latencyEWMAAlpha is the weight given to the latest response time when
+// updating the average latency of a host
+const latencyEWMAAlpha = 0.3
+
+// hostStats holds the live metrics of a DispatcherHost as seen by this node
+type hostStats struct {
+	inFlight atomic.Int64 // requests sent and not yet answered
+	failed   atomic.Bool  // last request failed with a network error
+	mutex    sync.RWMutex
+	latency  time.Duration // exponentially weighted moving average of response times
+
+	failures      int       // consecutive network failures
+	downSince     time.Time // when the circuit breaker last opened
+	trialInFlight bool      // a request is probing the host in *half_open state
+	lastError     string
+}
+
+// callStarted marks a new request in flight, returning false if the host
+// is in *half_open state with a trial request already in flight
+func (hs *hostStats) callStarted() bool {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+	if hs.statusLocked() == utils.MetaHalfOpen {
+		if hs.trialInFlight {
+			return false
+		}
+		hs.trialInFlight = true
+	}
+	hs.inFlight.Add(1)
+	return true
+}
+
+// callEnded records the outcome of a request started with callStarted
+func (hs *hostStats) callEnded(took time.Duration, err error) {
+	hs.inFlight.Add(-1)
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+	if !hs.updateBreakerLocked(err, breakerThreshold()) {
+		return
+	}
+	if hs.latency == 0 {
+		hs.latency = took
+	} else {
+		hs.latency = time.Duration(latencyEWMAAlpha*float64(took) +
+			(1-latencyEWMAAlpha)*float64(hs.latency))
+	}
+}
+
+// probeResult records the outcome of a health check on the circuit breaker only,
+// the probes are not counted as requests and a single failed one opens the circuit
+func (hs *hostStats) probeResult(err error) {
+	hs.mutex.Lock()
+	hs.updateBreakerLocked(err, 1)
+	hs.mutex.Unlock()
+}
+
+// updateBreakerLocked updates the circuit breaker with the outcome of a request,
+// opening it after thld consecutive failures (0 to never open it) and
+// returning false on network errors
+func (hs *hostStats) updateBreakerLocked(err error, thld int) bool {
+	hs.trialInFlight = false
+	if err != nil && rpcclient.ShouldFailover(err) {
+		hs.failed.Store(true)
+		hs.failures++
+		hs.lastError = err.Error()
+		if thld != 0 && hs.failures >= thld {
+			hs.downSince = time.Now() // (re)open the circuit
+		}
+		return false
+	}
+	hs.failed.Store(false)
+	hs.failures = 0
+	hs.downSince = time.Time{}
+	return true
+}
+
+// getLatency returns the average response time of the host
+func (hs *hostStats) getLatency() time.Duration {
+	hs.mutex.RLock()
+	defer hs.mutex.RUnlock()
+	return hs.latency
+}
+
+// status returns the circuit breaker state of the host
+func (hs *hostStats) status() string {
+	hs.mutex.RLock()
+	defer hs.mutex.RUnlock()
+	return hs.statusLocked()
+}
+
+func (hs *hostStats) statusLocked() string {
+	if hs.downSince.IsZero() {
+		return utils.MetaUp
+	}
+	if time.Since(hs.downSince) < config.CgrConfig().DispatcherSCfg().CircuitBreakerCooldown {
+		return utils.MetaDown
+	}
+	return utils.MetaHalfOpen // cooldown passed, let one request probe the host
+}
+
+// isAvailable returns false while the circuit breaker of the host is open
+// or while its *half_open trial request is in flight
+func (hs *hostStats) isAvailable() bool {
+	hs.mutex.RLock()
+	defer hs.mutex.RUnlock()
+	switch hs.statusLocked() {
+	case utils.MetaDown:
+		return false
+	case utils.MetaHalfOpen:
+		return !hs.trialInFlight
+	}
+	return true
+}
+
+// health returns a snapshot of the host state
+func (hs *hostStats) health() *DispatcherHostHealth {
+	hs.mutex.RLock()
+	defer hs.mutex.RUnlock()
+	hh := &DispatcherHostHealth{
+		Status:              hs.statusLocked(),
+		ConsecutiveFailures: hs.failures,
+		InFlight:            hs.inFlight.Load(),
+		Latency:             hs.latency,
+		LastError:           hs.lastError,
+	}
+	if !hs.downSince.IsZero() {
+		hh.DownSince = utils.TimePointer(hs.downSince)
+	}
+	return hh
+}
+
+// breakerThreshold returns the number of consecutive failed requests opening the
+// circuit of a host, 0 meaning the requests never open it
+func breakerThreshold() int {
+	if thld := config.CgrConfig().DispatcherSCfg().CircuitBreakerFailures; thld > 0 {
+		return thld
+	}
+	return 0
+}
+
+// DispatcherHostHealth is the state of a DispatcherHost as seen by this node
+type DispatcherHostHealth struct {
+	Status              string // *up, *down or *half_open
+	ConsecutiveFailures int
+	InFlight            int64
+	Latency             time.Duration
+	LastError           string
+	DownSince           *time.Time
+}
+
+// hostsStats indexes hostStats on host tenantID
+type hostsStats struct {
+	mutex sync.RWMutex
+	stats map[string]*hostStats
+}
+
+// dspHostsStats keeps the metrics of all DispatcherHosts used by this node
+var dspHostsStats = &hostsStats{stats: make(map[string]*hostStats)}
+
+// get returns the metrics of the host, creating them on first use
+func (hss *hostsStats) get(tntID string) (hs *hostStats) {
+	hss.mutex.RLock()
+	hs, has := hss.stats[tntID]
+	hss.mutex.RUnlock()
+	if has {
+		return
+	}
+	hss.mutex.Lock()
+	defer hss.mutex.Unlock()
+	if hs, has = hss.stats[tntID]; !has {
+		hs = new(hostStats)
+		hss.stats[tntID] = hs
+	}
+	return
+}
+
 // newSingleDispatcher is the constructor for singleDispatcher struct
 func newSingleDispatcher(hosts engine.DispatcherHostProfiles, params map[string]any, tntID string, sorter hostSorter) (_ Dispatcher, err error) {
 	if dflt, has := params[utils.MetaDefaultRatio]; has {
@@ -209,9 +550,15 @@ type singleResultDispatcher struct {
 func (sd *singleResultDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
 	ev utils.DataProvider, tnt, routeID string, dR *DispatcherRoute,
 	serviceMethod string, args any, reply any) (err error) {
-	if dR != nil && dR.HostID != utils.EmptyString { // route to previously discovered route
-		return callDHwithID(tnt, dR.HostID, routeID, dR, dm,
-			serviceMethod, args, reply)
+	if dR != nil && dR.HostID != utils.EmptyString &&
+		dspHostsStats.get(utils.ConcatenatedKey(tnt, dR.HostID)).isAvailable() { // route to previously discovered route
+		if err = callDHwithID(tnt, dR.HostID, routeID, dR, dm,
+			serviceMethod, args, reply); err != utils.ErrDSPHostNotFound {
+			return
+		}
+		// host gone or its *half_open trial taken by another request, continue with standard dispatching
+		utils.Logger.Warning(fmt.Sprintf("<%s> error <%s> dispatching to host with identity <%q>",
+			utils.DispatcherS, err.Error(), dR.HostID))
 	}
 	var hostIDs []string
 	if hostIDs, err = sd.sorter.Sort(flts, ev, tnt, sd.hosts); err != nil {
@@ -309,7 +656,8 @@ func (ld *loadDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
 	} else if lM, err = newLoadMetrics(ld.hosts, ld.defaultRatio); err != nil {
 		return
 	}
-	if dR != nil && dR.HostID != utils.EmptyString { // route to previously discovered route
+	if dR != nil && dR.HostID != utils.EmptyString &&
+		dspHostsStats.get(utils.ConcatenatedKey(tnt, dR.HostID)).isAvailable() { // route to previously discovered route
 		lM.incrementLoad(dR.HostID, ld.tntID)
 		err = callDHwithID(tnt, dR.HostID, routeID, dR, dm,
 			serviceMethod, args, reply)
@@ -460,9 +808,13 @@ func callDH(dh *engine.DispatcherHost, routeID string, dR *DispatcherRoute,
 				utils.DispatcherS, err.Error(), dR))
 		}
 	}
-	if err = dh.Call(context.TODO(), method, args, reply); err != nil {
-		return
+	hs := dspHostsStats.get(dh.TenantID())
+	if !hs.callStarted() { // another request is already probing the host
+		return utils.ErrDSPHostNotFound
 	}
+	start := time.Now()
+	err = dh.Call(context.TODO(), method, args, reply)
+	hs.callEnded(time.Since(start), err)
 	return
 }
 
@@ -21,6 +21,8 @@ package dispatchers
 import (
 	"net/rpc"
 	"reflect"
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
@@ -879,6 +881,38 @@ func TestLibDispatcherSingleResultDispatcherCase2(t *testing.T) {
 	engine.IntRPC = tmp
 }
 
+func TestLibDispatcherSingleResultDispatcherCachedRouteNotFound(t *testing.T) {
+	cacheInit := engine.Cache
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(nil, nil, nil)
+	engine.Cache = engine.NewCacheS(cfg, dm, nil)
+	value := &engine.DispatcherHost{
+		Tenant: "testTenant",
+		RemoteHost: &config.RemoteHost{
+			ID:        "testID",
+			Address:   rpcclient.InternalRPC,
+			Transport: utils.MetaInternal,
+		},
+	}
+	tmp := engine.IntRPC
+	engine.IntRPC = map[string]*rpcclient.RPCClient{}
+	chanRPC := make(chan birpc.ClientConnector, 1)
+	chanRPC <- new(mockTypeConDispatch2)
+	engine.IntRPC.AddInternalRPCClient(utils.AttributeSv1, chanRPC)
+	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:testID",
+		value, nil, true, utils.NonTransactional)
+	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:goneID",
+		nil, nil, true, utils.NonTransactional)
+	wgDsp := &singleResultDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
+	// the cached route is not usable, the sorted hosts are tried instead
+	if err := wgDsp.Dispatch(dm, nil, nil, "testTenant", utils.EmptyString,
+		&DispatcherRoute{HostID: "goneID"}, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp); err != nil {
+		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", nil, err)
+	}
+	engine.Cache = cacheInit
+	engine.IntRPC = tmp
+}
+
 func TestLibDispatcherSingleResultDispatcherCase3(t *testing.T) {
 	cacheInit := engine.Cache
 	cfg := config.NewDefaultCGRConfig()
@@ -1046,3 +1080,326 @@ func TestLibDispatcherNewInternalHost(t *testing.T) {
 		t.Errorf("newInternalHost(%q) returned an unexpected value(-want +got): \n%s", tnt, diff)
 	}
 }
+
+func TestLibDispatcherNewDispatcherLeastLoadLatency(t *testing.T) {
+	for strategy, expSorter := range map[string]hostSorter{
+		utils.MetaLeastLoad:    new(leastLoadSort),
+		utils.MetaLeastLatency: new(leastLatencySort),
+	} {
+		result, err := newDispatcher(&engine.DispatcherProfile{
+			Hosts:    engine.DispatcherHostProfiles{},
+			Strategy: strategy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result.(*singleResultDispatcher).sorter, expSorter) {
+			t.Errorf("Expected: %T, received: %T", expSorter, result.(*singleResultDispatcher).sorter)
+		}
+	}
+}
+
+func TestLibDispatcherLeastLoadSort(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	tnt := "cgrates.org_leastload"
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1"},
+		{ID: "testID2"},
+		{ID: "testID3"},
+	}
+	dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID1")).callStarted()
+	dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID1")).callStarted()
+	dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID2")).callStarted()
+
+	exp := engine.DispatcherHostIDs{"testID3", "testID2", "testID1"}
+	if hostIDs, err := new(leastLoadSort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+	if hosts[0].ID != "testID1" {
+		t.Errorf("Expected the original hosts order to be kept, received: %q", hosts.HostIDs())
+	}
+
+	dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID1")).callEnded(time.Millisecond, nil)
+	dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID1")).callEnded(time.Millisecond, nil)
+	exp = engine.DispatcherHostIDs{"testID1", "testID3", "testID2"}
+	if hostIDs, err := new(leastLoadSort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+}
+
+func TestLibDispatcherLeastLatencySort(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	tnt := "cgrates.org_leastlatency"
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1"},
+		{ID: "testID2"},
+		{ID: "testID3"},
+	}
+	for hostID, took := range map[string]time.Duration{
+		"testID1": 30 * time.Millisecond,
+		"testID2": 10 * time.Millisecond,
+		"testID3": 20 * time.Millisecond,
+	} {
+		hs := dspHostsStats.get(utils.ConcatenatedKey(tnt, hostID))
+		hs.callStarted()
+		hs.callEnded(took, nil)
+	}
+	exp := engine.DispatcherHostIDs{"testID2", "testID3", "testID1"}
+	if hostIDs, err := new(leastLatencySort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+
+	// the fastest host failing is moved last until it answers again
+	hs := dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID2"))
+	hs.callStarted()
+	hs.callEnded(time.Second, utils.ErrDisconnected)
+	exp = engine.DispatcherHostIDs{"testID3", "testID1", "testID2"}
+	if hostIDs, err := new(leastLatencySort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+	if latency := hs.getLatency(); latency != 10*time.Millisecond {
+		t.Errorf("Expected failed calls not to update latency, received: %v", latency)
+	}
+
+	// the average moves towards the latest response times
+	hs.callStarted()
+	hs.callEnded(110*time.Millisecond, nil)
+	if latency := hs.getLatency(); latency != 40*time.Millisecond {
+		t.Errorf("Expected latency 40ms, received: %v", latency)
+	}
+}
+
+func TestLibDispatcherNewDispatcherHash(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "testID1"},
+			{ID: "testID2"},
+		},
+		Strategy: utils.MetaHash,
+		StrategyParams: map[string]any{
+			utils.MetaVirtualNodes: 10,
+		},
+	}
+	result, err := newDispatcher(pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorter, canCast := result.(*singleResultDispatcher).sorter.(*hashSort)
+	if !canCast {
+		t.Fatalf("Expected *hashSort, received: %T", result.(*singleResultDispatcher).sorter)
+	}
+	if len(sorter.ring) != 20 {
+		t.Errorf("Expected 20 ring nodes, received: %d", len(sorter.ring))
+	}
+	pfl.StrategyParams[utils.MetaVirtualNodes] = 0
+	if _, err = newDispatcher(pfl); err == nil {
+		t.Error("Expected error for invalid virtual nodes")
+	}
+	pfl.StrategyParams = map[string]any{utils.MetaHashKey: "~*req.Account{*"}
+	if _, err = newDispatcher(pfl); err == nil {
+		t.Error("Expected error for invalid hash key template")
+	}
+}
+
+func TestLibDispatcherHashSort(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1"},
+		{ID: "testID2"},
+		{ID: "testID3"},
+		{ID: "testID4"},
+	}
+	sorter, err := newHashSort(hosts, map[string]any{
+		utils.MetaHashKey: "~*req.Account;:;~*req.OriginID",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstHosts := make(map[string]string)
+	for i := range 100 {
+		ev := utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.AccountField: strconv.Itoa(i), utils.OriginID: "origin"},
+		}
+		hostIDs, err := sorter.Sort(flts, ev, "cgrates.org", hosts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hostIDs) != len(hosts) {
+			t.Fatalf("Expected all hosts as failover, received: %q", hostIDs)
+		}
+		if again, _ := sorter.Sort(flts, ev, "cgrates.org", hosts); !reflect.DeepEqual(hostIDs, again) {
+			t.Errorf("Expected the same order for the same key, received: %q and %q", hostIDs, again)
+		}
+		firstHosts[strconv.Itoa(i)] = hostIDs[0]
+
+		// removing a host only moves its own keys, to their next ring node
+		remaining := engine.DispatcherHostProfiles{hosts[0], hosts[1], hosts[3]}
+		reduced, err := sorter.Sort(flts, ev, "cgrates.org", remaining)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := slices.DeleteFunc(slices.Clone(hostIDs), func(id string) bool { return id == "testID3" })
+		if !reflect.DeepEqual(engine.DispatcherHostIDs(exp), reduced) {
+			t.Errorf("Expected: %q, received: %q", exp, reduced)
+		}
+	}
+	used := make(map[string]bool)
+	for _, hostID := range firstHosts {
+		used[hostID] = true
+	}
+	if len(used) != len(hosts) {
+		t.Errorf("Expected keys spread over all hosts, received: %v", used)
+	}
+
+	// without key the weight order is kept
+	exp := engine.DispatcherHostIDs{"testID1", "testID2", "testID3", "testID4"}
+	if hostIDs, err := sorter.Sort(flts, utils.MapStorage{}, "cgrates.org", hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+}
+
+func TestLibDispatcherCircuitBreaker(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().CircuitBreakerFailures = 2
+	cfg.DispatcherSCfg().CircuitBreakerCooldown = 50 * time.Millisecond
+	config.SetCgrConfig(cfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+	flts := engine.NewFilterS(cfg, nil, nil)
+	tnt := "cgrates.org_breaker"
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1"},
+		{ID: "testID2"},
+	}
+	hs := dspHostsStats.get(utils.ConcatenatedKey(tnt, "testID1"))
+	hs.callStarted()
+	hs.callEnded(time.Millisecond, utils.ErrDisconnected)
+	if status := hs.status(); status != utils.MetaUp {
+		t.Errorf("Expected %q below the failures threshold, received: %q", utils.MetaUp, status)
+	}
+	hs.callStarted()
+	hs.callEnded(time.Millisecond, utils.ErrDisconnected)
+	if status := hs.status(); status != utils.MetaDown {
+		t.Errorf("Expected %q, received: %q", utils.MetaDown, status)
+	}
+	exp := engine.DispatcherHostIDs{"testID2"}
+	if hostIDs, err := new(noSort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+	if hh := hs.health(); hh.ConsecutiveFailures != 2 ||
+		hh.LastError != utils.ErrDisconnected.Error() || hh.DownSince == nil {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hh))
+	}
+
+	// after the cooldown the host is tried again
+	time.Sleep(60 * time.Millisecond)
+	if status := hs.status(); status != utils.MetaHalfOpen {
+		t.Errorf("Expected %q, received: %q", utils.MetaHalfOpen, status)
+	}
+	exp = engine.DispatcherHostIDs{"testID1", "testID2"}
+	if hostIDs, err := new(noSort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+
+	// only one trial request is let through
+	if !hs.callStarted() {
+		t.Error("Expected the trial request to be admitted")
+	}
+	if hs.callStarted() {
+		t.Error("Expected a second request to be rejected during the trial")
+	}
+	exp = engine.DispatcherHostIDs{"testID2"}
+	if hostIDs, err := new(noSort).Sort(flts, nil, tnt, hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+
+	// a failed trial opens the circuit again
+	hs.callEnded(time.Millisecond, utils.ErrDisconnected)
+	if status := hs.status(); status != utils.MetaDown {
+		t.Errorf("Expected %q, received: %q", utils.MetaDown, status)
+	}
+
+	// a successful one closes it
+	time.Sleep(60 * time.Millisecond)
+	hs.callStarted()
+	hs.callEnded(time.Millisecond, nil)
+	if hh := hs.health(); hh.Status != utils.MetaUp ||
+		hh.ConsecutiveFailures != 0 || hh.DownSince != nil {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hh))
+	}
+
+	// application errors do not count as failures
+	for range 3 {
+		hs.callStarted()
+		hs.callEnded(time.Millisecond, utils.ErrNotFound)
+	}
+	if status := hs.status(); status != utils.MetaUp {
+		t.Errorf("Expected %q, received: %q", utils.MetaUp, status)
+	}
+}
+
+func TestLibDispatcherProbeResult(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().HealthCheckInterval = time.Second
+	config.SetCgrConfig(cfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+	hs := dspHostsStats.get(utils.ConcatenatedKey("cgrates.org_probe", "testID1"))
+	hs.callStarted()
+	hs.callEnded(10*time.Millisecond, nil)
+
+	hs.probeResult(utils.ErrDisconnected)
+	if hh := hs.health(); hh.Status != utils.MetaDown || hh.ConsecutiveFailures != 1 ||
+		hh.InFlight != 0 || hh.Latency != 10*time.Millisecond {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hh))
+	}
+	hs.probeResult(nil)
+	if hh := hs.health(); hh.Status != utils.MetaUp {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hh))
+	}
+	// failed requests alone do not open the circuit without circuit_breaker_failures
+	hs.callStarted()
+	hs.callEnded(0, utils.ErrDisconnected)
+	if hh := hs.health(); hh.Status != utils.MetaUp || hh.ConsecutiveFailures != 1 {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hh))
+	}
+	hs.probeResult(nil)
+	if hh := hs.health(); hh.Status != utils.MetaUp || hh.ConsecutiveFailures != 0 ||
+		hh.InFlight != 0 || hh.Latency != 10*time.Millisecond {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hh))
+	}
+}
+
+func TestLibDispatcherBreakerThreshold(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+	if thld := breakerThreshold(); thld != 0 {
+		t.Errorf("Expected disabled circuit breaker, received: %d", thld)
+	}
+	cfg.DispatcherSCfg().HealthCheckInterval = time.Second
+	if thld := breakerThreshold(); thld != 0 {
+		t.Errorf("Expected the health checks not to change the threshold, received: %d", thld)
+	}
+	cfg.DispatcherSCfg().CircuitBreakerFailures = 5
+	if thld := breakerThreshold(); thld != 5 {
+		t.Errorf("Expected 5, received: %d", thld)
+	}
+}
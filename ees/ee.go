@@ -53,6 +53,8 @@ func NewEventExporter(cfg *config.EventExporterCfg, cgrCfg *config.CGRConfig, fi
 		return NewFileCSVee(cfg, cgrCfg, filterS, em)
 	case utils.MetaFileFWV:
 		return NewFileFWVee(cfg, cgrCfg, filterS, em)
+	case utils.MetaFileParquet:
+		return NewFileParquetEE(cfg, cgrCfg, em)
 	case utils.MetaHTTPPost:
 		return NewHTTPPostEE(cfg, cgrCfg, filterS, em)
 	case utils.MetaHTTPjsonMap:
@@ -60,6 +62,11 @@ func NewEventExporter(cfg *config.EventExporterCfg, cgrCfg *config.CGRConfig, fi
 	case utils.MetaNatsjsonMap:
 		return NewNatsEE(cfg, cgrCfg.GeneralCfg().NodeID,
 			cgrCfg.GeneralCfg().ConnectTimeout, em)
+	case utils.MetaMQTTjsonMap:
+		return NewMQTTee(cfg, cgrCfg.GeneralCfg().NodeID,
+			cgrCfg.GeneralCfg().ConnectTimeout, cgrCfg.GeneralCfg().ReplyTimeout, em)
+	case utils.MetaRedisStreams:
+		return NewRedisStreamsEE(cfg, cgrCfg.DataDbCfg(), em), nil
 	case utils.MetaAMQPjsonMap:
 		return NewAMQPee(cfg, em), nil
 	case utils.MetaAMQPV1jsonMap:
@@ -386,6 +386,8 @@ func ExportWithAttempts(exp EventExporter, eEv any, key string) (err error) {
 				break
 			}
 			evLog = utils.ToJSON(c.Body)
+		case *MQTTPosterRequest:
+			evLog = string(c.Body)
 		default:
 			evLog = utils.ToJSON(c)
 		}
@@ -55,11 +55,13 @@ func writeFailedPosts(_ string, value any) {
 func AddFailedPost(failedPostsDir, expPath, format string, attempts int, ev any,
 	opts *config.EventExporterOpts) {
 	key := utils.ConcatenatedKey(failedPostsDir, expPath, format)
-	// also in case of amqp,amqpv1,s3,sqs and kafka also separe them after queue id
+	// also in case of amqp,amqpv1,s3,sqs,kafka, mqtt and redis streams also separe them after queue id
 	var amqpQueueID string
 	var s3BucketID string
 	var sqsQueueID string
 	var kafkaTopic string
+	var mqttTopic string
+	var redisStream string
 
 	if amqpOpts := opts.AMQP; amqpOpts != nil {
 		if opts.AMQP.QueueID != nil {
@@ -80,8 +82,18 @@ func AddFailedPost(failedPostsDir, expPath, format string, attempts int, ev any,
 			kafkaTopic = *opts.Kafka.Topic
 		}
 	}
+	if mqttOpts := opts.MQTT; mqttOpts != nil {
+		if opts.MQTT.Topic != nil {
+			mqttTopic = *opts.MQTT.Topic
+		}
+	}
+	if redisOpts := opts.RedisStreams; redisOpts != nil {
+		if opts.RedisStreams.Stream != nil {
+			redisStream = *opts.RedisStreams.Stream
+		}
+	}
 	if qID := utils.FirstNonEmpty(amqpQueueID, s3BucketID, sqsQueueID,
-		kafkaTopic); len(qID) != 0 {
+		kafkaTopic, mqttTopic, redisStream); len(qID) != 0 {
 		key = utils.ConcatenatedKey(key, qID)
 	}
 	var failedPost *ExportEvents
@@ -0,0 +1,349 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+)
+
+// NewFileParquetEE creates a parquet file exporter
+func NewFileParquetEE(cfg *config.EventExporterCfg, cgrCfg *config.CGRConfig,
+	em *utils.ExporterMetrics) (pq *FileParquetEE, err error) {
+	pq = &FileParquetEE{
+		cfg:      cfg,
+		em:       em,
+		timezone: utils.FirstNonEmpty(cfg.Timezone, cgrCfg.GeneralCfg().DefaultTimezone),
+		colTypes: make(map[string]string),
+		scale:    utils.ParquetDefaultDecimalScale,
+		codec:    &parquet.Snappy,
+	}
+	if err = pq.parseOpts(cfg.Opts.Parquet); err != nil {
+		return nil, err
+	}
+	if cfg.Opts.Parquet.S3Endpoint != nil &&
+		*cfg.Opts.Parquet.S3Endpoint != utils.EmptyString {
+		s3Cfg := cfg.Clone()
+		s3Cfg.ExportPath = *cfg.Opts.Parquet.S3Endpoint
+		pq.s3 = NewS3EE(s3Cfg, em)
+	}
+	group := make(parquet.Group)
+	for _, fld := range cfg.ContentFields() {
+		fldPath := fld.GetPathSlice()
+		if fldPath[0] != utils.MetaExp || len(fldPath) < 2 {
+			continue
+		}
+		col := strings.Join(fldPath[1:], utils.NestingSep)
+		colType := utils.MetaString
+		if typ, has := cfg.Opts.Parquet.ColumnTypes[col]; has {
+			colType = typ
+		}
+		if group[col], err = parquetNode(colType, pq.scale); err != nil {
+			return nil, err
+		}
+		pq.colTypes[col] = colType
+	}
+	if len(group) == 0 {
+		return nil, fmt.Errorf("no %s fields defined in the template of exporter <%s>",
+			utils.MetaExp, cfg.ID)
+	}
+	pq.schema = parquet.NewSchema(cfg.ID, group)
+	for _, col := range pq.schema.Columns() {
+		pq.columns = append(pq.columns, strings.Join(col, utils.NestingSep))
+	}
+	return
+}
+
+// FileParquetEE implements EventExporter interface for .parquet files
+type FileParquetEE struct {
+	cfg      *config.EventExporterCfg
+	em       *utils.ExporterMetrics
+	timezone string
+	s3       *S3EE // uploads the finished files when configured
+
+	schema         *parquet.Schema
+	columns        []string          // column names in schema order
+	colTypes       map[string]string // column type for each column name
+	scale          int
+	codec          compress.Codec
+	maxRecords     int
+	maxFileSize    int
+	rotateInterval time.Duration
+
+	sync.Mutex  // protects the current file
+	file        *os.File
+	writer      *parquet.Writer
+	filePath    string
+	records     int
+	size        int
+	rotateTimer *time.Timer
+	uploads     sync.WaitGroup
+}
+
+func (pq *FileParquetEE) parseOpts(opts *config.ParquetOpts) error {
+	if opts.DecimalScale != nil {
+		pq.scale = *opts.DecimalScale
+	}
+	if opts.Compression != nil {
+		switch *opts.Compression {
+		case utils.MetaSnappy:
+			pq.codec = &parquet.Snappy
+		case utils.MetaGzip:
+			pq.codec = &parquet.Gzip
+		case utils.MetaZstd:
+			pq.codec = &parquet.Zstd
+		case utils.MetaNone:
+			pq.codec = &parquet.Uncompressed
+		default:
+			return fmt.Errorf("unsupported %s: <%s>", utils.ParquetCompression, *opts.Compression)
+		}
+	}
+	if opts.MaxRecords != nil {
+		pq.maxRecords = *opts.MaxRecords
+	}
+	if opts.MaxFileSize != nil {
+		pq.maxFileSize = *opts.MaxFileSize
+	}
+	if opts.RotateInterval != nil {
+		pq.rotateInterval = *opts.RotateInterval
+	}
+	return nil
+}
+
+// parquetNode returns the optional parquet column for the given column type
+func parquetNode(colType string, scale int) (parquet.Node, error) {
+	switch colType {
+	case utils.MetaString:
+		return parquet.Optional(parquet.String()), nil
+	case utils.MetaInt64, utils.MetaDuration:
+		return parquet.Optional(parquet.Int(64)), nil
+	case utils.MetaFloat64:
+		return parquet.Optional(parquet.Leaf(parquet.DoubleType)), nil
+	case utils.MetaBool:
+		return parquet.Optional(parquet.Leaf(parquet.BooleanType)), nil
+	case utils.MetaTimestamp:
+		return parquet.Optional(parquet.Timestamp(parquet.Microsecond)), nil
+	case utils.MetaDecimal:
+		return parquet.Optional(parquet.Decimal(scale, 18, parquet.Int64Type)), nil
+	default:
+		return nil, fmt.Errorf("unsupported parquet column type: <%s>", colType)
+	}
+}
+
+// parquetValue converts the exported value to the parquet value of the column
+// with the given index, empty values being written as null
+func (pq *FileParquetEE) parquetValue(colType string, val any, idx int) (v parquet.Value, err error) {
+	if val == nil || val == utils.EmptyString {
+		return parquet.Value{}.Level(0, 0, idx), nil
+	}
+	switch colType {
+	case utils.MetaInt64:
+		var i int64
+		if i, err = utils.IfaceAsTInt64(val); err != nil {
+			return
+		}
+		v = parquet.Int64Value(i)
+	case utils.MetaDuration:
+		var d time.Duration
+		if d, err = utils.IfaceAsDuration(val); err != nil {
+			return
+		}
+		v = parquet.Int64Value(d.Nanoseconds())
+	case utils.MetaFloat64:
+		var f float64
+		if f, err = utils.IfaceAsTFloat64(val); err != nil {
+			return
+		}
+		v = parquet.DoubleValue(f)
+	case utils.MetaBool:
+		var b bool
+		if b, err = utils.IfaceAsBool(val); err != nil {
+			return
+		}
+		v = parquet.BooleanValue(b)
+	case utils.MetaTimestamp:
+		var t time.Time
+		if t, err = utils.IfaceAsTime(val, pq.timezone); err != nil {
+			return
+		}
+		v = parquet.Int64Value(t.UnixMicro())
+	case utils.MetaDecimal:
+		var d *decimal.Big
+		if d, err = utils.IfaceAsBig(val); err != nil {
+			return
+		}
+		unscaled := new(decimal.Big).Mul(d, decimal.New(1, -pq.scale))
+		unscaled.Quantize(0)
+		i, ok := unscaled.Int64()
+		if !ok {
+			return v, fmt.Errorf("decimal value <%s> out of range", d)
+		}
+		v = parquet.Int64Value(i)
+	default:
+		v = parquet.ByteArrayValue([]byte(utils.IfaceAsString(val)))
+	}
+	return v.Level(0, 1, idx), nil
+}
+
+func (pq *FileParquetEE) Cfg() *config.EventExporterCfg { return pq.cfg }
+
+func (pq *FileParquetEE) Connect() (_ error) { return }
+
+func (pq *FileParquetEE) ExportEvent(ev any, _ string) (err error) {
+	rec := ev.(map[string]any)
+	row := make(parquet.Row, len(pq.columns))
+	var size int
+	for i, col := range pq.columns {
+		if row[i], err = pq.parquetValue(pq.colTypes[col], rec[col], i); err != nil {
+			return fmt.Errorf("cannot export column <%s>: %v", col, err)
+		}
+		switch {
+		case row[i].IsNull():
+		case row[i].Kind() == parquet.ByteArray:
+			size += len(row[i].ByteArray())
+		default:
+			size += 8
+		}
+	}
+	pq.Lock()
+	defer pq.Unlock()
+	if pq.writer == nil {
+		if err = pq.openFile(); err != nil {
+			return
+		}
+	}
+	if _, err = pq.writer.WriteRows([]parquet.Row{row}); err != nil {
+		return
+	}
+	pq.records++
+	pq.size += size
+	if (pq.maxRecords > 0 && pq.records >= pq.maxRecords) ||
+		(pq.maxFileSize > 0 && pq.size >= pq.maxFileSize) {
+		return pq.rotate()
+	}
+	return
+}
+
+// openFile creates a new file, written under a temporary name until it is finished
+func (pq *FileParquetEE) openFile() (err error) {
+	pq.filePath = path.Join(pq.cfg.ExportPath,
+		pq.cfg.ID+utils.Underline+utils.UUIDSha1Prefix()+utils.ParquetSuffix)
+	if pq.file, err = os.Create(pq.filePath + utils.TmpSuffix); err != nil {
+		return
+	}
+	pq.em.Set([]string{utils.ExportPath}, pq.filePath)
+	pq.writer = parquet.NewWriter(pq.file, pq.schema, parquet.Compression(pq.codec))
+	pq.records, pq.size = 0, 0
+	if pq.rotateInterval > 0 {
+		file := pq.file
+		pq.rotateTimer = time.AfterFunc(pq.rotateInterval, func() {
+			pq.Lock()
+			defer pq.Unlock()
+			if pq.file != file { // already rotated
+				return
+			}
+			if err := pq.rotate(); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when rotating the file",
+					utils.EEs, pq.cfg.ID, err.Error()))
+			}
+		})
+	}
+	return
+}
+
+// rotate finishes the current file and schedules its upload, the next export opening a new one
+func (pq *FileParquetEE) rotate() (err error) {
+	if pq.rotateTimer != nil {
+		pq.rotateTimer.Stop()
+		pq.rotateTimer = nil
+	}
+	err = pq.writer.Close()
+	if errClose := pq.file.Close(); err == nil {
+		err = errClose
+	}
+	pq.writer, pq.file = nil, nil
+	if err != nil {
+		return
+	}
+	if err = os.Rename(pq.filePath+utils.TmpSuffix, pq.filePath); err != nil {
+		return
+	}
+	if pq.s3 != nil {
+		pq.uploads.Add(1)
+		go pq.upload(pq.filePath)
+	}
+	return
+}
+
+// upload sends the finished file to S3, keeping the local copy
+func (pq *FileParquetEE) upload(filePath string) {
+	defer pq.uploads.Done()
+	err := pq.s3.Connect()
+	if err == nil {
+		var f *os.File
+		if f, err = os.Open(filePath); err == nil {
+			err = pq.s3.upload(path.Base(filePath), f)
+			f.Close()
+		}
+	}
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when uploading the file: <%s>",
+			utils.EEs, pq.cfg.ID, err.Error(), filePath))
+	}
+}
+
+func (pq *FileParquetEE) Close() (err error) {
+	pq.Lock()
+	if pq.writer != nil {
+		if err = pq.rotate(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+				utils.EEs, pq.cfg.ID, err.Error()))
+		}
+	}
+	pq.Unlock()
+	pq.uploads.Wait()
+	return
+}
+
+func (pq *FileParquetEE) GetMetrics() *utils.ExporterMetrics { return pq.em }
+
+func (pq *FileParquetEE) PrepareMap(cgrEv *utils.CGREvent) (any, error) {
+	return map[string]any(cgrEv.Event), nil
+}
+
+func (pq *FileParquetEE) PrepareOrderMap(onm *utils.OrderedNavigableMap) (any, error) {
+	rec := make(map[string]any)
+	for el := onm.GetFirstElement(); el != nil; el = el.Next() {
+		fldPath := el.Value
+		item, _ := onm.Field(fldPath)
+		fldPath = fldPath[:len(fldPath)-1] // remove the last index
+		rec[strings.Join(fldPath, utils.NestingSep)] = item.Data
+	}
+	return rec, nil
+}
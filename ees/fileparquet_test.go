@@ -0,0 +1,226 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/parquet-go/parquet-go"
+)
+
+func newTestParquetEE(t *testing.T, exportPath string) (*FileParquetEE, *config.CGRConfig, *engine.FilterS) {
+	t.Helper()
+	cfg := config.NewDefaultCGRConfig()
+	idb, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterS := engine.NewFilterS(cfg, nil, engine.NewDataManager(idb, cfg.CacheCfg(), nil))
+	eeCfg := cfg.EEsCfg().Exporters[0]
+	eeCfg.ID = "parquet_test"
+	eeCfg.Type = utils.MetaFileParquet
+	eeCfg.ExportPath = exportPath
+	eeCfg.Fields = []*config.FCTemplate{
+		{Path: "*exp.Account", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep)},
+		{Path: "*exp.Usage", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Usage", utils.InfieldSep)},
+		{Path: "*exp.Cost", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Cost", utils.InfieldSep)},
+		{Path: "*exp.AnswerTime", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.AnswerTime", utils.InfieldSep)},
+	}
+	for _, fld := range eeCfg.Fields {
+		fld.ComputePath()
+	}
+	eeCfg.ComputeFields()
+	eeCfg.Opts.Parquet.ColumnTypes = map[string]string{
+		"Usage":      utils.MetaDuration,
+		"Cost":       utils.MetaDecimal,
+		"AnswerTime": utils.MetaTimestamp,
+	}
+	eeCfg.Opts.Parquet.DecimalScale = utils.IntPointer(4)
+	eeCfg.Opts.Parquet.MaxRecords = utils.IntPointer(2)
+	em, err := utils.NewExporterMetrics("", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pq, err := NewFileParquetEE(eeCfg, cfg, em)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pq, cfg, filterS
+}
+
+func readTestParquetFile(t *testing.T, filePath string) (rows []map[string]any) {
+	t.Helper()
+	f, err := os.Open(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pFile, err := parquet.OpenFile(f, info.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := pFile.Schema().Columns()
+	r := parquet.NewReader(pFile)
+	defer r.Close()
+	buf := make([]parquet.Row, 10)
+	for {
+		n, err := r.ReadRows(buf)
+		for _, row := range buf[:n] {
+			rec := make(map[string]any)
+			for _, v := range row {
+				switch {
+				case v.IsNull():
+					rec[cols[v.Column()][0]] = nil
+				case v.Kind() == parquet.ByteArray:
+					rec[cols[v.Column()][0]] = string(v.ByteArray())
+				default:
+					rec[cols[v.Column()][0]] = v.Int64()
+				}
+			}
+			rows = append(rows, rec)
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+func TestFileParquetExportEvent(t *testing.T) {
+	dir := t.TempDir()
+	pq, cfg, filterS := newTestParquetEE(t, dir)
+	aTime := time.Date(2025, 3, 4, 10, 11, 12, 0, time.UTC)
+	for i, ev := range []map[string]any{
+		{utils.AccountField: "1001", utils.Usage: "1m30s", utils.Cost: "1.23456", utils.AnswerTime: aTime},
+		{utils.AccountField: "1002", utils.Usage: 10 * time.Second, utils.Cost: 0.5},
+		{utils.AccountField: "1003", utils.Usage: "1s", utils.Cost: "2"},
+	} {
+		if err := exportEventWithExporter(pq, &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     utils.IfaceAsString(i),
+			Event:  ev,
+		}, false, cfg, filterS); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first file is rotated after reaching parquetMaxRecords
+	files, err := filepath.Glob(filepath.Join(dir, "parquet_test_*"+utils.ParquetSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected one finished file, received: %v", files)
+	}
+	rcv := readTestParquetFile(t, files[0])
+	exp := []map[string]any{
+		{"Account": "1001", "Usage": int64(90 * time.Second), "Cost": int64(12346), "AnswerTime": aTime.UnixMicro()},
+		{"Account": "1002", "Usage": int64(10 * time.Second), "Cost": int64(5000), "AnswerTime": nil},
+	}
+	if !reflect.DeepEqual(rcv, exp) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if files, err = filepath.Glob(filepath.Join(dir, "*")); err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected two finished files, received: %v", files)
+	}
+	var found bool
+	for _, file := range files {
+		if rcv = readTestParquetFile(t, file); len(rcv) == 1 {
+			found = true
+			if rcv[0]["Account"] != "1003" || rcv[0]["Cost"] != int64(20000) {
+				t.Errorf("unexpected record: %s", utils.ToJSON(rcv))
+			}
+		}
+	}
+	if !found {
+		t.Errorf("the last record was not written on close")
+	}
+}
+
+func TestFileParquetRotateInterval(t *testing.T) {
+	dir := t.TempDir()
+	pq, _, _ := newTestParquetEE(t, dir)
+	pq.maxRecords = 0
+	pq.rotateInterval = 10 * time.Millisecond
+	if err := pq.ExportEvent(map[string]any{"Account": "1001"}, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || filepath.Ext(files[0]) != utils.ParquetSuffix {
+		t.Fatalf("expected the file to be rotated, received: %v", files)
+	}
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileParquetInvalidValue(t *testing.T) {
+	pq, _, _ := newTestParquetEE(t, t.TempDir())
+	if err := pq.ExportEvent(map[string]any{"Usage": "notAnUsage"}, utils.EmptyString); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+	if err := pq.ExportEvent(map[string]any{"Cost": "1e30"}, utils.EmptyString); err == nil {
+		t.Error("expected error for out of range decimal")
+	}
+	if pq.writer != nil {
+		t.Error("no file should be opened for invalid records")
+	}
+}
+
+func TestNewFileParquetEENoContentFields(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	eeCfg := cfg.EEsCfg().Exporters[0]
+	eeCfg.ID = "parquet_test"
+	em, err := utils.NewExporterMetrics("", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	errExp := "no *exp fields defined in the template of exporter <parquet_test>"
+	if _, err := NewFileParquetEE(eeCfg, cfg, em); err == nil || err.Error() != errExp {
+		t.Errorf("expected %q, received %v", errExp, err)
+	}
+}
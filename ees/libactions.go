@@ -31,6 +31,7 @@ import (
 func init() {
 	gob.Register(new(HTTPPosterRequest))
 	gob.Register(new(sqlPosterRequest))
+	gob.Register(new(MQTTPosterRequest))
 
 	engine.RegisterActionFunc(utils.MetaHTTPPost, callURL)
 	engine.RegisterActionFunc(utils.HttpPostAsync, callURLAsync)
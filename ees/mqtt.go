@@ -0,0 +1,236 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// NewMQTTee creates a MQTT poster
+func NewMQTTee(cfg *config.EventExporterCfg, nodeID string, connTimeout, replyTimeout time.Duration,
+	em *utils.ExporterMetrics) (pstr *MQTTee, err error) {
+	pstr = &MQTTee{
+		cfg:          cfg,
+		em:           em,
+		connTimeout:  connTimeout,
+		replyTimeout: replyTimeout,
+		reqs:         newConcReq(cfg.ConcurrentRequests),
+	}
+	err = pstr.parseOpts(cfg.Opts.MQTT, nodeID)
+	return
+}
+
+// MQTTee publishes the events to a MQTT broker
+type MQTTee struct {
+	topic        config.RSRParsers // template of the topic, populated from the exported event
+	qos          byte
+	retain       bool
+	clientID     string
+	username     string
+	password     string
+	tlsCfg       *tls.Config // nil for plain connections
+	connTimeout  time.Duration
+	replyTimeout time.Duration // maximum wait for the publish acknowledgement
+
+	poster mqtt.Client
+
+	cfg          *config.EventExporterCfg
+	em           *utils.ExporterMetrics
+	reqs         *concReq
+	sync.RWMutex // protect poster
+}
+
+// MQTTPosterRequest is the message published by MQTTee, the topic is
+// resolved when the event is prepared so it survives in the failed posts
+type MQTTPosterRequest struct {
+	Topic string
+	Body  []byte
+}
+
+func (pstr *MQTTee) parseOpts(opts *config.MQTTOpts, nodeID string) (err error) {
+	topic := utils.MQTTDefaultTopic
+	pstr.qos = utils.MQTTDefaultQoS
+	pstr.clientID = utils.CGRateSLwr + utils.Underline + nodeID + utils.Underline + pstr.cfg.ID
+	if opts != nil {
+		if opts.Topic != nil {
+			topic = *opts.Topic
+		}
+		if opts.QoS != nil {
+			if *opts.QoS < 0 || *opts.QoS > 2 {
+				return fmt.Errorf("invalid %s: %d", utils.MQTTQoS, *opts.QoS)
+			}
+			pstr.qos = byte(*opts.QoS)
+		}
+		if opts.Retain != nil {
+			pstr.retain = *opts.Retain
+		}
+		if opts.ClientID != nil {
+			pstr.clientID = *opts.ClientID
+		}
+		if opts.Username != nil {
+			pstr.username = *opts.Username
+		}
+		if opts.Password != nil {
+			pstr.password = *opts.Password
+		}
+		if opts.TLS != nil && *opts.TLS {
+			if pstr.tlsCfg, err = mqttTLSConfig(opts.CAPath, opts.ClientCertificate,
+				opts.ClientKey, opts.SkipTLSVerify); err != nil {
+				return
+			}
+		}
+	}
+	pstr.topic, err = config.NewRSRParsers(topic, utils.InfieldSep)
+	return
+}
+
+func (pstr *MQTTee) Cfg() *config.EventExporterCfg { return pstr.cfg }
+
+func (pstr *MQTTee) Connect() error {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.poster != nil && pstr.poster.IsConnectionOpen() {
+		return nil
+	}
+	// reconnecting is left to the ExportWithAttempts function so the messages
+	// are not queued inside the client while they are also saved as failed posts
+	clntOpts := mqtt.NewClientOptions().
+		AddBroker(pstr.Cfg().ExportPath).
+		SetClientID(pstr.clientID).
+		SetUsername(pstr.username).
+		SetPassword(pstr.password).
+		SetConnectTimeout(pstr.connTimeout).
+		SetAutoReconnect(false)
+	if pstr.tlsCfg != nil {
+		clntOpts.SetTLSConfig(pstr.tlsCfg)
+	}
+	clnt := mqtt.NewClient(clntOpts)
+	if tkn := clnt.Connect(); tkn.Wait() && tkn.Error() != nil {
+		return tkn.Error()
+	}
+	pstr.poster = clnt
+	return nil
+}
+
+func (pstr *MQTTee) ExportEvent(content any, _ string) error {
+	pstr.reqs.get()
+	defer pstr.reqs.done()
+	pstr.RLock()
+	defer pstr.RUnlock()
+	if pstr.poster == nil {
+		return utils.ErrDisconnected
+	}
+	req := content.(*MQTTPosterRequest)
+	tkn := pstr.poster.Publish(req.Topic, pstr.qos, pstr.retain, req.Body)
+	// QoS 0 tokens complete once the message is written, the others once the broker acknowledges it
+	if pstr.replyTimeout <= 0 {
+		tkn.Wait()
+	} else if !tkn.WaitTimeout(pstr.replyTimeout) {
+		return fmt.Errorf("timeout publishing to topic <%s>", req.Topic)
+	}
+	return tkn.Error()
+}
+
+func (pstr *MQTTee) Close() error {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.poster == nil {
+		return nil
+	}
+	pstr.poster.Disconnect(250)
+	pstr.poster = nil
+	return nil
+}
+
+func (pstr *MQTTee) GetMetrics() *utils.ExporterMetrics { return pstr.em }
+
+func (pstr *MQTTee) PrepareMap(mp *utils.CGREvent) (any, error) {
+	return pstr.prepareRequest(mp.Event, mp.APIOpts)
+}
+
+func (pstr *MQTTee) PrepareOrderMap(mp *utils.OrderedNavigableMap) (any, error) {
+	valMp := make(map[string]any)
+	for el := mp.GetFirstElement(); el != nil; el = el.Next() {
+		path := el.Value
+		nmIt, _ := mp.Field(path)
+		path = path[:len(path)-1] // remove the last index
+		valMp[strings.Join(path, utils.NestingSep)] = nmIt.String()
+	}
+	return pstr.prepareRequest(valMp, nil)
+}
+
+// prepareRequest builds the message body and resolves the topic template
+// against the exported fields(*req) and the options(*opts)
+func (pstr *MQTTee) prepareRequest(ev, opts map[string]any) (req *MQTTPosterRequest, err error) {
+	req = new(MQTTPosterRequest)
+	if req.Body, err = json.Marshal(ev); err != nil {
+		return
+	}
+	req.Topic, err = pstr.topic.ParseDataProvider(utils.MapStorage{
+		utils.MetaReq:  utils.MapStorage(ev),
+		utils.MetaOpts: utils.MapStorage(opts),
+	})
+	return
+}
+
+// mqttTLSConfig builds the TLS configuration used towards the broker
+func mqttTLSConfig(caPath, certPath, keyPath *string, skipVerify *bool) (tlsCfg *tls.Config, err error) {
+	tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	if skipVerify != nil {
+		tlsCfg.InsecureSkipVerify = *skipVerify
+	}
+	if caPath != nil && *caPath != utils.EmptyString {
+		var rootCAs *x509.CertPool
+		if rootCAs, err = x509.SystemCertPool(); err != nil {
+			return
+		}
+		var ca []byte
+		if ca, err = os.ReadFile(*caPath); err != nil {
+			return
+		}
+		if !rootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse root certificate from %q", *caPath)
+		}
+		tlsCfg.RootCAs = rootCAs
+	}
+	switch {
+	case certPath != nil && keyPath != nil:
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(*certPath, *keyPath); err != nil {
+			return
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	case certPath != nil:
+		return nil, fmt.Errorf("has certificate but no key")
+	case keyPath != nil:
+		return nil, fmt.Errorf("has key but no certificate")
+	}
+	return
+}
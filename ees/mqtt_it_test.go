@@ -0,0 +1,144 @@
+//go:build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+func startMosquitto(t *testing.T) {
+	t.Helper()
+	cmd := exec.Command("mosquitto", "-p", "1883")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err) // most probably not installed
+	}
+	t.Cleanup(func() { cmd.Process.Kill() })
+	time.Sleep(100 * time.Millisecond) // give the broker time to listen
+}
+
+// subscribeMQTT returns a channel receiving the messages published on topic
+func subscribeMQTT(t *testing.T, topic string) chan mqtt.Message {
+	t.Helper()
+	ch := make(chan mqtt.Message, 3)
+	clnt := mqtt.NewClient(mqtt.NewClientOptions().
+		AddBroker("tcp://127.0.0.1:1883").
+		SetClientID("cgrates_test_subscriber"))
+	if tkn := clnt.Connect(); tkn.Wait() && tkn.Error() != nil {
+		t.Fatal(tkn.Error())
+	}
+	t.Cleanup(func() { clnt.Disconnect(250) })
+	if tkn := clnt.Subscribe(topic, 1, func(_ mqtt.Client, msg mqtt.Message) {
+		ch <- msg
+	}); tkn.Wait() && tkn.Error() != nil {
+		t.Fatal(tkn.Error())
+	}
+	return ch
+}
+
+func TestMQTTee(t *testing.T) {
+	startMosquitto(t)
+	cgrCfg := config.NewDefaultCGRConfig()
+	eeCfg := config.NewEventExporterCfg("MQTTExporter", utils.MetaMQTTjsonMap,
+		"tcp://127.0.0.1:1883", utils.MetaNone, 1, &config.EventExporterOpts{
+			MQTT: &config.MQTTOpts{
+				Topic:  utils.StringPointer("cgrates/cdrs/;~*req.Account"),
+				QoS:    utils.IntPointer(1),
+				Retain: utils.BoolPointer(true),
+			},
+		})
+	evExp, err := NewEventExporter(eeCfg, cgrCfg, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer evExp.Close()
+
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]any{
+			"Account":     "1001",
+			"Destination": "1002",
+		},
+	}
+	if err := exportEventWithExporter(evExp, cgrEv, true, cgrCfg, new(engine.FilterS)); err != nil {
+		t.Fatal(err)
+	}
+
+	// subscribing after the export receives the retained message
+	ch := subscribeMQTT(t, "cgrates/cdrs/+")
+	select {
+	case msg := <-ch:
+		if msg.Topic() != "cgrates/cdrs/1001" {
+			t.Errorf("Expected topic %q, received %q", "cgrates/cdrs/1001", msg.Topic())
+		}
+		if !msg.Retained() {
+			t.Error("Expected the message to be retained")
+		}
+		if exp := `{"Account":"1001","Destination":"1002"}`; string(msg.Payload()) != exp {
+			t.Errorf("Expected %s, received %s", exp, msg.Payload())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Time limit exceeded")
+	}
+}
+
+func TestMQTTeeReplayFailedPosts(t *testing.T) {
+	expEv := &ExportEvents{
+		Path: "tcp://127.0.0.1:1883",
+		Type: utils.MetaMQTTjsonMap,
+		Opts: &config.EventExporterOpts{
+			MQTT: &config.MQTTOpts{
+				Topic: utils.StringPointer("cgrates/cdrs/;~*req.Account"),
+			},
+		},
+		Attempts: 1,
+		Events: []any{&MQTTPosterRequest{
+			Topic: "cgrates/cdrs/1001",
+			Body:  []byte(`{"Account":"1001"}`),
+		}},
+	}
+
+	// no broker listening yet so the events are returned as failed
+	failedEvs, err := expEv.ReplayFailedPosts()
+	if err == nil || failedEvs == nil || len(failedEvs.Events) != 1 {
+		t.Fatalf("Expected the event to fail, received %v, %v", failedEvs, err)
+	}
+
+	startMosquitto(t)
+	ch := subscribeMQTT(t, "cgrates/cdrs/#")
+	if failedEvs, err = failedEvs.ReplayFailedPosts(); err != nil || failedEvs != nil {
+		t.Fatalf("Expected the replay to succeed, received %v, %v", failedEvs, err)
+	}
+	select {
+	case msg := <-ch:
+		if msg.Topic() != "cgrates/cdrs/1001" || string(msg.Payload()) != `{"Account":"1001"}` {
+			t.Errorf("Unexpected message on topic %q: %s", msg.Topic(), msg.Payload())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Time limit exceeded")
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewMQTTee(t *testing.T) {
+	cfg := &config.EventExporterCfg{
+		ID:   "mqtt_exporter",
+		Opts: &config.EventExporterOpts{},
+	}
+	em := new(utils.ExporterMetrics)
+	pstr, err := NewMQTTee(cfg, "node1", time.Second, 2*time.Second, em)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "cgrates_node1_mqtt_exporter"; pstr.clientID != exp {
+		t.Errorf("Expected %q, received %q", exp, pstr.clientID)
+	}
+	if pstr.qos != utils.MQTTDefaultQoS || pstr.retain || pstr.tlsCfg != nil {
+		t.Errorf("Unexpected defaults: %+v", pstr)
+	}
+	if topic := pstr.topic.GetRule(utils.InfieldSep); topic != utils.MQTTDefaultTopic {
+		t.Errorf("Expected %q, received %q", utils.MQTTDefaultTopic, topic)
+	}
+	if pstr.Cfg() != cfg || pstr.GetMetrics() != em {
+		t.Error("Expected the config and metrics passed to the constructor")
+	}
+
+	cfg.Opts.MQTT = &config.MQTTOpts{
+		Topic:         utils.StringPointer("cgrates/thresholds"),
+		QoS:           utils.IntPointer(2),
+		Retain:        utils.BoolPointer(true),
+		ClientID:      utils.StringPointer("thresholds"),
+		Username:      utils.StringPointer("user"),
+		Password:      utils.StringPointer("pass"),
+		TLS:           utils.BoolPointer(true),
+		SkipTLSVerify: utils.BoolPointer(true),
+	}
+	if pstr, err = NewMQTTee(cfg, "node1", time.Second, 2*time.Second, em); err != nil {
+		t.Fatal(err)
+	}
+	if pstr.qos != 2 || !pstr.retain || pstr.clientID != "thresholds" ||
+		pstr.username != "user" || pstr.password != "pass" {
+		t.Errorf("Options not applied: %+v", pstr)
+	}
+	if pstr.tlsCfg == nil || !pstr.tlsCfg.InsecureSkipVerify {
+		t.Errorf("Expected TLS config skipping verification, received %+v", pstr.tlsCfg)
+	}
+
+	cfg.Opts.MQTT = &config.MQTTOpts{QoS: utils.IntPointer(3)}
+	if _, err = NewMQTTee(cfg, "node1", time.Second, 2*time.Second, em); err == nil ||
+		err.Error() != "invalid mqttQoS: 3" {
+		t.Errorf("Expected error for invalid QoS, received %v", err)
+	}
+	cfg.Opts.MQTT = &config.MQTTOpts{
+		TLS:       utils.BoolPointer(true),
+		ClientKey: utils.StringPointer("/tmp/key.pem"),
+	}
+	if _, err = NewMQTTee(cfg, "node1", time.Second, 2*time.Second, em); err == nil ||
+		err.Error() != "has key but no certificate" {
+		t.Errorf("Expected error for missing certificate, received %v", err)
+	}
+}
+
+func TestMQTTeePrepareTopicTemplate(t *testing.T) {
+	cfg := &config.EventExporterCfg{
+		ID: "mqtt_exporter",
+		Opts: &config.EventExporterOpts{
+			MQTT: &config.MQTTOpts{
+				Topic: utils.StringPointer("cgrates/;~*req.Tenant;/;~*req.Account;/;~*opts.*eventType"),
+			},
+		},
+	}
+	pstr, err := NewMQTTee(cfg, "node1", time.Second, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rply, err := pstr.PrepareMap(&utils.CGREvent{
+		Event: map[string]any{
+			utils.Tenant:       "cgrates.org",
+			utils.AccountField: "1001",
+		},
+		APIOpts: map[string]any{
+			utils.MetaEventType: utils.ThresholdHit,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &MQTTPosterRequest{
+		Topic: "cgrates/cgrates.org/1001/" + utils.ThresholdHit,
+		Body:  []byte(`{"Account":"1001","Tenant":"cgrates.org"}`),
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %+v, received %+v", exp, rply)
+	}
+
+	onm := utils.NewOrderedNavigableMap()
+	onm.Append(&utils.FullPath{
+		PathSlice: []string{utils.Tenant},
+		Path:      utils.Tenant,
+	}, &utils.DataLeaf{Data: "cgrates.org"})
+	onm.Append(&utils.FullPath{
+		PathSlice: []string{utils.AccountField},
+		Path:      utils.AccountField,
+	}, &utils.DataLeaf{Data: "1002"})
+	// the exported fields do not carry the options
+	if _, err = pstr.PrepareOrderMap(onm); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	pstr.topic = config.NewRSRParsersMustCompile("cgrates/;~*req.Tenant;/;~*req.Account", utils.InfieldSep)
+	if rply, err = pstr.PrepareOrderMap(onm); err != nil {
+		t.Fatal(err)
+	}
+	exp = &MQTTPosterRequest{
+		Topic: "cgrates/cgrates.org/1002",
+		Body:  []byte(`{"Account":"1002","Tenant":"cgrates.org"}`),
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %+v, received %+v", exp, rply)
+	}
+}
+
+func TestMQTTeeExportEventDisconnected(t *testing.T) {
+	pstr, err := NewMQTTee(&config.EventExporterCfg{Opts: &config.EventExporterOpts{}},
+		"node1", time.Second, time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pstr.ExportEvent(&MQTTPosterRequest{Topic: "cgrates/cdrs"}, ""); err != utils.ErrDisconnected {
+		t.Errorf("Expected %v, received %v", utils.ErrDisconnected, err)
+	}
+	if err := pstr.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMQTTeeFailedPostsEncoding(t *testing.T) {
+	expEv := &ExportEvents{
+		Path: "tcp://127.0.0.1:1883",
+		Type: utils.MetaMQTTjsonMap,
+		Opts: &config.EventExporterOpts{
+			MQTT: &config.MQTTOpts{Topic: utils.StringPointer("cgrates/;~*req.Account")},
+		},
+		Attempts: 1,
+		Events: []any{&MQTTPosterRequest{
+			Topic: "cgrates/1001",
+			Body:  []byte(`{"Account":"1001"}`),
+		}},
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(expEv); err != nil {
+		t.Fatal(err)
+	}
+	rcv := new(ExportEvents)
+	if err := gob.NewDecoder(&buf).Decode(rcv); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expEv.Events, rcv.Events) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expEv.Events), utils.ToJSON(rcv.Events))
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+// NewRedisStreamsEE creates a poster adding the events to a Redis stream
+func NewRedisStreamsEE(cfg *config.EventExporterCfg, dbCfg *config.DataDbCfg,
+	em *utils.ExporterMetrics) *RedisStreamsEE {
+	pstr := &RedisStreamsEE{
+		cfg:    cfg,
+		dbCfg:  dbCfg,
+		em:     em,
+		stream: utils.RedisStreamsDefaultStream,
+		reqs:   newConcReq(cfg.ConcurrentRequests),
+	}
+	if redisOpts := cfg.Opts.RedisStreams; redisOpts != nil {
+		if redisOpts.Stream != nil {
+			pstr.stream = *redisOpts.Stream
+		}
+		if redisOpts.MaxLen != nil {
+			pstr.maxLen = *redisOpts.MaxLen
+		}
+	}
+	return pstr
+}
+
+// RedisStreamsEE adds each event as one entry to a Redis stream
+type RedisStreamsEE struct {
+	stream string
+	maxLen int               // approximate length the stream is trimmed to, 0 disables trimming
+	dbCfg  *config.DataDbCfg // connection options shared with the DataDB
+	client radix.Client
+
+	cfg          *config.EventExporterCfg
+	em           *utils.ExporterMetrics
+	reqs         *concReq
+	sync.RWMutex // protect client
+}
+
+func (pstr *RedisStreamsEE) Cfg() *config.EventExporterCfg { return pstr.cfg }
+
+func (pstr *RedisStreamsEE) Connect() (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.client == nil {
+		pstr.client, err = engine.NewRedisClient(pstr.Cfg().ExportPath, pstr.dbCfg)
+	}
+	return
+}
+
+func (pstr *RedisStreamsEE) ExportEvent(content any, _ string) error {
+	pstr.reqs.get()
+	defer pstr.reqs.done()
+	pstr.RLock()
+	defer pstr.RUnlock()
+	if pstr.client == nil {
+		return utils.ErrDisconnected
+	}
+	fields := content.([]string)
+	args := make([]string, 0, len(fields)+5)
+	args = append(args, pstr.stream)
+	if pstr.maxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.Itoa(pstr.maxLen))
+	}
+	args = append(args, "*")
+	return pstr.client.Do(radix.Cmd(nil, "XADD", append(args, fields...)...))
+}
+
+func (pstr *RedisStreamsEE) Close() (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.client != nil {
+		err = pstr.client.Close()
+		pstr.client = nil
+	}
+	return
+}
+
+func (pstr *RedisStreamsEE) GetMetrics() *utils.ExporterMetrics { return pstr.em }
+
+// PrepareMap returns the entry fields and values, sorted by field name
+func (pstr *RedisStreamsEE) PrepareMap(mp *utils.CGREvent) (any, error) {
+	flds := make([]string, 0, len(mp.Event))
+	for fld := range mp.Event {
+		flds = append(flds, fld)
+	}
+	slices.Sort(flds)
+	entry := make([]string, 0, 2*len(flds))
+	for _, fld := range flds {
+		entry = append(entry, fld, utils.IfaceAsString(mp.Event[fld]))
+	}
+	return entry, nil
+}
+
+// PrepareOrderMap returns the entry fields and values in the template order
+func (pstr *RedisStreamsEE) PrepareOrderMap(mp *utils.OrderedNavigableMap) (any, error) {
+	var entry []string
+	for el := mp.GetFirstElement(); el != nil; el = el.Next() {
+		path := el.Value
+		nmIt, _ := mp.Field(path)
+		path = path[:len(path)-1] // remove the last index
+		entry = append(entry, strings.Join(path, utils.NestingSep), nmIt.String())
+	}
+	return entry, nil
+}
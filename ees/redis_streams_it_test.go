@@ -0,0 +1,93 @@
+//go:build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+func TestRedisStreamsEE(t *testing.T) {
+	rds, err := radix.NewPool(utils.TCP, "127.0.0.1:6379", 1)
+	if err != nil {
+		t.Fatal(err) // most probably redis is not running
+	}
+	defer rds.Close()
+	cleanup := func() {
+		if err := rds.Do(radix.Cmd(nil, "DEL", "cgrates_test_exports")); err != nil {
+			t.Error(err)
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	cgrCfg := config.NewDefaultCGRConfig()
+	eeCfg := config.NewEventExporterCfg("RedisExporter", utils.MetaRedisStreams,
+		"127.0.0.1:6379", utils.MetaNone, 1, &config.EventExporterOpts{
+			RedisStreams: &config.RedisStreamsOpts{
+				Stream: utils.StringPointer("cgrates_test_exports"),
+				MaxLen: utils.IntPointer(1),
+			},
+		})
+	evExp, err := NewEventExporter(eeCfg, cgrCfg, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer evExp.Close()
+
+	for i := 1; i <= 200; i++ {
+		cgrEv := &utils.CGREvent{
+			Tenant: "cgrates.org",
+			Event: map[string]any{
+				"Account":     fmt.Sprintf("%d", 1000+i),
+				"Destination": "1002",
+			},
+		}
+		if err := exportEventWithExporter(evExp, cgrEv, true, cgrCfg, new(engine.FilterS)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var entries []radix.StreamEntry
+	if err := rds.Do(radix.Cmd(&entries, "XREVRANGE", "cgrates_test_exports", "+", "-", "COUNT", "1")); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, received %d", len(entries))
+	}
+	if exp := map[string]string{"Account": "1200", "Destination": "1002"}; !reflect.DeepEqual(exp, entries[0].Fields) {
+		t.Errorf("Expected %v, received %v", exp, entries[0].Fields)
+	}
+	// the trimming is approximate so only check the stream did not keep all the entries
+	var streamLen int
+	if err := rds.Do(radix.Cmd(&streamLen, "XLEN", "cgrates_test_exports")); err != nil {
+		t.Fatal(err)
+	}
+	if streamLen >= 200 {
+		t.Errorf("Expected the stream to be trimmed, received length %d", streamLen)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewRedisStreamsEE(t *testing.T) {
+	cfg := &config.EventExporterCfg{
+		ID:   "redis_exporter",
+		Opts: &config.EventExporterOpts{},
+	}
+	dbCfg := config.NewDefaultCGRConfig().DataDbCfg()
+	em := new(utils.ExporterMetrics)
+	pstr := NewRedisStreamsEE(cfg, dbCfg, em)
+	if pstr.stream != utils.RedisStreamsDefaultStream || pstr.maxLen != 0 {
+		t.Errorf("Unexpected defaults: %+v", pstr)
+	}
+	if pstr.Cfg() != cfg || pstr.GetMetrics() != em || pstr.dbCfg != dbCfg {
+		t.Error("Expected the configs and metrics passed to the constructor")
+	}
+
+	cfg.Opts.RedisStreams = &config.RedisStreamsOpts{
+		Stream: utils.StringPointer("cdrs"),
+		MaxLen: utils.IntPointer(1000),
+	}
+	if pstr = NewRedisStreamsEE(cfg, dbCfg, em); pstr.stream != "cdrs" || pstr.maxLen != 1000 {
+		t.Errorf("Options not applied: %+v", pstr)
+	}
+}
+
+func TestRedisStreamsEEPrepare(t *testing.T) {
+	pstr := NewRedisStreamsEE(&config.EventExporterCfg{Opts: &config.EventExporterOpts{}}, nil, nil)
+	rply, err := pstr.PrepareMap(&utils.CGREvent{
+		Event: map[string]any{
+			utils.Tenant:       "cgrates.org",
+			utils.AccountField: "1001",
+			utils.Cost:         1.5,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{utils.AccountField, "1001", utils.Cost, "1.5", utils.Tenant, "cgrates.org"}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %q, received %q", exp, rply)
+	}
+
+	onm := utils.NewOrderedNavigableMap()
+	onm.Append(&utils.FullPath{
+		PathSlice: []string{utils.Tenant},
+		Path:      utils.Tenant,
+	}, &utils.DataLeaf{Data: "cgrates.org"})
+	onm.Append(&utils.FullPath{
+		PathSlice: []string{utils.AccountField},
+		Path:      utils.AccountField,
+	}, &utils.DataLeaf{Data: "1002"})
+	if rply, err = pstr.PrepareOrderMap(onm); err != nil {
+		t.Fatal(err)
+	}
+	exp = []string{utils.Tenant, "cgrates.org", utils.AccountField, "1002"}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %q, received %q", exp, rply)
+	}
+}
+
+func TestRedisStreamsEEExportEventDisconnected(t *testing.T) {
+	pstr := NewRedisStreamsEE(&config.EventExporterCfg{Opts: &config.EventExporterOpts{}}, nil, nil)
+	if err := pstr.ExportEvent([]string{utils.AccountField, "1001"}, ""); err != utils.ErrDisconnected {
+		t.Errorf("Expected %v, received %v", utils.ErrDisconnected, err)
+	}
+	if err := pstr.Close(); err != nil {
+		t.Error(err)
+	}
+}
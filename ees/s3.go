@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net/http"
 	"sync"
 
@@ -135,7 +136,12 @@ func (pstr *S3EE) Connect() (err error) {
 	return
 }
 
-func (pstr *S3EE) ExportEvent(message any, key string) (err error) {
+func (pstr *S3EE) ExportEvent(message any, key string) error {
+	return pstr.upload(key+utils.JSNSuffix, bytes.NewReader(message.([]byte)))
+}
+
+// upload puts the body in the configured bucket under the folder path
+func (pstr *S3EE) upload(key string, body io.Reader) (err error) {
 	pstr.reqs.get()
 	pstr.RLock()
 	_, err = pstr.up.Upload(&s3manager.UploadInput{
@@ -144,13 +150,13 @@ func (pstr *S3EE) ExportEvent(message any, key string) (err error) {
 		// Can also use the `filepath` standard library package to modify the
 		// filename as need for an S3 object key. Such as turning absolute path
 		// to a relative path.
-		Key: aws.String(fmt.Sprintf("%s/%s.json", pstr.folderPath, key)),
+		Key: aws.String(fmt.Sprintf("%s/%s", pstr.folderPath, key)),
 
 		// The file to be uploaded. io.ReadSeeker is preferred as the Uploader
 		// will be able to optimize memory when uploading large content. io.Reader
 		// is supported, but will require buffering of the reader's bytes for
 		// each part.
-		Body: bytes.NewReader(message.([]byte)),
+		Body: body,
 	})
 	pstr.RUnlock()
 	pstr.reqs.done()
@@ -1630,6 +1630,7 @@ func dynamicThreshold(_ *Account, act *Action, _ Actions, _ *FilterS, ev any,
 //	11 Weight: float
 //	12 ThresholdIDs: strings separated by "&".
 //	13 APIOpts: set of key-value pairs (separated by "&").
+//	14 BucketInterval: duration, optional
 //
 // Parameters are separated by ";" and must be provided in the specified order.
 func dynamicStats(_ *Account, act *Action, _ Actions, _ *FilterS, ev any,
@@ -1646,8 +1647,8 @@ func dynamicStats(_ *Account, act *Action, _ Actions, _ *FilterS, ev any,
 	}
 	// Parse action parameters based on the predefined format.
 	params := strings.Split(act.ExtraParameters, utils.InfieldSep)
-	if len(params) != 14 {
-		return fmt.Errorf("invalid number of parameters <%d> expected 14", len(params))
+	if len(params) != 14 && len(params) != 15 {
+		return fmt.Errorf("invalid number of parameters <%d> expected 14 or 15", len(params))
 	}
 	// parse dynamic parameters
 	for i := range params {
@@ -1752,6 +1753,13 @@ func dynamicStats(_ *Account, act *Action, _ Actions, _ *FilterS, ev any,
 			return err
 		}
 	}
+	// populate Stat's BucketInterval
+	if len(params) == 15 && params[14] != utils.EmptyString {
+		stQProf.BucketInterval, err = utils.ParseDurationWithNanosecs(params[14])
+		if err != nil {
+			return err
+		}
+	}
 
 	// create the StatQueueProfile based on the populated parameters
 	var reply string
@@ -5287,6 +5287,21 @@ func TestDynamicStats(t *testing.T) {
 			},
 			extraParams: "cgrates.org;Stat_1;;;;;;;;;;;;",
 		},
+		{
+			name:    "SuccessfulRequestBucketInterval",
+			connIDs: []string{connID},
+			expSqpwo: &StatQueueProfileWithAPIOpts{
+				StatQueueProfile: &StatQueueProfile{
+					Tenant:             "cgrates.org",
+					ID:                 "Stat_1",
+					ActivationInterval: &utils.ActivationInterval{},
+					TTL:                time.Hour,
+					BucketInterval:     time.Minute,
+				},
+				APIOpts: map[string]any{},
+			},
+			extraParams: "cgrates.org;Stat_1;;;;1h;;;;;;;;;1m",
+		},
 		{
 			name:        "MissingConns",
 			extraParams: "cgrates.org;Stat_1;FLTR_STAT_1;2014-07-29T15:00:00Z;100;10s;0;*acd&*tcd&*asr;Metric_FLTR;false;true;30;*none;key:value",
@@ -5295,7 +5310,7 @@ func TestDynamicStats(t *testing.T) {
 		{
 			name:        "WrongNumberOfParams",
 			extraParams: "tenant;;1;",
-			expectedErr: "invalid number of parameters <4> expected 14",
+			expectedErr: "invalid number of parameters <4> expected 14 or 15",
 		},
 		{
 			name:        "ActivationIntervalLengthFail",
@@ -109,6 +109,9 @@ func init() {
 	gob.Register(new(StatLowest))
 	gob.Register(new(StatREPSC))
 	gob.Register(new(StatREPFC))
+	gob.Register(new(StatPercentile))
+	gob.Register(new(StatHistogram))
+	gob.Register(new(StatAnomaly))
 
 	// others
 	gob.Register([]any{})
@@ -1183,6 +1183,10 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 				err, sqp.TenantID())
 		}
 	}
+	if err = sqp.checkBucketInterval(); err != nil {
+		return fmt.Errorf("%+s for item with ID: %+v",
+			err, sqp.TenantID())
+	}
 	oldSts, err := dm.GetStatQueueProfile(sqp.Tenant, sqp.ID, true, false, utils.NonTransactional)
 	if err != nil && err != utils.ErrNotFound {
 		return err
@@ -1214,6 +1218,7 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 	if oldSts == nil || // create the stats queue if it didn't exist before
 		oldSts.QueueLength != sqp.QueueLength ||
 		oldSts.TTL != sqp.TTL ||
+		oldSts.BucketInterval != sqp.BucketInterval ||
 		oldSts.MinItems != sqp.MinItems ||
 		(oldSts.Stored != sqp.Stored && oldSts.Stored) { // reset the stats queue if the profile changed these fields
 		guardian.Guardian.Guard(func() (_ error) { // we change the queue so lock it
@@ -1248,11 +1253,17 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 						sqp.MinItems, metric.FilterIDs); err != nil {
 						return
 					}
+					for _, bkt := range oSq.SQBuckets { // the buckets restart the metric as well
+						delete(bkt.SQMetrics, metric.MetricID)
+					}
 				}
 			}
 			for sqMetricID := range oSq.SQMetrics { // remove the old metrics
 				if !cMetricIDs.Has(sqMetricID) {
 					delete(oSq.SQMetrics, sqMetricID)
+					for _, bkt := range oSq.SQBuckets {
+						delete(bkt.SQMetrics, sqMetricID)
+					}
 				}
 			}
 			if sqp.Stored { // already changed the value in cache
@@ -2011,6 +2022,15 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 				err, rp.TenantID())
 		}
 	}
+	if err = rp.checkRateLimit(); err != nil {
+		return fmt.Errorf("%+s for item with ID: %+v",
+			err, rp.TenantID())
+	}
+	if _, err = resourceParentIDs(dm, rp); err != nil &&
+		err != utils.ErrNotFound { // the parents can be set later
+		return fmt.Errorf("%+s for item with ID: %+v",
+			err, rp.TenantID())
+	}
 	oldRes, err := dm.GetResourceProfile(rp.Tenant, rp.ID, true, false, utils.NonTransactional)
 	if err != nil && err != utils.ErrNotFound {
 		return err
@@ -2043,6 +2063,8 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 	if oldRes == nil || // create the resource if it didn't exist before
 		oldRes.UsageTTL != rp.UsageTTL ||
 		oldRes.Limit != rp.Limit ||
+		oldRes.RateInterval != rp.RateInterval ||
+		oldRes.Burst != rp.Burst ||
 		(oldRes.Stored != rp.Stored && oldRes.Stored) { // reset the resource if the profile changed these fields
 		err = dm.SetResource(&Resource{
 			Tenant: rp.Tenant,
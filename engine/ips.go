@@ -117,14 +117,15 @@ func ipProfileLockKey(tnt, id string) string {
 
 // IPPool defines a pool of IP addresses within an IPProfile.
 type IPPool struct {
-	ID        string
-	FilterIDs []string
-	Type      string
-	Range     string
-	Strategy  string
-	Message   string
-	Weight    float64
-	Blocker   bool
+	ID           string
+	FilterIDs    []string
+	Type         string
+	Range        string
+	PrefixLength int // when set, allocations delegate sub-prefixes of this length out of Range
+	Strategy     string
+	Message      string
+	Weight       float64
+	Blocker      bool
 }
 
 // Clone creates a deep copy of Pool for thread-safe use.
@@ -133,14 +134,15 @@ func (p *IPPool) Clone() *IPPool {
 		return nil
 	}
 	return &IPPool{
-		ID:        p.ID,
-		FilterIDs: slices.Clone(p.FilterIDs),
-		Type:      p.Type,
-		Range:     p.Range,
-		Strategy:  p.Strategy,
-		Message:   p.Message,
-		Weight:    p.Weight,
-		Blocker:   p.Blocker,
+		ID:           p.ID,
+		FilterIDs:    slices.Clone(p.FilterIDs),
+		Type:         p.Type,
+		Range:        p.Range,
+		PrefixLength: p.PrefixLength,
+		Strategy:     p.Strategy,
+		Message:      p.Message,
+		Weight:       p.Weight,
+		Blocker:      p.Blocker,
 	}
 }
 
@@ -166,30 +168,41 @@ func (a *PoolAllocation) Clone() *PoolAllocation {
 }
 
 // AllocatedIP represents one IP allocated on a pool, together with the message.
+// Prefix is only populated by pools delegating prefixes, Address being its
+// first address.
 type AllocatedIP struct {
 	ProfileID string
 	PoolID    string
 	Message   string
 	Address   netip.Addr
+	Prefix    netip.Prefix `json:",omitzero"`
 }
 
 // AsNavigableMap implements engine.NavigableMapper.
 func (ip *AllocatedIP) AsNavigableMap() map[string]*utils.DataNode {
-	return map[string]*utils.DataNode{
+	nm := map[string]*utils.DataNode{
 		utils.ProfileID: utils.NewLeafNode(ip.ProfileID),
 		utils.PoolID:    utils.NewLeafNode(ip.PoolID),
 		utils.Message:   utils.NewLeafNode(ip.Message),
 		utils.Address:   utils.NewLeafNode(ip.Address.String()),
 	}
+	if ip.Prefix.IsValid() {
+		nm[utils.Prefix] = utils.NewLeafNode(ip.Prefix.String())
+	}
+	return nm
 }
 
 // Digest returns a string representation of the allocated IP for digest replies.
 func (ip *AllocatedIP) Digest() string {
+	addr := ip.Address.String()
+	if ip.Prefix.IsValid() {
+		addr = ip.Prefix.String()
+	}
 	return utils.ConcatenatedKey(
 		ip.ProfileID,
 		ip.PoolID,
 		ip.Message,
-		ip.Address.String(),
+		addr,
 	)
 }
 
@@ -201,7 +214,7 @@ type IPAllocations struct {
 	TTLIndex    []string                   // allocIDs ordered by allocation time for TTL expiry
 
 	prfl       *IPProfile
-	poolRanges map[string]netip.Prefix          // parsed CIDR ranges by pool ID
+	poolRanges map[string]*ipPoolRange          // parsed ranges by pool ID
 	poolAllocs map[string]map[netip.Addr]string // IP to allocation ID mapping by pool (map[poolID]map[Addr]allocID)
 	lockID     string
 }
@@ -238,26 +251,43 @@ func (a *IPAllocations) computeUnexported(prfl *IPProfile) error {
 		}
 		a.poolAllocs[alloc.PoolID][alloc.Address] = allocID
 	}
-	a.poolRanges = make(map[string]netip.Prefix)
+	a.poolRanges = make(map[string]*ipPoolRange)
 	for _, poolCfg := range a.prfl.Pools {
-		prefix, err := netip.ParsePrefix(poolCfg.Range)
+		poolRange, err := newIPPoolRange(poolCfg)
 		if err != nil {
 			return err
 		}
-		a.poolRanges[poolCfg.ID] = prefix
+		for addr := range a.poolAllocs[poolCfg.ID] {
+			poolRange.allocate(addr)
+		}
+		a.poolRanges[poolCfg.ID] = poolRange
 	}
 	return nil
 }
 
+// unindexAllocation makes the address of an allocation available again
+// within its pool.
+func (a *IPAllocations) unindexAllocation(alloc *PoolAllocation) {
+	poolMap, hasPool := a.poolAllocs[alloc.PoolID]
+	if !hasPool {
+		return
+	}
+	if _, has := poolMap[alloc.Address]; !has {
+		return
+	}
+	delete(poolMap, alloc.Address)
+	if poolRange, has := a.poolRanges[alloc.PoolID]; has {
+		poolRange.release(alloc.Address)
+	}
+}
+
 // releaseAllocation releases the allocation for an ID.
 func (a *IPAllocations) releaseAllocation(allocID string) error {
 	alloc, has := a.Allocations[allocID] // Get the allocation first
 	if !has {
 		return fmt.Errorf("cannot find allocation record with id: %s", allocID)
 	}
-	if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
-		delete(poolMap, alloc.Address)
-	}
+	a.unindexAllocation(alloc)
 	if a.prfl.TTL > 0 {
 		for i, refID := range a.TTLIndex {
 			if refID == allocID {
@@ -276,6 +306,9 @@ func (a *IPAllocations) clearAllocations(allocIDs []string) error {
 	if len(allocIDs) == 0 {
 		clear(a.Allocations)
 		clear(a.poolAllocs)
+		for _, poolRange := range a.poolRanges {
+			poolRange.reset()
+		}
 		a.TTLIndex = a.TTLIndex[:0] // maintain capacity
 		return nil
 	}
@@ -292,10 +325,7 @@ func (a *IPAllocations) clearAllocations(allocIDs []string) error {
 	}
 
 	for _, allocID := range allocIDs {
-		alloc := a.Allocations[allocID]
-		if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
-			delete(poolMap, alloc.Address)
-		}
+		a.unindexAllocation(a.Allocations[allocID])
 		if a.prfl.TTL > 0 {
 			for i, refID := range a.TTLIndex {
 				if refID == allocID {
@@ -316,35 +346,40 @@ func (a *IPAllocations) clearAllocations(allocIDs []string) error {
 func (a *IPAllocations) allocateIPOnPool(allocID string, pool *IPPool,
 	dryRun bool) (*AllocatedIP, error) {
 	a.removeExpiredUnits()
+	poolRange, has := a.poolRanges[pool.ID]
+	if !has {
+		return nil, fmt.Errorf("pool %q: %w", pool.ID, utils.ErrNotFound)
+	}
 	if poolAlloc, has := a.Allocations[allocID]; has && !dryRun {
 		poolAlloc.Time = time.Now()
 		if a.prfl.TTL > 0 {
 			a.removeAllocFromTTLIndex(allocID)
+			a.TTLIndex = append(a.TTLIndex, allocID)
 		}
-		a.TTLIndex = append(a.TTLIndex, allocID)
 		return &AllocatedIP{
 			ProfileID: a.ID,
 			PoolID:    pool.ID,
 			Message:   pool.Message,
 			Address:   poolAlloc.Address,
+			Prefix:    poolRange.prefix(poolAlloc.Address),
 		}, nil
 	}
-	poolRange := a.poolRanges[pool.ID]
-	if !poolRange.IsSingleIP() {
-		return nil, errors.New("only single IP Pools are supported for now")
-	}
-	addr := poolRange.Addr()
-	if _, hasPool := a.poolAllocs[pool.ID]; hasPool {
-		if alcID, inUse := a.poolAllocs[pool.ID][addr]; inUse {
+	addr, isFree := poolRange.nextFree(a.poolAllocs[pool.ID])
+	if !isFree {
+		if poolRange.first == poolRange.last {
 			return nil, fmt.Errorf("allocation failed for pool %q, IP %q: %w (allocated to %q)",
-				pool.ID, addr, utils.ErrIPAlreadyAllocated, alcID)
+				pool.ID, poolRange.first, utils.ErrIPAlreadyAllocated,
+				a.poolAllocs[pool.ID][poolRange.first])
 		}
+		return nil, fmt.Errorf("allocation failed for pool %q: %w (pool exhausted)",
+			pool.ID, utils.ErrIPAlreadyAllocated)
 	}
 	allocIP := &AllocatedIP{
 		ProfileID: a.ID,
 		PoolID:    pool.ID,
 		Message:   pool.Message,
 		Address:   addr,
+		Prefix:    poolRange.prefix(addr),
 	}
 	if dryRun {
 		return allocIP, nil
@@ -354,10 +389,14 @@ func (a *IPAllocations) allocateIPOnPool(allocID string, pool *IPPool,
 		Address: addr,
 		Time:    time.Now(),
 	}
+	if a.prfl.TTL > 0 {
+		a.TTLIndex = append(a.TTLIndex, allocID)
+	}
 	if _, hasPool := a.poolAllocs[pool.ID]; !hasPool {
 		a.poolAllocs[pool.ID] = make(map[netip.Addr]string)
 	}
 	a.poolAllocs[pool.ID][addr] = allocID
+	poolRange.allocate(addr)
 	return allocIP, nil
 }
 
@@ -371,9 +410,7 @@ func (a *IPAllocations) removeExpiredUnits() {
 			break
 		}
 		if alloc != nil {
-			if poolMap, hasPool := a.poolAllocs[alloc.PoolID]; hasPool {
-				delete(poolMap, alloc.Address)
-			}
+			a.unindexAllocation(alloc)
 		}
 		delete(a.Allocations, allocID)
 		expiredCount++
@@ -441,11 +478,16 @@ func (a *IPAllocations) Clone() *IPAllocations {
 		return nil
 	}
 	clone := &IPAllocations{
-		Tenant:     a.Tenant,
-		ID:         a.ID,
-		TTLIndex:   slices.Clone(a.TTLIndex),
-		prfl:       a.prfl.Clone(),
-		poolRanges: maps.Clone(a.poolRanges),
+		Tenant:   a.Tenant,
+		ID:       a.ID,
+		TTLIndex: slices.Clone(a.TTLIndex),
+		prfl:     a.prfl.Clone(),
+	}
+	if a.poolRanges != nil {
+		clone.poolRanges = make(map[string]*ipPoolRange, len(a.poolRanges))
+		for poolID, poolRange := range a.poolRanges {
+			clone.poolRanges[poolID] = poolRange.clone()
+		}
 	}
 	if a.poolAllocs != nil {
 		clone.poolAllocs = make(map[string]map[netip.Addr]string)
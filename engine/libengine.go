@@ -64,6 +64,17 @@ func NewRPCPool(ctx *context.Context, dispatchStrategy string, keyPath, certPath
 	return
 }
 
+// RPCTransportFunc constructs the connections for the transports not implemented by rpcclient
+type RPCTransportFunc func(cfg *config.RemoteHost, keyPath, certPath, poolID string,
+	connectTimeout time.Duration, fltrS *FilterS) (birpc.ClientConnector, error)
+
+var rpcTransports = make(map[string]RPCTransportFunc)
+
+// RegisterRPCTransport makes the transport available to the rpc_conns
+func RegisterRPCTransport(transport string, f RPCTransportFunc) {
+	rpcTransports[transport] = f
+}
+
 // NewRPCConnection creates a new connection based on the RemoteHost structure
 // connCache is used to cache the connection with ID
 func NewRPCConnection(ctx *context.Context, cfg *config.RemoteHost, keyPath, certPath, caPath string, connAttempts, reconnects int,
@@ -89,6 +100,12 @@ func NewRPCConnection(ctx *context.Context, cfg *config.RemoteHost, keyPath, cer
 			utils.FirstDurationNonEmpty(cfg.ConnectTimeout, connectTimeout),
 			utils.FirstDurationNonEmpty(cfg.ReplyTimeout, replyTimeout),
 			cfg.Address, internalConnChan, lazyConnect, ctx.Client)
+	} else if newConn, has := rpcTransports[cfg.Transport]; has {
+		client, err = newConn(cfg,
+			utils.FirstNonEmpty(cfg.ClientKey, keyPath),
+			utils.FirstNonEmpty(cfg.ClientCertificate, certPath), poolID,
+			utils.FirstDurationNonEmpty(cfg.ConnectTimeout, connectTimeout),
+			NewFilterS(config.CgrConfig(), connMgr, dm))
 	} else {
 		client, err = rpcclient.NewRPCClient(ctx, utils.TCP, cfg.Address, cfg.TLS,
 			utils.FirstNonEmpty(cfg.ClientKey, keyPath),
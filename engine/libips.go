@@ -0,0 +1,376 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ipRandomAttempts is the number of random picks tried by the *random
+// strategy before falling back to a linear scan for a free address.
+const ipRandomAttempts = 8
+
+// ipPoolRange holds the parsed IPPool.Range together with the cursors used
+// by the pool strategy to find the next free address without scanning the
+// whole range.
+type ipPoolRange struct {
+	first    netip.Addr
+	last     netip.Addr
+	size     *big.Int // number of allocatable units, never modified after parsing
+	strategy string
+
+	// prefix delegation, each unit is a sub-prefix identified by its first address
+	prefixLen int
+	step      *big.Int // distance between two consecutive sub-prefixes
+
+	used     uint64       // number of allocated addresses within the range
+	ascNext  netip.Addr   // no free address exists below it
+	descNext netip.Addr   // no free address exists above it
+	lruNext  netip.Addr   // first address never handed out by *least_recently_used
+	released []netip.Addr // freed addresses, oldest first (*least_recently_used)
+}
+
+// newIPPoolRange parses the range of an IPPool and prepares the cursors
+// for its strategy. The range can be a single address, a CIDR prefix or a
+// start-end interval, for both IPv4 and IPv6. Network and broadcast
+// addresses are excluded from IPv4 prefixes. With PrefixLength set, the
+// range must be a CIDR prefix which is carved into sub-prefixes of that
+// length.
+func newIPPoolRange(pool *IPPool) (*ipPoolRange, error) {
+	if err := CheckIPPoolStrategy(pool.ID, pool.Strategy); err != nil {
+		return nil, err
+	}
+	r := &ipPoolRange{
+		strategy:  pool.Strategy,
+		prefixLen: pool.PrefixLength,
+	}
+	var err error
+	if pool.PrefixLength != 0 {
+		r.first, r.last, r.step, err = parseIPDelegationRange(pool.Range, pool.PrefixLength)
+	} else {
+		r.first, r.last, err = parseIPRange(pool.Range)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pool %q: %w", pool.ID, err)
+	}
+	r.size = new(big.Int).Sub(addrToBigInt(r.last), addrToBigInt(r.first))
+	if r.step != nil {
+		r.size.Quo(r.size, r.step)
+	}
+	r.size.Add(r.size, big.NewInt(1))
+	r.reset()
+	return r, nil
+}
+
+// CheckIPPoolStrategy returns an error if the allocation strategy of the pool is not supported,
+// allowing profiles to be rejected when set or loaded instead of when allocating.
+func CheckIPPoolStrategy(poolID, strategy string) error {
+	switch strategy {
+	case utils.EmptyString, utils.MetaAscending, utils.MetaDescending,
+		utils.MetaRandom, utils.MetaLeastRecentlyUsed:
+		return nil
+	}
+	return fmt.Errorf("pool %q: unsupported strategy %q", poolID, strategy)
+}
+
+// parseIPRange returns the first and last allocatable addresses of rng.
+func parseIPRange(rng string) (first, last netip.Addr, err error) {
+	if start, end, isInterval := strings.Cut(rng, utils.MinusChar); isInterval {
+		if first, err = netip.ParseAddr(strings.TrimSpace(start)); err != nil {
+			return
+		}
+		if last, err = netip.ParseAddr(strings.TrimSpace(end)); err != nil {
+			return
+		}
+		if first.BitLen() != last.BitLen() {
+			err = fmt.Errorf("range %q mixes IPv4 and IPv6 addresses", rng)
+			return
+		}
+		if last.Less(first) {
+			err = fmt.Errorf("range %q ends before it starts", rng)
+		}
+		return
+	}
+	if !strings.Contains(rng, utils.Slash) {
+		if first, err = netip.ParseAddr(rng); err != nil {
+			return
+		}
+		return first, first, nil
+	}
+	var prefix netip.Prefix
+	if prefix, err = netip.ParsePrefix(rng); err != nil {
+		return
+	}
+	prefix = prefix.Masked()
+	first, last = prefix.Addr(), lastAddrInPrefix(prefix)
+	if first.Is4() && prefix.Bits() < 31 { // skip network and broadcast
+		first, last = first.Next(), last.Prev()
+	}
+	return
+}
+
+// parseIPDelegationRange returns the first addresses of the first and last
+// sub-prefixes with length prefixLen carved out of the rng prefix, together
+// with the distance between two consecutive sub-prefixes.
+func parseIPDelegationRange(rng string, prefixLen int) (first, last netip.Addr, step *big.Int, err error) {
+	var prefix netip.Prefix
+	if prefix, err = netip.ParsePrefix(rng); err != nil {
+		return
+	}
+	prefix = prefix.Masked()
+	if prefixLen < prefix.Bits() || prefixLen > prefix.Addr().BitLen() {
+		err = fmt.Errorf("prefix length %d not within range %q", prefixLen, rng)
+		return
+	}
+	step = new(big.Int).Lsh(big.NewInt(1), uint(prefix.Addr().BitLen()-prefixLen))
+	first = prefix.Addr()
+	lastSub, _ := lastAddrInPrefix(prefix).Prefix(prefixLen)
+	return first, lastSub.Addr(), step, nil
+}
+
+// lastAddrInPrefix returns the highest address covered by the (masked) prefix.
+func lastAddrInPrefix(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// addrToBigInt converts an address into its numeric value.
+func addrToBigInt(addr netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(addr.AsSlice())
+}
+
+// bigIntToAddr converts a numeric value back into an address of the given
+// bit length.
+func bigIntToAddr(n *big.Int, bitLen int) netip.Addr {
+	b := make([]byte, bitLen/8)
+	n.FillBytes(b)
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// next returns the unit following addr, invalid when overflowing.
+func (r *ipPoolRange) next(addr netip.Addr) netip.Addr {
+	if r.step == nil {
+		return addr.Next()
+	}
+	n := addrToBigInt(addr)
+	if n.Add(n, r.step).BitLen() > addr.BitLen() {
+		return netip.Addr{}
+	}
+	return bigIntToAddr(n, addr.BitLen())
+}
+
+// prev returns the unit preceding addr, invalid when underflowing.
+func (r *ipPoolRange) prev(addr netip.Addr) netip.Addr {
+	if r.step == nil {
+		return addr.Prev()
+	}
+	n := addrToBigInt(addr)
+	if n.Sub(n, r.step).Sign() < 0 {
+		return netip.Addr{}
+	}
+	return bigIntToAddr(n, addr.BitLen())
+}
+
+// prefix returns the sub-prefix identified by addr, invalid when the pool
+// does not delegate prefixes.
+func (r *ipPoolRange) prefix(addr netip.Addr) netip.Prefix {
+	if r.prefixLen == 0 {
+		return netip.Prefix{}
+	}
+	return netip.PrefixFrom(addr, r.prefixLen)
+}
+
+// reset forgets all allocations, positioning the cursors at the range edges.
+func (r *ipPoolRange) reset() {
+	r.used = 0
+	r.ascNext = r.first
+	r.descNext = r.last
+	r.lruNext = r.first
+	r.released = nil
+}
+
+// contains checks whether the address belongs to the range.
+func (r *ipPoolRange) contains(addr netip.Addr) bool {
+	return addr.BitLen() == r.first.BitLen() &&
+		!addr.Less(r.first) && !r.last.Less(addr)
+}
+
+// isFull checks whether all addresses within the range are allocated.
+func (r *ipPoolRange) isFull() bool {
+	return r.size.IsUint64() && r.used >= r.size.Uint64()
+}
+
+// nextFree returns the next free address according to the pool strategy.
+// It does not mark the address as allocated, see allocate for that.
+func (r *ipPoolRange) nextFree(taken map[netip.Addr]string) (netip.Addr, bool) {
+	if r.isFull() {
+		return netip.Addr{}, false
+	}
+	switch r.strategy {
+	case utils.MetaDescending:
+		return r.nextDescending(taken)
+	case utils.MetaRandom:
+		return r.nextRandom(taken)
+	case utils.MetaLeastRecentlyUsed:
+		return r.nextLeastRecentlyUsed(taken)
+	default:
+		return r.nextAscending(taken)
+	}
+}
+
+// nextAscending returns the lowest free address.
+func (r *ipPoolRange) nextAscending(taken map[netip.Addr]string) (netip.Addr, bool) {
+	if addr, has := r.scanUp(r.ascNext, r.last, taken); has {
+		r.ascNext = addr
+		return addr, true
+	}
+	return r.scanUp(r.first, r.last, taken) // cursor out of sync, should not happen
+}
+
+// nextDescending returns the highest free address.
+func (r *ipPoolRange) nextDescending(taken map[netip.Addr]string) (netip.Addr, bool) {
+	for addr := r.descNext; addr.IsValid() && !addr.Less(r.first); addr = r.prev(addr) {
+		if _, has := taken[addr]; !has {
+			r.descNext = addr
+			return addr, true
+		}
+	}
+	for addr := r.last; addr.IsValid() && !addr.Less(r.first); addr = r.prev(addr) {
+		if _, has := taken[addr]; !has {
+			return addr, true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// nextRandom returns a random free address, falling back to the first free
+// address following the last random pick when the range is crowded.
+func (r *ipPoolRange) nextRandom(taken map[netip.Addr]string) (netip.Addr, bool) {
+	base := addrToBigInt(r.first)
+	var addr netip.Addr
+	for range ipRandomAttempts {
+		offset, err := rand.Int(rand.Reader, r.size)
+		if err != nil {
+			return r.nextAscending(taken)
+		}
+		if r.step != nil {
+			offset.Mul(offset, r.step)
+		}
+		addr = bigIntToAddr(offset.Add(offset, base), r.first.BitLen())
+		if _, has := taken[addr]; !has {
+			return addr, true
+		}
+	}
+	if free, has := r.scanUp(addr, r.last, taken); has {
+		return free, true
+	}
+	return r.scanUp(r.first, addr, taken)
+}
+
+// nextLeastRecentlyUsed prefers addresses never handed out, followed by the
+// ones released the longest time ago.
+func (r *ipPoolRange) nextLeastRecentlyUsed(taken map[netip.Addr]string) (netip.Addr, bool) {
+	if addr, has := r.scanUp(r.lruNext, r.last, taken); has {
+		r.lruNext = addr
+		return addr, true
+	}
+	r.lruNext = netip.Addr{} // all addresses were handed out at least once
+	for len(r.released) != 0 {
+		if _, has := taken[r.released[0]]; !has {
+			return r.released[0], true
+		}
+		r.released = r.released[1:] // stale entry, reallocated since
+	}
+	// released history is not persisted, recover the addresses freed
+	// before a restart
+	return r.nextAscending(taken)
+}
+
+// scanUp returns the first free address within [from, to].
+func (r *ipPoolRange) scanUp(from, to netip.Addr, taken map[netip.Addr]string) (netip.Addr, bool) {
+	for addr := from; addr.IsValid() && !to.Less(addr); addr = r.next(addr) {
+		if _, has := taken[addr]; !has {
+			return addr, true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// allocate marks the address returned by nextFree as allocated.
+func (r *ipPoolRange) allocate(addr netip.Addr) {
+	if !r.contains(addr) {
+		return
+	}
+	r.used++
+	if addr == r.ascNext {
+		r.ascNext = r.next(addr)
+	}
+	if addr == r.descNext {
+		r.descNext = r.prev(addr)
+	}
+	if r.strategy != utils.MetaLeastRecentlyUsed {
+		return
+	}
+	if addr == r.lruNext {
+		r.lruNext = r.next(addr)
+	} else if len(r.released) != 0 && r.released[0] == addr {
+		r.released = r.released[1:]
+	}
+}
+
+// release makes an allocated address available again.
+func (r *ipPoolRange) release(addr netip.Addr) {
+	if !r.contains(addr) {
+		return
+	}
+	if r.used != 0 {
+		r.used--
+	}
+	if !r.ascNext.IsValid() || addr.Less(r.ascNext) {
+		r.ascNext = addr
+	}
+	if !r.descNext.IsValid() || r.descNext.Less(addr) {
+		r.descNext = addr
+	}
+	if r.strategy == utils.MetaLeastRecentlyUsed {
+		r.released = append(r.released, addr)
+	}
+}
+
+// clone returns a deep copy of the range and its cursors.
+func (r *ipPoolRange) clone() *ipPoolRange {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	clone.released = slices.Clone(r.released)
+	return &clone
+}
@@ -0,0 +1,318 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestParseIPRange(t *testing.T) {
+	tests := []struct {
+		rng         string
+		first, last string
+		size        int64
+		wantErr     bool
+	}{
+		{rng: "10.0.0.1", first: "10.0.0.1", last: "10.0.0.1", size: 1},
+		{rng: "10.0.0.1/32", first: "10.0.0.1", last: "10.0.0.1", size: 1},
+		{rng: "10.0.0.0/30", first: "10.0.0.1", last: "10.0.0.2", size: 2},
+		{rng: "10.0.0.0/31", first: "10.0.0.0", last: "10.0.0.1", size: 2},
+		{rng: "10.0.0.7/22", first: "10.0.0.1", last: "10.0.3.254", size: 1022},
+		{rng: "10.0.0.10-10.0.0.19", first: "10.0.0.10", last: "10.0.0.19", size: 10},
+		{rng: "2001:db8::/120", first: "2001:db8::", last: "2001:db8::ff", size: 256},
+		{rng: "2001:db8::a - 2001:db8::f", first: "2001:db8::a", last: "2001:db8::f", size: 6},
+		{rng: "10.0.0.19-10.0.0.10", wantErr: true},
+		{rng: "10.0.0.1-2001:db8::1", wantErr: true},
+		{rng: "10.0.0.0/33", wantErr: true},
+		{rng: "not_an_ip", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rng, func(t *testing.T) {
+			r, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: tt.rng})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, received nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.first.String() != tt.first || r.last.String() != tt.last {
+				t.Errorf("expected range %s-%s, received %s-%s", tt.first, tt.last, r.first, r.last)
+			}
+			if r.size.Int64() != tt.size {
+				t.Errorf("expected size %d, received %s", tt.size, r.size)
+			}
+		})
+	}
+}
+
+func TestIPPoolRangeUnsupportedStrategy(t *testing.T) {
+	if _, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: "10.0.0.1/32",
+		Strategy: "*unknown"}); err == nil {
+		t.Error("expected error for unsupported strategy")
+	}
+	expErr := `pool "POOL1": unsupported strategy "*ascendin"`
+	if err := CheckIPPoolStrategy("POOL1", "*ascendin"); err == nil || err.Error() != expErr {
+		t.Errorf("expected error %q, received %v", expErr, err)
+	}
+	if err := CheckIPPoolStrategy("POOL1", utils.MetaLeastRecentlyUsed); err != nil {
+		t.Error(err)
+	}
+}
+
+// allocateAll allocates addresses until the range is exhausted, returning
+// them in allocation order.
+func allocateAll(t *testing.T, r *ipPoolRange, taken map[netip.Addr]string) []string {
+	t.Helper()
+	var addrs []string
+	for {
+		addr, ok := r.nextFree(taken)
+		if !ok {
+			return addrs
+		}
+		if _, has := taken[addr]; has {
+			t.Fatalf("address %s returned while allocated", addr)
+		}
+		taken[addr] = "alloc"
+		r.allocate(addr)
+		addrs = append(addrs, addr.String())
+	}
+}
+
+func TestIPPoolRangeStrategies(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     []string
+	}{
+		{strategy: utils.MetaAscending,
+			want: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"}},
+		{strategy: utils.MetaDescending,
+			want: []string{"10.0.0.6", "10.0.0.5", "10.0.0.4", "10.0.0.3", "10.0.0.2", "10.0.0.1"}},
+		{strategy: utils.MetaLeastRecentlyUsed,
+			want: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			r, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: "10.0.0.0/29", Strategy: tt.strategy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := allocateAll(t, r, make(map[netip.Addr]string))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, received %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIPPoolRangeRandom(t *testing.T) {
+	r, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: "10.0.0.0/28", Strategy: utils.MetaRandom})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := allocateAll(t, r, make(map[netip.Addr]string))
+	if len(got) != 14 {
+		t.Errorf("expected 14 addresses, received %d: %v", len(got), got)
+	}
+}
+
+func TestIPPoolRangeReleaseReuse(t *testing.T) {
+	taken := make(map[netip.Addr]string)
+	r, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: "10.0.0.1-10.0.0.4",
+		Strategy: utils.MetaLeastRecentlyUsed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocateAll(t, r, taken)
+	for _, rel := range []string{"10.0.0.3", "10.0.0.1"} {
+		addr := netip.MustParseAddr(rel)
+		delete(taken, addr)
+		r.release(addr)
+	}
+	if got, want := allocateAll(t, r, taken), []string{"10.0.0.3", "10.0.0.1"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+
+	r.strategy = utils.MetaAscending
+	for _, rel := range []string{"10.0.0.4", "10.0.0.2"} {
+		addr := netip.MustParseAddr(rel)
+		delete(taken, addr)
+		r.release(addr)
+	}
+	if got, want := allocateAll(t, r, taken), []string{"10.0.0.2", "10.0.0.4"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+}
+
+func TestIPPoolRangeLargeIPv6(t *testing.T) {
+	r, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: "2001:db8::/64", Strategy: utils.MetaRandom})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.isFull() {
+		t.Fatal("expected /64 range not to be full")
+	}
+	addr, ok := r.nextFree(nil)
+	if !ok || !r.contains(addr) {
+		t.Errorf("expected address within range, received %s", addr)
+	}
+}
+
+func TestIPAllocationsAllocateOnCIDRPool(t *testing.T) {
+	prfl := &IPProfile{
+		Tenant: "cgrates.org",
+		ID:     "IPS1",
+		TTL:    time.Hour,
+		Pools: []*IPPool{
+			{
+				ID:       "POOL1",
+				Type:     "*ipv4",
+				Range:    "192.168.0.0/30",
+				Strategy: utils.MetaAscending,
+			},
+		},
+	}
+	allocs := &IPAllocations{
+		Tenant:      "cgrates.org",
+		ID:          "IPS1",
+		Allocations: make(map[string]*PoolAllocation),
+	}
+	if err := allocs.computeUnexported(prfl); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"192.168.0.1", "192.168.0.2"} {
+		allocIP, err := allocs.allocateIPOnPool(utils.GenUUID(), prfl.Pools[0], false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allocIP.Address.String() != want {
+			t.Errorf("allocation %d: expected %s, received %s", i, want, allocIP.Address)
+		}
+	}
+	if len(allocs.TTLIndex) != 2 {
+		t.Errorf("expected 2 entries in TTLIndex, received %v", allocs.TTLIndex)
+	}
+	if _, err := allocs.allocateIPOnPool("alloc3", prfl.Pools[0], false); !errors.Is(err, utils.ErrIPAlreadyAllocated) {
+		t.Errorf("expected %v, received %v", utils.ErrIPAlreadyAllocated, err)
+	}
+
+	// releasing frees the address for the next allocation
+	allocID := allocs.poolAllocs["POOL1"][netip.MustParseAddr("192.168.0.1")]
+	if err := allocs.releaseAllocation(allocID); err != nil {
+		t.Fatal(err)
+	}
+	allocIP, err := allocs.allocateIPOnPool("alloc3", prfl.Pools[0], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocIP.Address.String() != "192.168.0.1" {
+		t.Errorf("expected 192.168.0.1, received %s", allocIP.Address)
+	}
+
+	// restored allocations are accounted for by the pool cursors
+	restored := &IPAllocations{
+		Tenant:      "cgrates.org",
+		ID:          "IPS1",
+		Allocations: allocs.Allocations,
+	}
+	if err := restored.computeUnexported(prfl); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restored.allocateIPOnPool("alloc4", prfl.Pools[0], true); !errors.Is(err, utils.ErrIPAlreadyAllocated) {
+		t.Errorf("expected %v, received %v", utils.ErrIPAlreadyAllocated, err)
+	}
+}
+
+func TestIPPoolRangePrefixDelegation(t *testing.T) {
+	r, err := newIPPoolRange(&IPPool{ID: "POOL1", Range: "2001:db8:100::/40",
+		PrefixLength: 56, Strategy: utils.MetaAscending})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.size.Int64() != 1<<16 {
+		t.Errorf("expected %d sub-prefixes, received %s", 1<<16, r.size)
+	}
+	if got := r.last.String(); got != "2001:db8:1ff:ff00::" {
+		t.Errorf("expected last sub-prefix at 2001:db8:1ff:ff00::, received %s", got)
+	}
+	taken := make(map[netip.Addr]string)
+	var got []string
+	for range 2 {
+		addr, ok := r.nextFree(taken)
+		if !ok {
+			t.Fatal("expected free sub-prefix")
+		}
+		taken[addr] = "alloc"
+		r.allocate(addr)
+		got = append(got, r.prefix(addr).String())
+	}
+	if want := []string{"2001:db8:100::/56", "2001:db8:100:100::/56"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+
+	r.strategy = utils.MetaDescending
+	if addr, _ := r.nextFree(taken); r.prefix(addr).String() != "2001:db8:1ff:ff00::/56" {
+		t.Errorf("expected 2001:db8:1ff:ff00::/56, received %s", r.prefix(addr))
+	}
+
+	r.strategy = utils.MetaRandom
+	addr, ok := r.nextFree(taken)
+	if !ok || !r.contains(addr) || r.prefix(addr).Masked().Addr() != addr {
+		t.Errorf("expected aligned sub-prefix within range, received %s", r.prefix(addr))
+	}
+
+	for _, pool := range []*IPPool{
+		{ID: "POOL2", Range: "2001:db8:100::/40", PrefixLength: 32},
+		{ID: "POOL3", Range: "2001:db8:100::/40", PrefixLength: 129},
+		{ID: "POOL4", Range: "2001:db8::1-2001:db8::5", PrefixLength: 64},
+	} {
+		if _, err := newIPPoolRange(pool); err == nil {
+			t.Errorf("expected error for pool %s", pool.ID)
+		}
+	}
+}
+
+func TestAllocatedIPAsNavigableMapPrefix(t *testing.T) {
+	allocIP := &AllocatedIP{
+		ProfileID: "IPS1",
+		PoolID:    "POOL1",
+		Message:   "delegated",
+		Address:   netip.MustParseAddr("2001:db8:100::"),
+		Prefix:    netip.MustParsePrefix("2001:db8:100::/56"),
+	}
+	nm := allocIP.AsNavigableMap()
+	if nm[utils.Prefix] == nil || nm[utils.Prefix].Value.Data != "2001:db8:100::/56" {
+		t.Errorf("expected Prefix leaf with 2001:db8:100::/56, received %+v", nm[utils.Prefix])
+	}
+	if want := "IPS1:POOL1:delegated:2001:db8:100::/56"; allocIP.Digest() != want {
+		t.Errorf("expected digest %q, received %q", want, allocIP.Digest())
+	}
+	allocIP.Prefix = netip.Prefix{}
+	if _, has := allocIP.AsNavigableMap()[utils.Prefix]; has {
+		t.Error("expected no Prefix leaf for single address allocations")
+	}
+}
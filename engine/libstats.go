@@ -38,6 +38,7 @@ type StatQueueProfile struct {
 	ActivationInterval *utils.ActivationInterval // Activation interval
 	QueueLength        int
 	TTL                time.Duration
+	BucketInterval     time.Duration // width of the time buckets aggregating the events within TTL, 0 to queue each event
 	MinItems           int
 	Metrics            []*MetricWithFilters // list of metrics to build
 	Stored             bool
@@ -54,14 +55,15 @@ func (sqp *StatQueueProfile) Clone() *StatQueueProfile {
 		return nil
 	}
 	result := &StatQueueProfile{
-		Tenant:      sqp.Tenant,
-		ID:          sqp.ID,
-		QueueLength: sqp.QueueLength,
-		TTL:         sqp.TTL,
-		MinItems:    sqp.MinItems,
-		Stored:      sqp.Stored,
-		Blocker:     sqp.Blocker,
-		Weight:      sqp.Weight,
+		Tenant:         sqp.Tenant,
+		ID:             sqp.ID,
+		QueueLength:    sqp.QueueLength,
+		TTL:            sqp.TTL,
+		BucketInterval: sqp.BucketInterval,
+		MinItems:       sqp.MinItems,
+		Stored:         sqp.Stored,
+		Blocker:        sqp.Blocker,
+		Weight:         sqp.Weight,
 	}
 	if sqp.FilterIDs != nil {
 		result.FilterIDs = make([]string, len(sqp.FilterIDs))
@@ -83,6 +85,48 @@ func (sqp *StatQueueProfile) Clone() *StatQueueProfile {
 	return result
 }
 
+// checkBucketInterval validates the time buckets settings, the buckets sliding over TTL
+// and all the metrics needing to be mergeable
+func (sqp *StatQueueProfile) checkBucketInterval() error {
+	if sqp.BucketInterval == 0 {
+		return sqp.checkSketchMetrics()
+	}
+	if sqp.BucketInterval < 0 || sqp.TTL < sqp.BucketInterval {
+		return fmt.Errorf("invalid BucketInterval <%s> for TTL <%s>", sqp.BucketInterval, sqp.TTL)
+	}
+	for _, metric := range sqp.Metrics {
+		sm, err := NewStatMetric(metric.MetricID, 0, nil)
+		if err != nil {
+			return err
+		}
+		if _, canMerge := sm.(statMetricMerger); !canMerge {
+			return fmt.Errorf("metric <%s> cannot be aggregated into time buckets", metric.MetricID)
+		}
+	}
+	return nil
+}
+
+// checkSketchMetrics rejects the sketch metrics within the queues removing their events
+// one by one, the sketches being unable to forget single values
+func (sqp *StatQueueProfile) checkSketchMetrics() error {
+	if sqp.TTL == -1 || (sqp.TTL == 0 && sqp.QueueLength == 0) { // events are never removed
+		return nil
+	}
+	for _, metric := range sqp.Metrics {
+		sm, err := NewStatMetric(metric.MetricID, 0, nil)
+		if err != nil {
+			continue // reported when building the queue
+		}
+		if anomaly, isAnomaly := sm.(*StatAnomaly); isAnomaly {
+			sm = anomaly.Metric
+		}
+		if _, isSketch := sm.(statMetricSketch); isSketch {
+			return fmt.Errorf("metric <%s> needs a BucketInterval to expire its events", metric.MetricID)
+		}
+	}
+	return nil
+}
+
 // CacheClone returns a clone of StatQueueProfile used by ltcache CacheCloner
 func (sqp *StatQueueProfile) CacheClone() any {
 	return sqp.Clone()
@@ -169,6 +213,23 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 		}
 		sSQ.SQMetrics[metricID] = marshaled
 	}
+	if len(sq.SQBuckets) != 0 {
+		sSQ.SQBuckets = make([]*StoredSQBucket, len(sq.SQBuckets))
+		for i, bkt := range sq.SQBuckets {
+			sSQ.SQBuckets[i] = &StoredSQBucket{
+				StartTime:  bkt.StartTime,
+				ExpiryTime: bkt.ExpiryTime,
+				SQMetrics:  make(map[string][]byte, len(bkt.SQMetrics)),
+			}
+			for metricID, metric := range bkt.SQMetrics {
+				marshaled, err := metric.Marshal(ms)
+				if err != nil {
+					return nil, err
+				}
+				sSQ.SQBuckets[i].SQMetrics[metricID] = marshaled
+			}
+		}
+	}
 	return
 }
 
@@ -178,9 +239,17 @@ type StoredStatQueue struct {
 	ID         string
 	SQItems    []SQItem
 	SQMetrics  map[string][]byte
+	SQBuckets  []*StoredSQBucket
 	Compressed bool
 }
 
+// StoredSQBucket differs from SQBucket due to serialization of SQMetrics
+type StoredSQBucket struct {
+	StartTime  time.Time
+	ExpiryTime time.Time
+	SQMetrics  map[string][]byte
+}
+
 type StatQueueWithAPIOpts struct {
 	*StatQueue
 	APIOpts map[string]any
@@ -215,6 +284,26 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 		}
 		sq.SQMetrics[metricID] = metric
 	}
+	if len(ssq.SQBuckets) != 0 {
+		sq.SQBuckets = make([]*SQBucket, len(ssq.SQBuckets))
+		for i, sBkt := range ssq.SQBuckets {
+			sq.SQBuckets[i] = &SQBucket{
+				StartTime:  sBkt.StartTime,
+				ExpiryTime: sBkt.ExpiryTime,
+				SQMetrics:  make(map[string]StatMetric, len(sBkt.SQMetrics)),
+			}
+			for metricID, marshaled := range sBkt.SQMetrics {
+				metric, err := NewStatMetric(metricID, 0, nil)
+				if err != nil {
+					return nil, err
+				}
+				if err := metric.LoadMarshaled(ms, marshaled); err != nil {
+					return nil, err
+				}
+				sq.SQBuckets[i].SQMetrics[metricID] = metric
+			}
+		}
+	}
 	if ssq.Compressed {
 		sq.Expand()
 	}
@@ -226,6 +315,33 @@ type SQItem struct {
 	ExpiryTime *time.Time // Used to auto-expire events
 }
 
+// SQBucket aggregates the events of a StatQueue received within a time interval
+type SQBucket struct {
+	StartTime  time.Time
+	ExpiryTime time.Time             // Used to auto-expire the whole bucket
+	SQMetrics  map[string]StatMetric // metrics of the events within the bucket
+}
+
+// Clone clones *SQBucket
+func (bkt *SQBucket) Clone() *SQBucket {
+	if bkt == nil {
+		return nil
+	}
+	result := &SQBucket{
+		StartTime:  bkt.StartTime,
+		ExpiryTime: bkt.ExpiryTime,
+	}
+	if bkt.SQMetrics != nil {
+		result.SQMetrics = make(map[string]StatMetric, len(bkt.SQMetrics))
+		for k, m := range bkt.SQMetrics {
+			if m != nil {
+				result.SQMetrics[k] = m.Clone()
+			}
+		}
+	}
+	return result
+}
+
 func NewStatQueue(tnt, id string, metrics []*MetricWithFilters, minItems int) (sq *StatQueue, err error) {
 	sq = &StatQueue{
 		Tenant:    tnt,
@@ -248,7 +364,8 @@ type StatQueue struct {
 	ID        string
 	SQItems   []SQItem
 	SQMetrics map[string]StatMetric
-	lkID      string // ID of the lock used when matching the stat
+	SQBuckets []*SQBucket // replace SQItems when the profile aggregates into time buckets
+	lkID      string      // ID of the lock used when matching the stat
 	sqPrfl    *StatQueueProfile
 	dirty     *bool          // needs save
 	ttl       *time.Duration // timeToLeave, picked on each init
@@ -282,6 +399,12 @@ func (sq *StatQueue) Clone() *StatQueue {
 			}
 		}
 	}
+	if sq.SQBuckets != nil {
+		result.SQBuckets = make([]*SQBucket, len(sq.SQBuckets))
+		for i, bkt := range sq.SQBuckets {
+			result.SQBuckets[i] = bkt.Clone()
+		}
+	}
 	if sq.sqPrfl != nil {
 		result.sqPrfl = sq.sqPrfl.Clone()
 	}
@@ -343,6 +466,10 @@ func (sq *StatQueue) ProcessEvent(tnt, evID string, filterS *FilterS, evNm utils
 	if oneEv := sq.isOneEvent(); oneEv {
 		return sq.addOneEvent(tnt, filterS, evNm)
 	}
+	if sq.sqPrfl.BucketInterval > 0 {
+		sq.remExpired()
+		return sq.addBucketEvent(tnt, filterS, evNm)
+	}
 	sq.remExpired()
 	sq.remOnQueueLength()
 	return sq.addStatEvent(tnt, evID, filterS, evNm)
@@ -381,6 +508,9 @@ func (sq *StatQueue) remEventWithID(evID string) {
 
 // remExpired expires items in queue
 func (sq *StatQueue) remExpired() (removed int) {
+	if len(sq.SQBuckets) != 0 {
+		return sq.remExpiredBuckets()
+	}
 	var expIdx *int // index of last item to be expired
 	for i, item := range sq.SQItems {
 		if item.ExpiryTime == nil {
@@ -400,6 +530,43 @@ func (sq *StatQueue) remExpired() (removed int) {
 	return
 }
 
+// remExpiredBuckets drops the aged buckets, rebuilding the metrics out of the remaining ones
+func (sq *StatQueue) remExpiredBuckets() (removed int) {
+	now := time.Now()
+	for removed < len(sq.SQBuckets) &&
+		!sq.SQBuckets[removed].ExpiryTime.After(now) { // buckets are ordered
+		removed++
+	}
+	if removed == 0 {
+		return
+	}
+	sq.SQBuckets = sq.SQBuckets[removed:]
+	sq.mergeBuckets()
+	return
+}
+
+// mergeBuckets recreates the metrics of the queue out of the metrics of its buckets
+func (sq *StatQueue) mergeBuckets() {
+	for metricID, metric := range sq.SQMetrics {
+		merged, err := NewStatMetric(metricID, metric.GetMinItems(), metric.GetFilterIDs())
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, merge buckets, error: %s",
+				metricID, err.Error()))
+			continue
+		}
+		merger, canMerge := merged.(statMetricMerger)
+		if !canMerge {
+			continue
+		}
+		for _, bkt := range sq.SQBuckets {
+			if bktMetric, has := bkt.SQMetrics[metricID]; has {
+				merger.Merge(bktMetric)
+			}
+		}
+		sq.SQMetrics[metricID] = merged
+	}
+}
+
 // remOnQueueLength removes elements based on QueueLength setting
 func (sq *StatQueue) remOnQueueLength() {
 	if sq.sqPrfl.QueueLength <= 0 { // infinite length
@@ -439,6 +606,53 @@ func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils
 	return
 }
 
+// addBucketEvent computes metrics for an event, within both the queue and its current bucket
+func (sq *StatQueue) addBucketEvent(tnt string, filterS *FilterS, evNm utils.MapStorage) (err error) {
+	bktStart := time.Now().Truncate(sq.sqPrfl.BucketInterval)
+	var bkt *SQBucket
+	if len(sq.SQBuckets) != 0 &&
+		sq.SQBuckets[len(sq.SQBuckets)-1].StartTime.Equal(bktStart) {
+		bkt = sq.SQBuckets[len(sq.SQBuckets)-1]
+	} else {
+		bkt = &SQBucket{
+			StartTime:  bktStart,
+			ExpiryTime: bktStart.Add(sq.sqPrfl.TTL),
+			SQMetrics:  make(map[string]StatMetric),
+		}
+		sq.SQBuckets = append(sq.SQBuckets, bkt)
+	}
+	var pass bool
+	// recreate the request without *opts
+	dDP := newDynamicDP(config.CgrConfig().FilterSCfg().ResourceSConns, config.CgrConfig().FilterSCfg().StatSConns,
+		config.CgrConfig().FilterSCfg().ApierSConns, config.CgrConfig().FilterSCfg().TrendSConns, config.CgrConfig().FilterSCfg().RankingSConns, tnt, utils.MapStorage{utils.MetaReq: evNm[utils.MetaReq]})
+	for metricID, metric := range sq.SQMetrics {
+		if pass, err = filterS.Pass(tnt, metric.GetFilterIDs(),
+			evNm); err != nil {
+			return
+		} else if !pass {
+			continue
+		}
+		bktMetric, has := bkt.SQMetrics[metricID]
+		if !has {
+			if bktMetric, err = NewStatMetric(metricID, 0, nil); err != nil {
+				return
+			}
+			bkt.SQMetrics[metricID] = bktMetric
+		}
+		if err = bktMetric.AddOneEvent(dDP); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, bucket event, error: %s",
+				metricID, err.Error()))
+			return
+		}
+		if err = metric.AddOneEvent(dDP); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, bucket event, error: %s",
+				metricID, err.Error()))
+			return
+		}
+	}
+	return
+}
+
 func (sq *StatQueue) Compress(maxQL int64, roundDec int) bool {
 	if int64(len(sq.SQItems)) < maxQL || maxQL == 0 {
 		return false
@@ -534,6 +748,11 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 		ID        string
 		SQItems   []SQItem
 		SQMetrics map[string]json.RawMessage
+		SQBuckets []*struct {
+			StartTime  time.Time
+			ExpiryTime time.Time
+			SQMetrics  map[string]json.RawMessage
+		}
 	}
 	if err = json.Unmarshal(data, &tmp); err != nil {
 		return
@@ -541,8 +760,28 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 	sq.Tenant = tmp.Tenant
 	sq.ID = tmp.ID
 	sq.SQItems = tmp.SQItems
-	sq.SQMetrics = make(map[string]StatMetric)
-	for metricID, val := range tmp.SQMetrics {
+	if sq.SQMetrics, err = unmarshalStatMetrics(tmp.SQMetrics); err != nil {
+		return
+	}
+	if tmp.SQBuckets != nil {
+		sq.SQBuckets = make([]*SQBucket, len(tmp.SQBuckets))
+		for i, bkt := range tmp.SQBuckets {
+			sq.SQBuckets[i] = &SQBucket{
+				StartTime:  bkt.StartTime,
+				ExpiryTime: bkt.ExpiryTime,
+			}
+			if sq.SQBuckets[i].SQMetrics, err = unmarshalStatMetrics(bkt.SQMetrics); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// unmarshalStatMetrics decodes the JSON metrics based on the type within their ID
+func unmarshalStatMetrics(rawMetrics map[string]json.RawMessage) (metrics map[string]StatMetric, err error) {
+	metrics = make(map[string]StatMetric)
+	for metricID, val := range rawMetrics {
 		metricSplit := strings.Split(metricID, utils.HashtagSep)
 		var metric StatMetric
 		switch metricSplit[0] {
@@ -574,13 +813,19 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 			metric = new(StatREPSC)
 		case utils.MetaREPFC:
 			metric = new(StatREPFC)
+		case utils.MetaPercentile, utils.MetaP50, utils.MetaP95, utils.MetaP99:
+			metric = new(StatPercentile)
+		case utils.MetaHistogram:
+			metric = new(StatHistogram)
+		case utils.MetaAnomaly:
+			metric = new(StatAnomaly)
 		default:
-			return fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
+			return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 		}
 		if err = json.Unmarshal([]byte(val), metric); err != nil {
-			return
+			return nil, err
 		}
-		sq.SQMetrics[metricID] = metric
+		metrics[metricID] = metric
 	}
 	return
 }
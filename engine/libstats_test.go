@@ -1185,6 +1185,223 @@ func TestStatQueueUnmarshalJSON(t *testing.T) {
 
 }
 
+func TestStatQueueBuckets(t *testing.T) {
+	sqPrfl := &StatQueueProfile{
+		Tenant:         "cgrates.org",
+		ID:             "STS_BKT",
+		TTL:            3 * time.Hour,
+		BucketInterval: time.Hour,
+		Metrics: []*MetricWithFilters{
+			{MetricID: utils.MetaSum + utils.HashtagSep + "~*req.Cost"},
+			{MetricID: utils.MetaHighest + utils.HashtagSep + "~*req.Cost"},
+			{MetricID: utils.MetaDDC + utils.HashtagSep + "~*req.Destination"},
+			{MetricID: utils.MetaHistogram + utils.HashtagSep + "~*req.Cost#20"},
+		},
+	}
+	if err := sqPrfl.checkBucketInterval(); err != nil {
+		t.Fatal(err)
+	}
+	sq, err := NewStatQueue(sqPrfl.Tenant, sqPrfl.ID, sqPrfl.Metrics, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sq.sqPrfl = sqPrfl
+	sq.ttl = utils.DurationPointer(sqPrfl.TTL)
+	processEv := func(cost float64, dest string) {
+		t.Helper()
+		if err := sq.ProcessEvent("cgrates.org", "ev", nil, utils.MapStorage{
+			utils.MetaReq: map[string]any{"Cost": cost, "Destination": dest}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkMetrics := func(sum, highest, ddc float64) {
+		t.Helper()
+		for metricID, exp := range map[string]float64{
+			utils.MetaSum + utils.HashtagSep + "~*req.Cost":        sum,
+			utils.MetaHighest + utils.HashtagSep + "~*req.Cost":    highest,
+			utils.MetaDDC + utils.HashtagSep + "~*req.Destination": ddc,
+		} {
+			if rcv := sq.SQMetrics[metricID].GetFloat64Value(2); rcv != exp {
+				t.Errorf("expected %s %v, received %v", metricID, exp, rcv)
+			}
+		}
+	}
+
+	processEv(10, "1001")
+	processEv(30, "1002")
+	if len(sq.SQItems) != 0 {
+		t.Errorf("expected no queue items, received %d", len(sq.SQItems))
+	}
+	if len(sq.SQBuckets) != 1 {
+		t.Fatalf("expected 1 bucket, received %d", len(sq.SQBuckets))
+	}
+	if exp := sq.SQBuckets[0].StartTime.Add(sqPrfl.TTL); !sq.SQBuckets[0].ExpiryTime.Equal(exp) {
+		t.Errorf("expected bucket expiry %v, received %v", exp, sq.SQBuckets[0].ExpiryTime)
+	}
+	checkMetrics(40, 30, 2)
+
+	// age the first bucket so the next event opens a new one
+	sq.SQBuckets[0].StartTime = sq.SQBuckets[0].StartTime.Add(-time.Hour)
+	sq.SQBuckets[0].ExpiryTime = sq.SQBuckets[0].ExpiryTime.Add(-time.Hour)
+	processEv(5, "1001")
+	if len(sq.SQBuckets) != 2 {
+		t.Fatalf("expected 2 buckets, received %d", len(sq.SQBuckets))
+	}
+	checkMetrics(45, 30, 2)
+
+	// expire the first bucket, the metrics being rebuilt out of the remaining one
+	sq.SQBuckets[0].ExpiryTime = time.Now().Add(-time.Second)
+	if removed := sq.remExpired(); removed != 1 {
+		t.Errorf("expected 1 bucket removed, received %d", removed)
+	}
+	if len(sq.SQBuckets) != 1 {
+		t.Fatalf("expected 1 bucket, received %d", len(sq.SQBuckets))
+	}
+	checkMetrics(5, 5, 1)
+	// the histogram forgets the expired values together with their bucket
+	if rcv, exp := sq.SQMetrics[utils.MetaHistogram+utils.HashtagSep+"~*req.Cost#20"].GetStringValue(0),
+		"20:1;+Inf:1"; rcv != exp {
+		t.Errorf("expected %s, received %s", exp, rcv)
+	}
+
+	sq.SQBuckets[0].ExpiryTime = time.Now().Add(-time.Second)
+	sq.remExpired()
+	checkMetrics(0, utils.StatsNA, utils.StatsNA)
+}
+
+func TestStatQueueBucketsStored(t *testing.T) {
+	ms, err := NewMarshaler(utils.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqPrfl := &StatQueueProfile{
+		Tenant:         "cgrates.org",
+		ID:             "STS_BKT",
+		TTL:            time.Hour,
+		BucketInterval: time.Minute,
+		Metrics: []*MetricWithFilters{
+			{MetricID: utils.MetaTCC},
+			{MetricID: utils.MetaP95 + utils.HashtagSep + "~*req.Cost"},
+		},
+	}
+	sq, err := NewStatQueue(sqPrfl.Tenant, sqPrfl.ID, sqPrfl.Metrics, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sq.sqPrfl = sqPrfl
+	sq.ttl = utils.DurationPointer(sqPrfl.TTL)
+	for i := 1; i <= 3; i++ {
+		if err := sq.ProcessEvent("cgrates.org", "ev", nil, utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Cost: float64(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ssq, err := NewStoredStatQueue(sq, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ssq.SQBuckets) != 1 {
+		t.Fatalf("expected 1 stored bucket, received %d", len(ssq.SQBuckets))
+	}
+	rcv, err := ssq.AsStatQueue(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rcv.SQBuckets) != 1 ||
+		!rcv.SQBuckets[0].StartTime.Equal(sq.SQBuckets[0].StartTime) ||
+		!rcv.SQBuckets[0].ExpiryTime.Equal(sq.SQBuckets[0].ExpiryTime) {
+		t.Fatalf("expected buckets %s, received %s", utils.ToJSON(sq.SQBuckets), utils.ToJSON(rcv.SQBuckets))
+	}
+	for metricID, metric := range sq.SQMetrics {
+		if exp, rcv := metric.GetStringValue(2), rcv.SQBuckets[0].SQMetrics[metricID].GetStringValue(2); exp != rcv {
+			t.Errorf("expected %s %s, received %s", metricID, exp, rcv)
+		}
+	}
+
+	var rcvJSON *StatQueue
+	if err = json.Unmarshal([]byte(utils.ToJSON(sq)), &rcvJSON); err != nil {
+		t.Fatal(err)
+	}
+	if exp := utils.ToJSON(sq); utils.ToJSON(rcvJSON) != exp {
+		t.Errorf("expected %s, received %s", exp, utils.ToJSON(rcvJSON))
+	}
+
+	// queues stored before the buckets were introduced keep working on items
+	ssq.SQBuckets = nil
+	if rcv, err = ssq.AsStatQueue(ms); err != nil {
+		t.Fatal(err)
+	} else if rcv.SQBuckets != nil {
+		t.Errorf("expected no buckets, received %s", utils.ToJSON(rcv.SQBuckets))
+	}
+}
+
+func TestStatQueueProfileCheckBucketInterval(t *testing.T) {
+	sqPrfl := &StatQueueProfile{
+		TTL:            time.Minute,
+		BucketInterval: time.Hour,
+		Metrics:        []*MetricWithFilters{{MetricID: utils.MetaASR}},
+	}
+	experr := "invalid BucketInterval <1h0m0s> for TTL <1m0s>"
+	if err := sqPrfl.checkBucketInterval(); err == nil || err.Error() != experr {
+		t.Errorf("expected error <%s>, received <%v>", experr, err)
+	}
+	sqPrfl.TTL = 24 * time.Hour
+	if err := sqPrfl.checkBucketInterval(); err != nil {
+		t.Error(err)
+	}
+	sqPrfl.Metrics = append(sqPrfl.Metrics, &MetricWithFilters{MetricID: "*anomaly#*asr"})
+	experr = "metric <*anomaly#*asr> cannot be aggregated into time buckets"
+	if err := sqPrfl.checkBucketInterval(); err == nil || err.Error() != experr {
+		t.Errorf("expected error <%s>, received <%v>", experr, err)
+	}
+	sqPrfl.Metrics[1] = &MetricWithFilters{MetricID: "*unknown"}
+	if err := sqPrfl.checkBucketInterval(); err == nil {
+		t.Error("expected error for the unsupported metric")
+	}
+	sqPrfl.BucketInterval = 0
+	if err := sqPrfl.checkBucketInterval(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStatQueueProfileCheckSketchMetrics(t *testing.T) {
+	sqPrfl := &StatQueueProfile{
+		TTL:            time.Hour,
+		BucketInterval: time.Minute,
+		Metrics: []*MetricWithFilters{
+			{MetricID: utils.MetaASR},
+			{MetricID: "*p95#~*req.Usage"},
+			{MetricID: "*histogram#~*req.Usage#10|60"},
+		},
+	}
+	if err := sqPrfl.checkBucketInterval(); err != nil {
+		t.Error(err)
+	}
+	// without buckets the sketches cannot forget the expired events
+	sqPrfl.BucketInterval = 0
+	experr := "metric <*p95#~*req.Usage> needs a BucketInterval to expire its events"
+	if err := sqPrfl.checkBucketInterval(); err == nil || err.Error() != experr {
+		t.Errorf("expected error <%s>, received <%v>", experr, err)
+	}
+	sqPrfl.Metrics = []*MetricWithFilters{{MetricID: "*anomaly#*histogram#~*req.Usage#10|60"}}
+	sqPrfl.TTL = 0
+	sqPrfl.QueueLength = 100
+	experr = "metric <*anomaly#*histogram#~*req.Usage#10|60> needs a BucketInterval to expire its events"
+	if err := sqPrfl.checkBucketInterval(); err == nil || err.Error() != experr {
+		t.Errorf("expected error <%s>, received <%v>", experr, err)
+	}
+	// the events are never removed
+	sqPrfl.QueueLength = 0
+	if err := sqPrfl.checkBucketInterval(); err != nil {
+		t.Error(err)
+	}
+	sqPrfl.TTL = -1
+	sqPrfl.QueueLength = 100
+	if err := sqPrfl.checkBucketInterval(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestLibRoutesRouteIDs(t *testing.T) {
 	sortedRoutesList := SortedRoutesList{
 		{
@@ -132,18 +132,22 @@ func CsvDump(s any) ([]string, error) {
 	return result, nil
 }
 
-func getColumnCount(s any) int {
+// getColumnCount returns the number of csv columns of the model and how many of them are required,
+// the optional ones being appended after the required ones so older csv files still load
+func getColumnCount(s any) (count, required int) {
 	st := reflect.TypeOf(s)
 	numFields := st.NumField()
-	count := 0
 	for i := 0; i < numFields; i++ {
 		field := st.Field(i)
 		index := field.Tag.Get("index")
 		if index != utils.EmptyString {
 			count++
+			if field.Tag.Get("optional") != "true" {
+				required++
+			}
 		}
 	}
-	return count
+	return
 }
 
 type DestinationMdls []DestinationMdl
@@ -1029,7 +1033,7 @@ type ResourceMdls []*ResourceMdl
 func (tps ResourceMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.UsageTTL, utils.Limit, utils.AllocationMessage, utils.Blocker, utils.Stored,
-		utils.Weight, utils.ThresholdIDs}
+		utils.Weight, utils.ThresholdIDs, utils.RateInterval, utils.Burst, utils.ParentID}
 }
 
 func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
@@ -1057,6 +1061,15 @@ func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
 		if tp.Limit != utils.EmptyString {
 			rl.Limit = tp.Limit
 		}
+		if tp.RateInterval != utils.EmptyString {
+			rl.RateInterval = tp.RateInterval
+		}
+		if tp.Burst != utils.EmptyString {
+			rl.Burst = tp.Burst
+		}
+		if tp.ParentID != utils.EmptyString {
+			rl.ParentID = tp.ParentID
+		}
 		if tp.AllocationMessage != utils.EmptyString {
 			rl.AllocationMessage = tp.AllocationMessage
 		}
@@ -1113,6 +1126,9 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			Weight:            rl.Weight,
 			Limit:             rl.Limit,
 			AllocationMessage: rl.AllocationMessage,
+			RateInterval:      rl.RateInterval,
+			Burst:             rl.Burst,
+			ParentID:          rl.ParentID,
 		}
 		if rl.ActivationInterval != nil {
 			if rl.ActivationInterval.ActivationTime != utils.EmptyString {
@@ -1143,6 +1159,9 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			mdl.Weight = rl.Weight
 			mdl.Limit = rl.Limit
 			mdl.AllocationMessage = rl.AllocationMessage
+			mdl.RateInterval = rl.RateInterval
+			mdl.Burst = rl.Burst
+			mdl.ParentID = rl.ParentID
 			if rl.ActivationInterval != nil {
 				if rl.ActivationInterval.ActivationTime != utils.EmptyString {
 					mdl.ActivationInterval = rl.ActivationInterval.ActivationTime
@@ -1171,6 +1190,7 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 		Weight:            tpRL.Weight,
 		Blocker:           tpRL.Blocker,
 		Stored:            tpRL.Stored,
+		ParentID:          tpRL.ParentID,
 		AllocationMessage: tpRL.AllocationMessage,
 		ThresholdIDs:      make([]string, len(tpRL.ThresholdIDs)),
 		FilterIDs:         make([]string, len(tpRL.FilterIDs)),
@@ -1194,6 +1214,16 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 			return nil, err
 		}
 	}
+	if tpRL.RateInterval != utils.EmptyString {
+		if rp.RateInterval, err = utils.ParseDurationWithNanosecs(tpRL.RateInterval); err != nil {
+			return nil, err
+		}
+	}
+	if tpRL.Burst != utils.EmptyString {
+		if rp.Burst, err = strconv.ParseFloat(tpRL.Burst, 64); err != nil {
+			return nil, err
+		}
+	}
 	return rp, nil
 }
 
@@ -1204,6 +1234,7 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 		FilterIDs:          make([]string, len(rp.FilterIDs)),
 		ActivationInterval: new(utils.TPActivationInterval),
 		Limit:              strconv.FormatFloat(rp.Limit, 'f', -1, 64),
+		ParentID:           rp.ParentID,
 		AllocationMessage:  rp.AllocationMessage,
 		Blocker:            rp.Blocker,
 		Stored:             rp.Stored,
@@ -1213,6 +1244,12 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 	if rp.UsageTTL != time.Duration(0) {
 		tpRL.UsageTTL = rp.UsageTTL.String()
 	}
+	if rp.RateInterval != 0 {
+		tpRL.RateInterval = rp.RateInterval.String()
+	}
+	if rp.Burst != 0 {
+		tpRL.Burst = strconv.FormatFloat(rp.Burst, 'f', -1, 64)
+	}
 
 	copy(tpRL.FilterIDs, rp.FilterIDs)
 	copy(tpRL.ThresholdIDs, rp.ThresholdIDs)
@@ -1248,6 +1285,7 @@ func (tps IPMdls) CSVHeader() []string {
 		utils.PoolMessage,
 		utils.PoolWeight,
 		utils.PoolBlocker,
+		utils.PoolPrefixLength,
 	}
 }
 
@@ -1279,13 +1317,14 @@ func (tps IPMdls) AsTPIPs() []*utils.TPIPProfile {
 			pool, found := poolMap[tenID][poolID]
 			if !found {
 				pool = &utils.TPIPPool{
-					ID:       mdl.PoolID,
-					Type:     mdl.PoolType,
-					Range:    mdl.PoolRange,
-					Strategy: mdl.PoolStrategy,
-					Message:  mdl.PoolMessage,
-					Weight:   mdl.PoolWeight,
-					Blocker:  mdl.PoolBlocker,
+					ID:           mdl.PoolID,
+					Type:         mdl.PoolType,
+					Range:        mdl.PoolRange,
+					PrefixLength: mdl.PoolPrefixLength,
+					Strategy:     mdl.PoolStrategy,
+					Message:      mdl.PoolMessage,
+					Weight:       mdl.PoolWeight,
+					Blocker:      mdl.PoolBlocker,
 				}
 			}
 			if mdl.PoolFilterIDs != utils.EmptyString {
@@ -1398,6 +1437,7 @@ func APItoModelIP(tp *utils.TPIPProfile) IPMdls {
 		mdl.PoolID = pool.ID
 		mdl.PoolType = pool.Type
 		mdl.PoolRange = pool.Range
+		mdl.PoolPrefixLength = pool.PrefixLength
 		mdl.PoolStrategy = pool.Strategy
 		mdl.PoolMessage = pool.Message
 		mdl.PoolWeight = pool.Weight
@@ -1440,14 +1480,15 @@ func APItoIP(tp *utils.TPIPProfile, timezone string) (*IPProfile, error) {
 
 	for i, pool := range tp.Pools {
 		ipp.Pools[i] = &IPPool{
-			ID:        pool.ID,
-			FilterIDs: pool.FilterIDs,
-			Type:      pool.Type,
-			Range:     pool.Range,
-			Strategy:  pool.Strategy,
-			Message:   pool.Message,
-			Weight:    pool.Weight,
-			Blocker:   pool.Blocker,
+			ID:           pool.ID,
+			FilterIDs:    pool.FilterIDs,
+			Type:         pool.Type,
+			Range:        pool.Range,
+			PrefixLength: pool.PrefixLength,
+			Strategy:     pool.Strategy,
+			Message:      pool.Message,
+			Weight:       pool.Weight,
+			Blocker:      pool.Blocker,
 		}
 	}
 	return ipp, nil
@@ -1471,14 +1512,15 @@ func IPProfileToAPI(ipp *IPProfile) *utils.TPIPProfile {
 
 	for i, pool := range ipp.Pools {
 		tp.Pools[i] = &utils.TPIPPool{
-			ID:        pool.ID,
-			FilterIDs: pool.FilterIDs,
-			Type:      pool.Type,
-			Range:     pool.Range,
-			Strategy:  pool.Strategy,
-			Message:   pool.Message,
-			Weight:    pool.Weight,
-			Blocker:   pool.Blocker,
+			ID:           pool.ID,
+			FilterIDs:    pool.FilterIDs,
+			Type:         pool.Type,
+			Range:        pool.Range,
+			PrefixLength: pool.PrefixLength,
+			Strategy:     pool.Strategy,
+			Message:      pool.Message,
+			Weight:       pool.Weight,
+			Blocker:      pool.Blocker,
 		}
 	}
 
@@ -1499,7 +1541,7 @@ type StatMdls []*StatMdl
 func (tps StatMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs, utils.BucketInterval}
 }
 
 func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
@@ -1519,7 +1561,6 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 				Stored:      model.Stored,
 				Weight:      model.Weight,
 				MinItems:    model.MinItems,
-				TTL:         model.TTL,
 				QueueLength: model.QueueLength,
 			}
 		}
@@ -1538,6 +1579,9 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		if model.TTL != utils.EmptyString {
 			st.TTL = model.TTL
 		}
+		if model.BucketInterval != utils.EmptyString {
+			st.BucketInterval = model.BucketInterval
+		}
 		if model.QueueLength != 0 {
 			st.QueueLength = model.QueueLength
 		}
@@ -1623,6 +1667,7 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 				}
 				mdl.QueueLength = st.QueueLength
 				mdl.TTL = st.TTL
+				mdl.BucketInterval = st.BucketInterval
 				mdl.MinItems = st.MinItems
 				mdl.Stored = st.Stored
 				mdl.Blocker = st.Blocker
@@ -1665,6 +1710,11 @@ func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfil
 			return nil, err
 		}
 	}
+	if tpST.BucketInterval != utils.EmptyString {
+		if st.BucketInterval, err = utils.ParseDurationWithNanosecs(tpST.BucketInterval); err != nil {
+			return nil, err
+		}
+	}
 	for i, metric := range tpST.Metrics {
 		st.Metrics[i] = &MetricWithFilters{
 			MetricID:  metric.MetricID,
@@ -1711,6 +1761,9 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	if st.TTL != time.Duration(0) {
 		tpST.TTL = st.TTL.String()
 	}
+	if st.BucketInterval != time.Duration(0) {
+		tpST.BucketInterval = st.BucketInterval.String()
+	}
 	copy(tpST.FilterIDs, st.FilterIDs)
 	copy(tpST.ThresholdIDs, st.ThresholdIDs)
 
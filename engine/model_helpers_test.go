@@ -1905,6 +1905,67 @@ func TestResourceProfileToAPI(t *testing.T) {
 	}
 }
 
+func TestResourceProfileRateLimitAPI(t *testing.T) {
+	tpRL := &utils.TPResourceProfile{
+		Tenant:       "cgrates.org",
+		ID:           "ResGroup1",
+		Limit:        "10",
+		RateInterval: "1m0s",
+		Burst:        "20",
+	}
+	rp, err := APItoResource(tpRL, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Limit != 10 || rp.RateInterval != time.Minute || rp.Burst != 20 {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(rp))
+	}
+	if rcv := ResourceProfileToAPI(rp); rcv.Limit != tpRL.Limit ||
+		rcv.RateInterval != tpRL.RateInterval || rcv.Burst != tpRL.Burst {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(tpRL), utils.ToJSON(rcv))
+	}
+	mdls := APItoModelResource(tpRL)
+	if len(mdls) != 1 || mdls[0].Limit != "10" ||
+		mdls[0].RateInterval != "1m0s" || mdls[0].Burst != "20" {
+		t.Errorf("Unexpected models: %s", utils.ToJSON(mdls))
+	}
+	if rcv := mdls.AsTPResources(); !reflect.DeepEqual(rcv, []*utils.TPResourceProfile{tpRL}) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(tpRL), utils.ToJSON(rcv))
+	}
+	tpRL.Limit = "10/1s"
+	if _, err = APItoResource(tpRL, "UTC"); err == nil {
+		t.Error("Expected error for invalid limit")
+	}
+}
+
+func TestResourceMdlsParentID(t *testing.T) {
+	tpRL := &utils.TPResourceProfile{
+		TPid:     testTPID,
+		Tenant:   "cgrates.org",
+		ID:       "CUST1",
+		Limit:    "2",
+		ParentID: "RESELLER1",
+	}
+	mdls := APItoModelResource(tpRL)
+	if len(mdls) != 1 || mdls[0].Limit != "2" || mdls[0].ParentID != "RESELLER1" {
+		t.Fatalf("Unexpected models: %s", utils.ToJSON(mdls))
+	}
+	if rcv := mdls.AsTPResources(); len(rcv) != 1 ||
+		rcv[0].Limit != tpRL.Limit || rcv[0].ParentID != tpRL.ParentID {
+		t.Errorf("Unexpected profiles: %s", utils.ToJSON(rcv))
+	}
+	rp, err := APItoResource(tpRL, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.ParentID != tpRL.ParentID {
+		t.Errorf("Expecting: %q, received: %q", tpRL.ParentID, rp.ParentID)
+	}
+	if rcv := ResourceProfileToAPI(rp); rcv.ParentID != tpRL.ParentID {
+		t.Errorf("Expecting: %q, received: %q", tpRL.ParentID, rcv.ParentID)
+	}
+}
+
 func TestAPItoModelResource(t *testing.T) {
 	tpRL := &utils.TPResourceProfile{
 		Tenant:             "cgrates.org",
@@ -2266,7 +2327,7 @@ func TestAPItoModelAccountActions(t *testing.T) {
 func TestCSVHeader(t *testing.T) {
 	var tps ResourceMdls
 	eOut := []string{
-		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs",
+		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs", "RateInterval", "Burst", "ParentID",
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -5606,6 +5667,33 @@ func TestStatMdlsAsTPStatsCase2(t *testing.T) {
 	}
 }
 
+func TestStatMdlsBucketInterval(t *testing.T) {
+	tpST := &utils.TPStatProfile{
+		Tenant:         "cgrates.org",
+		ID:             "STS_BKT",
+		TTL:            "1h",
+		BucketInterval: "1m",
+		Metrics:        []*utils.MetricWithFilters{{MetricID: utils.MetaASR}},
+	}
+	mdls := APItoModelStats(tpST)
+	if len(mdls) != 1 || mdls[0].TTL != "1h" || mdls[0].BucketInterval != "1m" {
+		t.Fatalf("expected TTL <1h> with BucketInterval <1m>, received %s", utils.ToJSON(mdls))
+	}
+	if rcv := mdls.AsTPStats(); len(rcv) != 1 || !reflect.DeepEqual(rcv[0], tpST) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(tpST), utils.ToJSON(rcv))
+	}
+	sqp, err := APItoStats(tpST, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqp.TTL != time.Hour || sqp.BucketInterval != time.Minute {
+		t.Errorf("expected TTL 1h with BucketInterval 1m, received %s", utils.ToJSON(sqp))
+	}
+	if rcv := StatQueueProfileToAPI(sqp); rcv.TTL != "1h0m0s" || rcv.BucketInterval != "1m0s" {
+		t.Errorf("expected TTL 1h0m0s with BucketInterval 1m0s, received %s", utils.ToJSON(rcv))
+	}
+}
+
 func TestStatMdlsCSVHeader(t *testing.T) {
 	testStruct := StatMdls{{
 		PK:                 0,
@@ -5627,7 +5715,7 @@ func TestStatMdlsCSVHeader(t *testing.T) {
 	}}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs, utils.BucketInterval}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -253,6 +253,9 @@ type ResourceMdl struct {
 	Stored             bool    `index:"8" re:".*"`
 	Weight             float64 `index:"9" re:".*"`
 	ThresholdIDs       string  `index:"10" re:".*"`
+	RateInterval       string  `index:"11" re:".*" optional:"true"`
+	Burst              string  `index:"12" re:".*" optional:"true"`
+	ParentID           string  `index:"13" re:".*" optional:"true"`
 	CreatedAt          time.Time
 }
 
@@ -278,6 +281,7 @@ type IPMdl struct {
 	PoolMessage        string  `index:"12" re:".*"`
 	PoolWeight         float64 `index:"13" re:".*"`
 	PoolBlocker        bool    `index:"14" re:".*"`
+	PoolPrefixLength   int     `index:"15" re:".*" optional:"true"`
 	CreatedAt          time.Time
 }
 
@@ -301,6 +305,7 @@ type StatMdl struct {
 	Blocker            bool    `index:"10" re:".*"`
 	Weight             float64 `index:"11" re:".*"`
 	ThresholdIDs       string  `index:"12" re:".*"`
+	BucketInterval     string  `index:"13" re:".*" optional:"true"`
 	CreatedAt          time.Time
 }
 
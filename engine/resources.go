@@ -21,9 +21,12 @@ package engine
 import (
 	"fmt"
 	"maps"
+	"math"
 	"runtime"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -41,6 +44,9 @@ type ResourceProfile struct {
 	ActivationInterval *utils.ActivationInterval // time when this resource becomes active and expires
 	UsageTTL           time.Duration             // auto-expire the usage after this duration
 	Limit              float64                   // limit value
+	RateInterval       time.Duration             // refill Limit units over this interval, rate limiting instead of counting usages
+	Burst              float64                   // maximum units available at once when rate limiting, Limit if 0
+	ParentID           string                    // parent resource, allocations consume its units as well
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
@@ -60,6 +66,9 @@ func (rp *ResourceProfile) Clone() *ResourceProfile {
 		ID:                rp.ID,
 		UsageTTL:          rp.UsageTTL,
 		Limit:             rp.Limit,
+		RateInterval:      rp.RateInterval,
+		Burst:             rp.Burst,
+		ParentID:          rp.ParentID,
 		AllocationMessage: rp.AllocationMessage,
 		Blocker:           rp.Blocker,
 		Stored:            rp.Stored,
@@ -79,6 +88,29 @@ func (rp *ResourceProfile) Clone() *ResourceProfile {
 	return clone
 }
 
+// isRateLimited returns true if the resource limits the rate of the units instead of their usages
+func (rp *ResourceProfile) isRateLimited() bool {
+	return rp.RateInterval > 0
+}
+
+// burst returns the capacity of the token bucket
+func (rp *ResourceProfile) burst() float64 {
+	if rp.Burst > 0 {
+		return rp.Burst
+	}
+	return rp.Limit
+}
+
+// checkRateLimit validates the rate limiting settings
+func (rp *ResourceProfile) checkRateLimit() error {
+	if rp.RateInterval < 0 || rp.Burst < 0 ||
+		(rp.isRateLimited() && rp.Limit <= 0) {
+		return fmt.Errorf("invalid rate limit <%v> per <%s> with burst <%v>",
+			rp.Limit, rp.RateInterval, rp.Burst)
+	}
+	return nil
+}
+
 // CacheClone returns a clone of ResourceProfile used by ltcache CacheCloner
 func (rp *ResourceProfile) CacheClone() any {
 	return rp.Clone()
@@ -155,18 +187,27 @@ func (ru *ResourceUsage) Clone() (cln *ResourceUsage) {
 	return
 }
 
+// ResourceTokens is the token bucket of a rate limited resource
+type ResourceTokens struct {
+	Tokens     float64   // units available at LastRefill
+	LastRefill time.Time // last time the tokens were refilled
+}
+
 // Resource represents a resource in the system
 // not thread safe, needs locking at process level
 type Resource struct {
-	Tenant string
-	ID     string
-	Usages map[string]*ResourceUsage
-	TTLIdx []string         // holds ordered list of ResourceIDs based on their TTL, empty if feature is disableda
-	lkID   string           // ID of the lock used when matching the resource
-	ttl    *time.Duration   // time to leave for this resource, picked up on each Resource initialization out of config
-	tUsage *float64         // sum of all usages
-	dirty  *bool            // the usages were modified, needs save, *bool so we only save if enabled in config
-	rPrf   *ResourceProfile // for ordering purposes
+	Tenant   string
+	ID       string
+	Usages   map[string]*ResourceUsage
+	Tokens   *ResourceTokens  // token bucket, populated only for rate limited resources
+	TTLIdx   []string         // holds ordered list of ResourceIDs based on their TTL, empty if feature is disableda
+	lkID     string           // ID of the lock used when matching the resource
+	ttl      *time.Duration   // time to leave for this resource, picked up on each Resource initialization out of config
+	tUsage   *float64         // sum of all usages
+	dirty    *bool            // the usages were modified, needs save, *bool so we only save if enabled in config
+	rPrf     *ResourceProfile // for ordering purposes
+	parent   *Resource        // parent resource, populated when matching
+	ancestor bool             // loaded only as ancestor of the matched resources
 }
 
 // Clone clones *Resource (lkID excluded)
@@ -184,6 +225,10 @@ func (r *Resource) Clone() *Resource {
 			clone.Usages[key] = usage.Clone()
 		}
 	}
+	if r.Tokens != nil {
+		tokensCopy := *r.Tokens
+		clone.Tokens = &tokensCopy
+	}
 	if r.TTLIdx != nil {
 		clone.TTLIdx = make([]string, len(r.TTLIdx))
 		copy(clone.TTLIdx, r.TTLIdx)
@@ -303,11 +348,105 @@ func (r *Resource) TotalUsage() (tU float64) {
 // Available returns the available number of units
 // Exported method to be used by filterS
 func (r *ResourceWithConfig) Available() float64 {
+	if r.Config.isRateLimited() {
+		return r.availableTokens(r.Config, time.Now())
+	}
 	return r.Config.Limit - r.TotalUsage()
 }
 
+// availableTokens returns the tokens of the bucket refilled up to now
+func (r *Resource) availableTokens(rPrf *ResourceProfile, now time.Time) float64 {
+	if r.Tokens == nil { // the bucket starts full
+		return rPrf.burst()
+	}
+	tokens := r.Tokens.Tokens
+	if elapsed := now.Sub(r.Tokens.LastRefill); elapsed > 0 {
+		tokens += rPrf.Limit * float64(elapsed) / float64(rPrf.RateInterval)
+	}
+	return math.Min(tokens, rPrf.burst())
+}
+
+// refillTokens refills the token bucket with the units accumulated since the last refill
+func (r *Resource) refillTokens(now time.Time) {
+	r.Tokens = &ResourceTokens{
+		Tokens:     r.availableTokens(r.rPrf, now),
+		LastRefill: now,
+	}
+}
+
+// tokensWait returns the time until the bucket has the units, false if they exceed its capacity
+func (r *Resource) tokensWait(units float64) (time.Duration, bool) {
+	if units > r.rPrf.burst() {
+		return 0, false
+	}
+	missing := units - r.Tokens.Tokens
+	if missing <= 0 {
+		return 0, true
+	}
+	return time.Duration(math.Ceil(missing / r.rPrf.Limit * float64(r.rPrf.RateInterval))), true
+}
+
+// allocationWait returns the time until the resource and its ancestors can allocate the units,
+// false if the units cannot be allocated by waiting
+func (r *Resource) allocationWait(units float64) (wait time.Duration, canWait bool) {
+	for lvl := r; lvl != nil; lvl = lvl.parent {
+		if !lvl.rPrf.isRateLimited() {
+			if lvl.rPrf.Limit != -1 && lvl.rPrf.Limit < lvl.TotalUsage()+units {
+				return 0, false
+			}
+			continue
+		}
+		lvlWait, can := lvl.tokensWait(units)
+		if !can {
+			return 0, false
+		}
+		wait = max(wait, lvlWait)
+	}
+	return wait, true
+}
+
+// allocationMessage returns the allocation messages of the resource and its ancestors, closest first
+func (r *Resource) allocationMessage() string {
+	var alcMsgs []string
+	for lvl := r; lvl != nil; lvl = lvl.parent {
+		alcMsgs = append(alcMsgs, utils.FirstNonEmpty(lvl.rPrf.AllocationMessage, lvl.rPrf.ID))
+	}
+	return strings.Join(alcMsgs, utils.InfieldSep)
+}
+
+// rateLimitInfo returns the allocation message suffix with the tokens remaining in the buckets
+// of the resource and its ancestors after consuming the units and the time when they are full again,
+// empty if no bucket is involved
+func (r *Resource) rateLimitInfo(units float64, now time.Time) string {
+	remaining := -1.0
+	var resetTime time.Time
+	for lvl := r; lvl != nil; lvl = lvl.parent {
+		if !lvl.rPrf.isRateLimited() {
+			continue
+		}
+		lvlRemaining := math.Max(lvl.Tokens.Tokens-units, 0)
+		if remaining == -1 || lvlRemaining < remaining {
+			remaining = lvlRemaining
+		}
+		lvlReset := now.Add(time.Duration((lvl.rPrf.burst() - lvlRemaining) / lvl.rPrf.Limit * float64(lvl.rPrf.RateInterval)))
+		if lvlReset.After(resetTime) {
+			resetTime = lvlReset
+		}
+	}
+	if remaining == -1 { // no bucket involved
+		return utils.EmptyString
+	}
+	return utils.InfieldSep + utils.RemainingTokens + utils.InInFieldSep + strconv.FormatFloat(remaining, 'f', -1, 64) +
+		utils.InfieldSep + utils.ResetTime + utils.InInFieldSep + resetTime.UTC().Format(time.RFC3339)
+}
+
 // recordUsage records a new usage
 func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
+	if r.rPrf != nil && r.rPrf.isRateLimited() { // consume the tokens instead of recording the usage
+		r.refillTokens(time.Now())
+		r.Tokens.Tokens = math.Max(r.Tokens.Tokens-ru.Units, 0)
+		return
+	}
 	if _, hasID := r.Usages[ru.ID]; hasID {
 		return fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
 	}
@@ -330,6 +469,9 @@ func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
 
 // clearUsage clears the usage for an ID
 func (r *Resource) clearUsage(ruID string) (err error) {
+	if r.rPrf != nil && r.rPrf.isRateLimited() { // consumed tokens are not given back
+		return
+	}
 	ru, hasIt := r.Usages[ruID]
 	if !hasIt {
 		return fmt.Errorf("cannot find usage record with id: %s", ruID)
@@ -379,6 +521,10 @@ func (rs Resources) recordUsage(ru *ResourceUsage) (err error) {
 	}
 	if err != nil {
 		for _, r := range rs[:nonReservedIdx] {
+			if r.rPrf != nil && r.rPrf.isRateLimited() { // give back the consumed tokens
+				r.Tokens.Tokens = math.Min(r.Tokens.Tokens+ru.Units, r.rPrf.burst())
+				continue
+			}
 			if errClear := r.clearUsage(ru.ID); errClear != nil {
 				utils.Logger.Warning(fmt.Sprintf("<%s> cannot clear usage, err: %s", utils.ResourceS, errClear.Error()))
 			} // best effort
@@ -399,13 +545,55 @@ func (rs Resources) clearUsage(ruTntID string) (err error) {
 	return
 }
 
+// linkAncestors links the resources to their parents, appending the ancestors
+// to the matched resources and unlocking the ones not needed
+func (rs Resources) linkAncestors(ancs Resources) (_ Resources, err error) {
+	loaded := make(map[string]*Resource, len(rs)+len(ancs))
+	for _, r := range slices.Concat(rs, ancs) {
+		r.parent = nil
+		loaded[r.ID] = r
+	}
+	used := make(utils.StringSet)
+	for _, r := range rs {
+		used.Add(r.ID)
+	}
+	for _, r := range rs { // the appended ancestors are linked while walking the chains
+		chain := utils.NewStringSet([]string{r.ID})
+		for lvl := r; lvl.rPrf.ParentID != utils.EmptyString && lvl.parent == nil; lvl = lvl.parent {
+			prnt, has := loaded[lvl.rPrf.ParentID]
+			if !has || chain.Has(prnt.ID) {
+				err = fmt.Errorf("invalid parent resource <%s> of <%s>",
+					lvl.rPrf.ParentID, lvl.TenantID())
+				rs.unlock()
+				ancs.unlock()
+				return nil, err
+			}
+			chain.Add(prnt.ID)
+			lvl.parent = prnt
+			if !used.Has(prnt.ID) {
+				used.Add(prnt.ID)
+				prnt.ancestor = true
+				rs = append(rs, prnt)
+			}
+		}
+	}
+	for _, anc := range ancs {
+		if !used.Has(anc.ID) {
+			Resources{anc}.unlock()
+		}
+	}
+	return rs, nil
+}
+
 // allocateResource attempts allocating resources for a *ResourceUsage
 // simulates on dryRun
-// returns utils.ErrResourceUnavailable if allocation is not possible
+// returns utils.ErrResourceUnavailable if allocation is not possible,
+// wrapped in utils.ErrRetryAfter if rate limited resources can allocate it later
 func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage string, err error) {
 	if len(rs) == 0 {
 		return "", utils.ErrResourceUnavailable
 	}
+	now := time.Now()
 	// Simulate resource usage
 	for _, r := range rs {
 		r.removeExpiredUnits()
@@ -416,14 +604,33 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 			err = fmt.Errorf("empty configuration for resourceID: %s", r.TenantID())
 			return
 		}
-		if alcMessage == utils.EmptyString &&
-			(r.rPrf.Limit >= r.TotalUsage()+ru.Units || r.rPrf.Limit == -1) {
-			alcMessage = utils.FirstNonEmpty(r.rPrf.AllocationMessage, r.rPrf.ID)
+		if r.rPrf.isRateLimited() {
+			r.refillTokens(now)
+		}
+	}
+	var retryAfter time.Duration // shortest wait until a rate limited resource has the units
+	var canRetry bool
+	for _, r := range rs {
+		if r.ancestor {
+			continue
+		}
+		wait, canWait := r.allocationWait(ru.Units)
+		if !canWait {
+			continue
+		}
+		if wait == 0 {
+			alcMessage = r.allocationMessage() + r.rateLimitInfo(ru.Units, now)
+			break
+		}
+		if !canRetry || wait < retryAfter {
+			retryAfter, canRetry = wait, true
 		}
 	}
 	if alcMessage == "" {
-		err = utils.ErrResourceUnavailable
-		return
+		if canRetry {
+			return "", utils.NewErrRetryAfter(utils.ErrResourceUnavailable, retryAfter)
+		}
+		return "", utils.ErrResourceUnavailable
 	}
 	if dryRun {
 		return
@@ -671,8 +878,19 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 		// Lock items in sorted order to prevent AB-BA deadlock.
 		itemIDs = slices.Sorted(maps.Keys(rIDs))
 	}
+	// lock the ancestors together with the candidates, in sorted order
+	var ancIDs utils.StringSet
+	if ancIDs, err = rS.resourceAncestorIDs(tnt, itemIDs); err != nil {
+		return nil, err
+	}
+	lockIDs := itemIDs
+	if len(ancIDs) != 0 {
+		lockIDs = slices.Sorted(maps.Keys(utils.JoinStringSet(utils.NewStringSet(itemIDs), ancIDs)))
+	}
+	candIDs := utils.NewStringSet(itemIDs)
 	rs = make(Resources, 0, len(itemIDs))
-	for _, id := range itemIDs {
+	var ancs Resources // resources loaded as ancestors of the candidates
+	for _, id := range lockIDs {
 		lkPrflID := guardian.Guardian.GuardIDs("",
 			config.CgrConfig().GeneralCfg().LockingTimeout,
 			resourceProfileLockKey(tnt, id))
@@ -684,21 +902,25 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 				continue
 			}
 			rs.unlock()
+			ancs.unlock()
 			return
 		}
 		rPrf.lock(lkPrflID)
-		if rPrf.ActivationInterval != nil && ev.Time != nil &&
+		matched := candIDs.Has(id)
+		if matched && rPrf.ActivationInterval != nil && ev.Time != nil &&
 			!rPrf.ActivationInterval.IsActiveAtTime(*ev.Time) { // not active
-			rPrf.unlock()
-			continue
+			matched = false
 		}
-		var pass bool
-		if pass, err = rS.filterS.Pass(tnt, rPrf.FilterIDs,
-			evNm); err != nil {
-			rPrf.unlock()
-			rs.unlock()
-			return nil, err
-		} else if !pass {
+		if matched {
+			if matched, err = rS.filterS.Pass(tnt, rPrf.FilterIDs,
+				evNm); err != nil {
+				rPrf.unlock()
+				rs.unlock()
+				ancs.unlock()
+				return nil, err
+			}
+		}
+		if !matched && !ancIDs.Has(id) {
 			rPrf.unlock()
 			continue
 		}
@@ -710,6 +932,7 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 			guardian.Guardian.UnguardIDs(lkID)
 			rPrf.unlock()
 			rs.unlock()
+			ancs.unlock()
 			return nil, err
 		}
 		r.lock(lkID) // pass the lock into resource so we have it as reference
@@ -724,26 +947,80 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 			r.ttl = utils.DurationPointer(rPrf.UsageTTL)
 		}
 		r.rPrf = rPrf
+		r.ancestor = !matched
+		if !matched {
+			ancs = append(ancs, r)
+			continue
+		}
 		rs = append(rs, r)
 	}
 
 	if len(rs) == 0 {
+		ancs.unlock()
 		return nil, utils.ErrNotFound
 	}
 	rs.Sort()
 	for i, r := range rs {
 		if r.rPrf.Blocker && i != len(rs)-1 { // blocker will stop processing and we are not at last index
-			Resources(rs[i+1:]).unlock()
+			ancs = append(ancs, rs[i+1:]...) // kept only if ancestors of the remaining ones
 			rs = rs[:i+1]
 			break
 		}
 	}
+	if rs, err = rs.linkAncestors(ancs); err != nil {
+		return nil, err
+	}
 	if err = Cache.Set(utils.CacheEventResources, evUUID, itemIDs, nil, true, ""); err != nil {
 		rs.unlock()
 	}
 	return
 }
 
+// resourceAncestorIDs returns the IDs of the ancestors of the resources
+func (rS *ResourceService) resourceAncestorIDs(tnt string, rIDs []string) (ancIDs utils.StringSet, err error) {
+	ancIDs = make(utils.StringSet)
+	for _, id := range rIDs {
+		rPrf, errPrf := rS.dm.GetResourceProfile(tnt, id,
+			true, true, utils.NonTransactional)
+		if errPrf != nil {
+			if errPrf == utils.ErrNotFound {
+				continue
+			}
+			return nil, errPrf
+		}
+		prntIDs, errPrnt := resourceParentIDs(rS.dm, rPrf)
+		if errPrnt == utils.ErrNotFound { // the last parent is missing
+			errPrnt = fmt.Errorf("missing parent resource <%s> of <%s>",
+				prntIDs[len(prntIDs)-1], rPrf.TenantID())
+		}
+		if errPrnt != nil {
+			return nil, errPrnt
+		}
+		ancIDs.AddSlice(prntIDs)
+	}
+	return
+}
+
+// resourceParentIDs returns the IDs of the parents of the ResourceProfile, closest first,
+// ending with the missing one on utils.ErrNotFound
+func resourceParentIDs(dm *DataManager, rp *ResourceProfile) (prntIDs []string, err error) {
+	visited := utils.NewStringSet([]string{rp.ID})
+	for prntID := rp.ParentID; prntID != utils.EmptyString; {
+		if visited.Has(prntID) {
+			return nil, fmt.Errorf("cyclic parent chain for resource <%s>", rp.TenantID())
+		}
+		visited.Add(prntID)
+		prntIDs = append(prntIDs, prntID)
+		var prnt *ResourceProfile
+		if prnt, err = dm.GetResourceProfile(rp.Tenant, prntID,
+			true, true, utils.NonTransactional); err != nil {
+			return
+		}
+		prntID = prnt.ParentID
+	}
+	return
+}
+
 // V1GetResourcesForEvent returns active resource configs matching the event
 func (rS *ResourceService) V1GetResourcesForEvent(ctx *context.Context, args *utils.CGREvent, reply *Resources) (err error) {
 	if args == nil {
@@ -789,7 +1066,9 @@ func (rS *ResourceService) V1GetResourcesForEvent(ctx *context.Context, args *ut
 	if mtcRLs, err = rS.matchingResourcesForEvent(tnt, args, usageID, usageTTL); err != nil {
 		return err
 	}
-	*reply = mtcRLs
+	*reply = slices.DeleteFunc(slices.Clone(mtcRLs), func(r *Resource) bool {
+		return r.ancestor // ancestors are not matching the event
+	})
 	mtcRLs.unlock()
 	return
 }
@@ -854,6 +1133,8 @@ func (rS *ResourceService) V1AuthorizeResources(ctx *context.Context, args *util
 			Units:  units}, true); err != nil {
 		if err == utils.ErrResourceUnavailable {
 			err = utils.ErrResourceUnauthorized
+		} else if rlErr, isRateLimited := err.(*utils.ErrRetryAfter); isRateLimited {
+			err = utils.NewErrRetryAfter(utils.ErrResourceUnauthorized, rlErr.RetryAfter)
 		}
 		return
 	}
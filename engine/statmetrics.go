@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package engine
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -71,20 +72,26 @@ func (swc *StatWithCompress) Clone() *StatWithCompress {
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string, []string) (StatMetric, error){
-		utils.MetaASR:      NewASR,
-		utils.MetaACD:      NewACD,
-		utils.MetaTCD:      NewTCD,
-		utils.MetaACC:      NewACC,
-		utils.MetaTCC:      NewTCC,
-		utils.MetaPDD:      NewPDD,
-		utils.MetaDDC:      NewDDC,
-		utils.MetaSum:      NewStatSum,
-		utils.MetaAverage:  NewStatAverage,
-		utils.MetaDistinct: NewStatDistinct,
-		utils.MetaHighest:  NewStatHighest,
-		utils.MetaLowest:   NewStatLowest,
-		utils.MetaREPSC:    NewStatREPSC,
-		utils.MetaREPFC:    NewStatREPFC,
+		utils.MetaASR:        NewASR,
+		utils.MetaACD:        NewACD,
+		utils.MetaTCD:        NewTCD,
+		utils.MetaACC:        NewACC,
+		utils.MetaTCC:        NewTCC,
+		utils.MetaPDD:        NewPDD,
+		utils.MetaDDC:        NewDDC,
+		utils.MetaSum:        NewStatSum,
+		utils.MetaAverage:    NewStatAverage,
+		utils.MetaDistinct:   NewStatDistinct,
+		utils.MetaHighest:    NewStatHighest,
+		utils.MetaLowest:     NewStatLowest,
+		utils.MetaREPSC:      NewStatREPSC,
+		utils.MetaREPFC:      NewStatREPFC,
+		utils.MetaPercentile: NewStatPercentile,
+		utils.MetaP50:        newStatPercentileAlias("50"),
+		utils.MetaP95:        newStatPercentileAlias("95"),
+		utils.MetaP99:        newStatPercentileAlias("99"),
+		utils.MetaHistogram:  NewStatHistogram,
+		utils.MetaAnomaly:    NewStatAnomaly,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
@@ -93,8 +100,15 @@ func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMe
 		return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 	}
 	var extraParams string
-	if len(metricSplit[1:]) > 0 {
-		extraParams = metricSplit[1]
+	switch metricSplit[0] {
+	case utils.MetaPercentile, utils.MetaP50, utils.MetaP95, utils.MetaP99,
+		utils.MetaHistogram, utils.MetaAnomaly:
+		// multiple parameters, ie. *percentile#95#~*req.FieldName or *anomaly#*sum#~*req.FieldName
+		_, extraParams, _ = strings.Cut(metricID, utils.HashtagSep)
+	default:
+		if len(metricSplit[1:]) > 0 {
+			extraParams = metricSplit[1]
+		}
 	}
 	return metrics[metricSplit[0]](minItems, extraParams, filterIDs)
 }
@@ -116,6 +130,18 @@ type StatMetric interface {
 	Clone() StatMetric
 }
 
+// statMetricSketch is implemented by the metrics keeping only aggregated counts, unable
+// to remove single events. Their queues need to expire the events with time buckets.
+type statMetricSketch interface {
+	isSketch()
+}
+
+// statMetricMerger is implemented by the metrics able to aggregate the state of
+// another metric of the same type, needed by the queues windowing into time buckets
+type statMetricMerger interface {
+	Merge(m StatMetric)
+}
+
 func NewASR(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatASR{Events: make(map[string]*StatWithCompress),
 		MinItems: minItems, FilterIDs: filterIDs}, nil
@@ -262,6 +288,17 @@ func (asr *StatASR) RemEvent(evID string) {
 	asr.val = nil
 }
 
+// Merge adds the state of another ASR metric, used when aggregating time buckets
+func (asr *StatASR) Merge(m StatMetric) {
+	o, canCast := m.(*StatASR)
+	if !canCast {
+		return
+	}
+	asr.Answered += o.Answered
+	asr.Count += o.Count
+	asr.val = nil
+}
+
 // Marshal is part of StatMetric interface
 func (asr *StatASR) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(asr)
@@ -450,6 +487,17 @@ func (acd *StatACD) RemEvent(evID string) {
 	acd.val = nil
 }
 
+// Merge adds the state of another ACD metric, used when aggregating time buckets
+func (acd *StatACD) Merge(m StatMetric) {
+	o, canCast := m.(*StatACD)
+	if !canCast {
+		return
+	}
+	acd.Sum += o.Sum
+	acd.Count += o.Count
+	acd.val = nil
+}
+
 func (acd *StatACD) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(acd)
 }
@@ -636,6 +684,17 @@ func (tcd *StatTCD) RemEvent(evID string) {
 	tcd.val = nil
 }
 
+// Merge adds the state of another TCD metric, used when aggregating time buckets
+func (tcd *StatTCD) Merge(m StatMetric) {
+	o, canCast := m.(*StatTCD)
+	if !canCast {
+		return
+	}
+	tcd.Sum += o.Sum
+	tcd.Count += o.Count
+	tcd.val = nil
+}
+
 func (tcd *StatTCD) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(tcd)
 }
@@ -815,6 +874,17 @@ func (acc *StatACC) RemEvent(evID string) {
 	acc.val = nil
 }
 
+// Merge adds the state of another ACC metric, used when aggregating time buckets
+func (acc *StatACC) Merge(m StatMetric) {
+	o, canCast := m.(*StatACC)
+	if !canCast {
+		return
+	}
+	acc.Sum += o.Sum
+	acc.Count += o.Count
+	acc.val = nil
+}
+
 func (acc *StatACC) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(acc)
 }
@@ -996,6 +1066,17 @@ func (tcc *StatTCC) RemEvent(evID string) {
 	tcc.val = nil
 }
 
+// Merge adds the state of another TCC metric, used when aggregating time buckets
+func (tcc *StatTCC) Merge(m StatMetric) {
+	o, canCast := m.(*StatTCC)
+	if !canCast {
+		return
+	}
+	tcc.Sum += o.Sum
+	tcc.Count += o.Count
+	tcc.val = nil
+}
+
 func (tcc *StatTCC) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(tcc)
 }
@@ -1181,6 +1262,17 @@ func (pdd *StatPDD) RemEvent(evID string) {
 	pdd.val = nil
 }
 
+// Merge adds the state of another PDD metric, used when aggregating time buckets
+func (pdd *StatPDD) Merge(m StatMetric) {
+	o, canCast := m.(*StatPDD)
+	if !canCast {
+		return
+	}
+	pdd.Sum += o.Sum
+	pdd.Count += o.Count
+	pdd.val = nil
+}
+
 func (pdd *StatPDD) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(pdd)
 }
@@ -1378,6 +1470,20 @@ func (ddc *StatDDC) RemEvent(evID string) {
 	}
 }
 
+// Merge adds the state of another DDC metric, used when aggregating time buckets
+func (ddc *StatDDC) Merge(m StatMetric) {
+	o, canCast := m.(*StatDDC)
+	if !canCast {
+		return
+	}
+	for fieldValue := range o.FieldValues {
+		if _, has := ddc.FieldValues[fieldValue]; !has {
+			ddc.FieldValues[fieldValue] = make(utils.StringSet)
+		}
+	}
+	ddc.Count += o.Count
+}
+
 func (ddc *StatDDC) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(ddc)
 }
@@ -1559,6 +1665,17 @@ func (sum *StatSum) RemEvent(evID string) {
 	sum.val = nil
 }
 
+// Merge adds the state of another Sum metric, used when aggregating time buckets
+func (sum *StatSum) Merge(m StatMetric) {
+	o, canCast := m.(*StatSum)
+	if !canCast {
+		return
+	}
+	sum.Sum += o.Sum
+	sum.Count += o.Count
+	sum.val = nil
+}
+
 func (sum *StatSum) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(sum)
 }
@@ -1742,6 +1859,17 @@ func (avg *StatAverage) RemEvent(evID string) {
 	avg.val = nil
 }
 
+// Merge adds the state of another Average metric, used when aggregating time buckets
+func (avg *StatAverage) Merge(m StatMetric) {
+	o, canCast := m.(*StatAverage)
+	if !canCast {
+		return
+	}
+	avg.Sum += o.Sum
+	avg.Count += o.Count
+	avg.val = nil
+}
+
 func (avg *StatAverage) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(avg)
 }
@@ -1945,6 +2073,20 @@ func (dst *StatDistinct) RemEvent(evID string) {
 	}
 }
 
+// Merge adds the state of another Distinct metric, used when aggregating time buckets
+func (dst *StatDistinct) Merge(m StatMetric) {
+	o, canCast := m.(*StatDistinct)
+	if !canCast {
+		return
+	}
+	for fieldValue := range o.FieldValues {
+		if _, has := dst.FieldValues[fieldValue]; !has {
+			dst.FieldValues[fieldValue] = make(utils.StringSet)
+		}
+	}
+	dst.Count += o.Count
+}
+
 func (dst *StatDistinct) Marshal(ms Marshaler) (marshaled []byte, err error) {
 	return ms.Marshal(dst)
 }
@@ -2130,6 +2272,19 @@ func (s *StatHighest) RemEvent(evID string) {
 	s.cachedVal = nil
 }
 
+// Merge adds the state of another Highest metric, used when aggregating time buckets
+func (s *StatHighest) Merge(m StatMetric) {
+	o, canCast := m.(*StatHighest)
+	if !canCast || o.Count == 0 {
+		return
+	}
+	if s.Count == 0 || o.Highest > s.Highest {
+		s.Highest = o.Highest
+	}
+	s.Count += o.Count
+	s.cachedVal = nil
+}
+
 func (s *StatHighest) Marshal(ms Marshaler) ([]byte, error) {
 	return ms.Marshal(s)
 }
@@ -2310,6 +2465,19 @@ func (s *StatLowest) RemEvent(evID string) {
 	s.cachedVal = nil
 }
 
+// Merge adds the state of another Lowest metric, used when aggregating time buckets
+func (s *StatLowest) Merge(m StatMetric) {
+	o, canCast := m.(*StatLowest)
+	if !canCast || o.Count == 0 {
+		return
+	}
+	if s.Count == 0 || o.Lowest < s.Lowest {
+		s.Lowest = o.Lowest
+	}
+	s.Count += o.Count
+	s.cachedVal = nil
+}
+
 func (s *StatLowest) Marshal(ms Marshaler) ([]byte, error) {
 	return ms.Marshal(s)
 }
@@ -2470,6 +2638,16 @@ func (s *StatREPSC) RemEvent(evID string) {
 	s.cachedVal = nil
 }
 
+// Merge adds the state of another REPSC metric, used when aggregating time buckets
+func (s *StatREPSC) Merge(m StatMetric) {
+	o, canCast := m.(*StatREPSC)
+	if !canCast {
+		return
+	}
+	s.Count += o.Count
+	s.cachedVal = nil
+}
+
 func (s *StatREPSC) Marshal(ms Marshaler) ([]byte, error) {
 	return ms.Marshal(s)
 }
@@ -2657,6 +2835,16 @@ func (s *StatREPFC) RemEvent(evID string) {
 	s.cachedVal = nil
 }
 
+// Merge adds the state of another REPFC metric, used when aggregating time buckets
+func (s *StatREPFC) Merge(m StatMetric) {
+	o, canCast := m.(*StatREPFC)
+	if !canCast {
+		return
+	}
+	s.Count += o.Count
+	s.cachedVal = nil
+}
+
 func (s *StatREPFC) Marshal(ms Marshaler) ([]byte, error) {
 	return ms.Marshal(s)
 }
@@ -2692,3 +2880,699 @@ func (s *StatREPFC) GetCompressFactor(events map[string]int) map[string]int {
 	}
 	return events
 }
+
+const (
+	sketchRelativeAccuracy = 0.01    // relative error of the values estimated out of the sketch bins
+	sketchKeyOffset        = 1 << 20 // keeps positive the keys of the values between 0 and 1
+	sketchMinValue         = 1e-9    // absolute values below are counted as 0
+)
+
+var (
+	sketchGamma    = (1 + sketchRelativeAccuracy) / (1 - sketchRelativeAccuracy)
+	sketchLogGamma = math.Log(sketchGamma)
+)
+
+// sketchKey returns the key of the logarithmic bin counting the value,
+// the keys being ordered the same way as the values they count
+func sketchKey(v float64) int {
+	abs := math.Abs(v)
+	if abs < sketchMinValue {
+		return 0
+	}
+	k := int(math.Ceil(math.Log(abs)/sketchLogGamma)) + sketchKeyOffset
+	if v < 0 {
+		return -k
+	}
+	return k
+}
+
+// sketchValue returns the value estimated by the bin with the given key
+func sketchValue(k int) float64 {
+	if k == 0 {
+		return 0
+	}
+	sign := 1.0
+	if k < 0 {
+		k, sign = -k, -1
+	}
+	return sign * 2 * math.Pow(sketchGamma, float64(k-sketchKeyOffset)) / (sketchGamma + 1)
+}
+
+// NewStatPercentile creates a StatPercentile metric out of the <percentile>#<fieldName> parameters.
+func NewStatPercentile(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.SplitN(extraParams, utils.HashtagSep, 2)
+	if len(params) != 2 || params[1] == utils.EmptyString {
+		return nil, fmt.Errorf("invalid <%s> metric parameters: <%s>", utils.MetaPercentile, extraParams)
+	}
+	percentile, err := strconv.ParseFloat(params[0], 64)
+	if err != nil || percentile < 0 || percentile > 100 {
+		return nil, fmt.Errorf("invalid <%s> metric percentile: <%s>", utils.MetaPercentile, params[0])
+	}
+	return &StatPercentile{
+		FilterIDs:  filterIDs,
+		MinItems:   minItems,
+		FieldName:  params[1],
+		Percentile: percentile,
+		Bins:       make(map[int]int64),
+	}, nil
+}
+
+// newStatPercentileAlias returns the constructor of the metrics fixing the percentile, ie. *p95#<fieldName>.
+func newStatPercentileAlias(percentile string) func(int, string, []string) (StatMetric, error) {
+	return func(minItems int, fieldName string, filterIDs []string) (StatMetric, error) {
+		return NewStatPercentile(minItems, percentile+utils.HashtagSep+fieldName, filterIDs)
+	}
+}
+
+// StatPercentile estimates a percentile of a field out of a logarithmic sketch,
+// the sketch being its only state. Single events cannot be removed, the values
+// expiring with the time buckets of the queue (see statMetricSketch).
+type StatPercentile struct {
+	FilterIDs  []string // event filters to apply before processing
+	FieldName  string   // field path to extract from events
+	MinItems   int      // minimum events required for valid results
+	Percentile float64  // percentile to estimate, between 0 and 100
+
+	Bins  map[int]int64 // number of values counted by each sketch bin
+	Count int64         // number of values counted
+
+	cachedVal *float64
+}
+
+// Clone creates a deep copy of StatPercentile.
+func (s *StatPercentile) Clone() StatMetric {
+	if s == nil {
+		return nil
+	}
+	clone := &StatPercentile{
+		FilterIDs:  slices.Clone(s.FilterIDs),
+		FieldName:  s.FieldName,
+		MinItems:   s.MinItems,
+		Percentile: s.Percentile,
+		Bins:       maps.Clone(s.Bins),
+		Count:      s.Count,
+	}
+	if s.cachedVal != nil {
+		val := *s.cachedVal
+		clone.cachedVal = &val
+	}
+	return clone
+}
+
+func (s *StatPercentile) GetStringValue(decimals int) string {
+	v := s.getValue(decimals)
+	if v == utils.StatsNA {
+		return utils.NotAvailable
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (s *StatPercentile) GetValue(decimals int) any {
+	return s.getValue(decimals)
+}
+
+func (s *StatPercentile) GetFloat64Value(decimals int) float64 {
+	return s.getValue(decimals)
+}
+
+// getValue returns the value of the bin holding the percentile, calculating if cache is invalid.
+func (s *StatPercentile) getValue(decimals int) float64 {
+	if s.cachedVal != nil {
+		return *s.cachedVal
+	}
+	if s.Count == 0 || s.Count < int64(s.MinItems) {
+		s.cachedVal = utils.Float64Pointer(utils.StatsNA)
+		return *s.cachedVal
+	}
+	keys := slices.Sorted(maps.Keys(s.Bins))
+	rank := s.Percentile / 100 * float64(s.Count-1)
+	var cnt int64
+	k := keys[len(keys)-1]
+	for _, key := range keys {
+		if cnt += s.Bins[key]; float64(cnt) > rank {
+			k = key
+			break
+		}
+	}
+	v := utils.Round(sketchValue(k), decimals, utils.MetaRoundingMiddle)
+	s.cachedVal = &v
+	return v
+}
+
+// AddEvent counts the event value within the sketch.
+func (s *StatPercentile) AddEvent(evID string, ev utils.DataProvider) error {
+	return s.AddOneEvent(ev)
+}
+
+// AddOneEvent counts the event value within the sketch.
+func (s *StatPercentile) AddOneEvent(ev utils.DataProvider) error {
+	val, err := s.getFieldValue(ev)
+	if err != nil {
+		return err
+	}
+	s.Bins[sketchKey(val)]++
+	s.Count++
+	s.cachedVal = nil
+	return nil
+}
+
+// getFieldValue gets the numeric value from the DataProvider.
+func (s *StatPercentile) getFieldValue(ev utils.DataProvider) (float64, error) {
+	ival, err := utils.DPDynamicInterface(s.FieldName, ev)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return 0, utils.ErrPrefix(err, s.FieldName)
+		}
+		return 0, err
+	}
+	return utils.IfaceAsFloat64(ival)
+}
+
+// RemEvent does nothing, the sketch not knowing the bins of single events.
+func (s *StatPercentile) RemEvent(evID string) {}
+
+// Merge adds the sketch bins of another Percentile metric, used when aggregating time buckets
+func (s *StatPercentile) Merge(m StatMetric) {
+	o, canCast := m.(*StatPercentile)
+	if !canCast {
+		return
+	}
+	for key, cnt := range o.Bins {
+		s.Bins[key] += cnt
+	}
+	s.Count += o.Count
+	s.cachedVal = nil
+}
+
+func (s *StatPercentile) Marshal(ms Marshaler) ([]byte, error) {
+	return ms.Marshal(s)
+}
+
+func (s *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) error {
+	return ms.Unmarshal(marshaled, &s)
+}
+
+// GetFilterIDs is part of StatMetric interface.
+func (s *StatPercentile) GetFilterIDs() []string {
+	return s.FilterIDs
+}
+
+// GetMinItems returns the minimum items for the metric.
+func (s *StatPercentile) GetMinItems() int { return s.MinItems }
+
+// Compress is part of StatMetric interface, the sketch keeping no events.
+func (s *StatPercentile) Compress(queueLen int64, defaultID string, decimals int) []string {
+	return nil
+}
+
+func (s *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	return events
+}
+
+func (s *StatPercentile) isSketch() {}
+
+// NewStatHistogram creates a StatHistogram metric out of the <fieldName>#<bound1>|<bound2>... parameters.
+func NewStatHistogram(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.SplitN(extraParams, utils.HashtagSep, 2)
+	if len(params) != 2 || params[0] == utils.EmptyString || params[1] == utils.EmptyString {
+		return nil, fmt.Errorf("invalid <%s> metric parameters: <%s>", utils.MetaHistogram, extraParams)
+	}
+	boundsStr := strings.Split(params[1], utils.PipeSep)
+	bounds := make([]float64, len(boundsStr))
+	for i, boundStr := range boundsStr {
+		bound, err := strconv.ParseFloat(boundStr, 64)
+		if err != nil || (i != 0 && bound <= bounds[i-1]) {
+			return nil, fmt.Errorf("invalid <%s> metric buckets: <%s>", utils.MetaHistogram, params[1])
+		}
+		bounds[i] = bound
+	}
+	return &StatHistogram{
+		FilterIDs: filterIDs,
+		MinItems:  minItems,
+		FieldName: params[0],
+		Bounds:    bounds,
+		Buckets:   make([]int64, len(bounds)+1),
+	}, nil
+}
+
+// StatHistogram counts the values of a field into buckets with ascending upper bounds,
+// the last bucket counting the values above all the bounds. Like StatPercentile it
+// keeps only the bucket counts, the values expiring with the time buckets of the queue.
+type StatHistogram struct {
+	FilterIDs []string // event filters to apply before processing
+	FieldName string   // field path to extract from events
+	MinItems  int      // minimum events required for valid results
+
+	Bounds  []float64 // upper bounds of the buckets
+	Buckets []int64   // number of values counted by each bucket
+	Count   int64     // number of values counted
+}
+
+// Clone creates a deep copy of StatHistogram.
+func (s *StatHistogram) Clone() StatMetric {
+	if s == nil {
+		return nil
+	}
+	clone := &StatHistogram{
+		FilterIDs: slices.Clone(s.FilterIDs),
+		FieldName: s.FieldName,
+		MinItems:  s.MinItems,
+		Bounds:    slices.Clone(s.Bounds),
+		Buckets:   slices.Clone(s.Buckets),
+		Count:     s.Count,
+	}
+	return clone
+}
+
+// BucketValues returns the cumulative counts of the buckets indexed by their upper bound,
+// +Inf for the last one.
+func (s *StatHistogram) BucketValues() map[string]float64 {
+	na := s.Count == 0 || s.Count < int64(s.MinItems)
+	vals := make(map[string]float64, len(s.Buckets))
+	var cnt int64
+	for i, bucket := range s.Buckets {
+		cnt += bucket
+		bound := utils.PlusInf
+		if i < len(s.Bounds) {
+			bound = strconv.FormatFloat(s.Bounds[i], 'f', -1, 64)
+		}
+		vals[bound] = float64(cnt)
+		if na {
+			vals[bound] = utils.StatsNA
+		}
+	}
+	return vals
+}
+
+// GetStringValue returns the cumulative counts of the buckets as <bound>:<count> pairs.
+func (s *StatHistogram) GetStringValue(decimals int) string {
+	if s.Count == 0 || s.Count < int64(s.MinItems) {
+		return utils.NotAvailable
+	}
+	pairs := make([]string, len(s.Buckets))
+	var cnt int64
+	for i, bucket := range s.Buckets {
+		cnt += bucket
+		bound := utils.PlusInf
+		if i < len(s.Bounds) {
+			bound = strconv.FormatFloat(s.Bounds[i], 'f', -1, 64)
+		}
+		pairs[i] = bound + utils.InInFieldSep + strconv.FormatInt(cnt, 10)
+	}
+	return strings.Join(pairs, utils.InfieldSep)
+}
+
+// GetValue returns the cumulative counts of the buckets indexed by their upper bound.
+func (s *StatHistogram) GetValue(decimals int) any {
+	if s.Count == 0 || s.Count < int64(s.MinItems) {
+		return utils.StatsNA
+	}
+	return s.BucketValues()
+}
+
+// GetFloat64Value returns the number of values counted.
+func (s *StatHistogram) GetFloat64Value(decimals int) float64 {
+	if s.Count == 0 || s.Count < int64(s.MinItems) {
+		return utils.StatsNA
+	}
+	return float64(s.Count)
+}
+
+// bucketIdx returns the index of the bucket counting the value.
+func (s *StatHistogram) bucketIdx(val float64) int {
+	idx, _ := slices.BinarySearch(s.Bounds, val)
+	return idx
+}
+
+// AddEvent counts the event value within its bucket.
+func (s *StatHistogram) AddEvent(evID string, ev utils.DataProvider) error {
+	return s.AddOneEvent(ev)
+}
+
+// AddOneEvent counts the event value within its bucket.
+func (s *StatHistogram) AddOneEvent(ev utils.DataProvider) error {
+	val, err := s.getFieldValue(ev)
+	if err != nil {
+		return err
+	}
+	s.Buckets[s.bucketIdx(val)]++
+	s.Count++
+	return nil
+}
+
+// getFieldValue gets the numeric value from the DataProvider.
+func (s *StatHistogram) getFieldValue(ev utils.DataProvider) (float64, error) {
+	ival, err := utils.DPDynamicInterface(s.FieldName, ev)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return 0, utils.ErrPrefix(err, s.FieldName)
+		}
+		return 0, err
+	}
+	return utils.IfaceAsFloat64(ival)
+}
+
+// RemEvent does nothing, the buckets not knowing the values of single events.
+func (s *StatHistogram) RemEvent(evID string) {}
+
+// Merge adds the buckets of another Histogram metric with the same bounds,
+// used when aggregating time buckets
+func (s *StatHistogram) Merge(m StatMetric) {
+	o, canCast := m.(*StatHistogram)
+	if !canCast || len(o.Buckets) != len(s.Buckets) {
+		return
+	}
+	for i, cnt := range o.Buckets {
+		s.Buckets[i] += cnt
+	}
+	s.Count += o.Count
+}
+
+func (s *StatHistogram) Marshal(ms Marshaler) ([]byte, error) {
+	return ms.Marshal(s)
+}
+
+func (s *StatHistogram) LoadMarshaled(ms Marshaler, marshaled []byte) error {
+	return ms.Unmarshal(marshaled, &s)
+}
+
+// GetFilterIDs is part of StatMetric interface.
+func (s *StatHistogram) GetFilterIDs() []string {
+	return s.FilterIDs
+}
+
+// GetMinItems returns the minimum items for the metric.
+func (s *StatHistogram) GetMinItems() int { return s.MinItems }
+
+// Compress is part of StatMetric interface, the buckets keeping no events.
+func (s *StatHistogram) Compress(queueLen int64, defaultID string, decimals int) []string {
+	return nil
+}
+
+func (s *StatHistogram) GetCompressFactor(events map[string]int) map[string]int {
+	return events
+}
+
+func (s *StatHistogram) isSketch() {}
+
+const (
+	anomalyDefaultAlpha   = 0.3 // weight of the latest week within the baseline
+	anomalyMinSamples     = 3   // weeks needed within a slot before reporting deviations
+	anomalySampleDecimals = 9   // precision the wrapped metric is sampled with
+)
+
+// NewStatAnomaly creates a StatAnomaly metric out of the [<alpha>#]<metricID> parameters,
+// wrapping the metric with the given ID.
+func NewStatAnomaly(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	alpha := anomalyDefaultAlpha
+	metricID := extraParams
+	if alphaStr, wrappedID, has := strings.Cut(extraParams, utils.HashtagSep); has &&
+		!strings.HasPrefix(alphaStr, utils.Meta) {
+		var err error
+		if alpha, err = strconv.ParseFloat(alphaStr, 64); err != nil || alpha <= 0 || alpha > 1 {
+			return nil, fmt.Errorf("invalid <%s> metric alpha: <%s>", utils.MetaAnomaly, alphaStr)
+		}
+		metricID = wrappedID
+	}
+	if metricID == utils.EmptyString ||
+		strings.HasPrefix(metricID, utils.MetaAnomaly) {
+		return nil, fmt.Errorf("invalid <%s> metric parameters: <%s>", utils.MetaAnomaly, extraParams)
+	}
+	metric, err := NewStatMetric(metricID, minItems, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StatAnomaly{
+		FilterIDs: filterIDs,
+		MinItems:  minItems,
+		MetricID:  metricID,
+		Alpha:     alpha,
+		Metric:    metric,
+		Baseline:  make(map[int]*StatBaseline),
+	}, nil
+}
+
+// StatBaseline is the exponentially weighted mean and variance of a metric
+// value within one slot of the week.
+type StatBaseline struct {
+	Mean     float64
+	Variance float64
+	Count    int64 // number of values learned
+}
+
+// add learns a new value, alpha being the weight given to it
+func (b *StatBaseline) add(val, alpha float64) {
+	if b.Count == 0 {
+		b.Mean = val
+	} else {
+		diff := val - b.Mean
+		incr := alpha * diff
+		b.Mean += incr
+		b.Variance = (1 - alpha) * (b.Variance + diff*incr)
+	}
+	b.Count++
+}
+
+// StatAnomaly reports the deviation of a wrapped metric from its seasonal baseline,
+// as z-score against the mean and variance learned for the current hour of the week.
+type StatAnomaly struct {
+	FilterIDs []string // event filters to apply before processing
+	MinItems  int      // minimum events required for valid results
+	MetricID  string   // ID of the wrapped metric
+	Alpha     float64  // weight of the latest week when learning the baseline
+
+	Metric   StatMetric            // wrapped metric
+	Baseline map[int]*StatBaseline // baseline indexed by hour of the week
+
+	SlotStart time.Time // start of the hour currently sampled
+	SlotSum   float64   // sum of the values sampled within the current hour
+	SlotCount int64     // number of values sampled within the current hour
+}
+
+// anomalySlot returns the hour of the week for the time
+func anomalySlot(t time.Time) int {
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// Clone creates a deep copy of StatAnomaly.
+func (s *StatAnomaly) Clone() StatMetric {
+	if s == nil {
+		return nil
+	}
+	clone := &StatAnomaly{
+		FilterIDs: slices.Clone(s.FilterIDs),
+		MinItems:  s.MinItems,
+		MetricID:  s.MetricID,
+		Alpha:     s.Alpha,
+		SlotStart: s.SlotStart,
+		SlotSum:   s.SlotSum,
+		SlotCount: s.SlotCount,
+	}
+	if s.Metric != nil {
+		clone.Metric = s.Metric.Clone()
+	}
+	if s.Baseline != nil {
+		clone.Baseline = make(map[int]*StatBaseline, len(s.Baseline))
+		for slot, b := range s.Baseline {
+			bCln := *b
+			clone.Baseline[slot] = &bCln
+		}
+	}
+	return clone
+}
+
+// rollSlot learns the average of the values sampled within the previous hour once a new one starts
+func (s *StatAnomaly) rollSlot(now time.Time) {
+	slotStart := now.Truncate(time.Hour)
+	if slotStart.Equal(s.SlotStart) {
+		return
+	}
+	if s.SlotCount != 0 {
+		slot := anomalySlot(s.SlotStart)
+		b, has := s.Baseline[slot]
+		if !has {
+			b = new(StatBaseline)
+			s.Baseline[slot] = b
+		}
+		b.add(s.SlotSum/float64(s.SlotCount), s.Alpha)
+	}
+	s.SlotStart = slotStart
+	s.SlotSum = 0
+	s.SlotCount = 0
+}
+
+// sample records the value of the wrapped metric for the current hour
+func (s *StatAnomaly) sample(now time.Time) {
+	s.rollSlot(now)
+	val := s.Metric.GetFloat64Value(anomalySampleDecimals)
+	if val == utils.StatsNA {
+		return
+	}
+	s.SlotSum += val
+	s.SlotCount++
+}
+
+// getValue returns the z-score of the wrapped metric, not available until the slot
+// of the week learned enough values. The slots are only rolled when events are added or removed.
+func (s *StatAnomaly) getValue(now time.Time, decimals int) float64 {
+	b, has := s.Baseline[anomalySlot(now)]
+	if !has || b.Count < anomalyMinSamples || b.Variance <= 0 {
+		return utils.StatsNA
+	}
+	val := s.Metric.GetFloat64Value(decimals)
+	if val == utils.StatsNA {
+		return utils.StatsNA
+	}
+	return utils.Round((val-b.Mean)/math.Sqrt(b.Variance), decimals, utils.MetaRoundingMiddle)
+}
+
+// GetStringValue returns the z-score as string.
+func (s *StatAnomaly) GetStringValue(decimals int) string {
+	val := s.getValue(time.Now(), decimals)
+	if val == utils.StatsNA {
+		return utils.NotAvailable
+	}
+	return strconv.FormatFloat(val, 'f', -1, 64)
+}
+
+// GetValue returns the z-score.
+func (s *StatAnomaly) GetValue(decimals int) any {
+	return s.getValue(time.Now(), decimals)
+}
+
+// GetFloat64Value returns the z-score.
+func (s *StatAnomaly) GetFloat64Value(decimals int) float64 {
+	return s.getValue(time.Now(), decimals)
+}
+
+// AddEvent passes the event to the wrapped metric, sampling its new value.
+func (s *StatAnomaly) AddEvent(evID string, ev utils.DataProvider) error {
+	if err := s.Metric.AddEvent(evID, ev); err != nil {
+		return err
+	}
+	s.sample(time.Now())
+	return nil
+}
+
+// AddOneEvent passes the event to the wrapped metric, sampling its new value.
+func (s *StatAnomaly) AddOneEvent(ev utils.DataProvider) error {
+	if err := s.Metric.AddOneEvent(ev); err != nil {
+		return err
+	}
+	s.sample(time.Now())
+	return nil
+}
+
+// RemEvent removes the event from the wrapped metric, learning the previous hour if a new one started.
+func (s *StatAnomaly) RemEvent(evID string) {
+	s.Metric.RemEvent(evID)
+	s.rollSlot(time.Now())
+}
+
+// storedStatAnomaly is the StatAnomaly with its wrapped metric marshaled
+type storedStatAnomaly struct {
+	FilterIDs []string
+	MinItems  int
+	MetricID  string
+	Alpha     float64
+	Metric    []byte
+	Baseline  map[int]*StatBaseline
+	SlotStart time.Time
+	SlotSum   float64
+	SlotCount int64
+}
+
+func (s *StatAnomaly) Marshal(ms Marshaler) ([]byte, error) {
+	metric, err := s.Metric.Marshal(ms)
+	if err != nil {
+		return nil, err
+	}
+	return ms.Marshal(&storedStatAnomaly{
+		FilterIDs: s.FilterIDs,
+		MinItems:  s.MinItems,
+		MetricID:  s.MetricID,
+		Alpha:     s.Alpha,
+		Metric:    metric,
+		Baseline:  s.Baseline,
+		SlotStart: s.SlotStart,
+		SlotSum:   s.SlotSum,
+		SlotCount: s.SlotCount,
+	})
+}
+
+func (s *StatAnomaly) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	var sSA storedStatAnomaly
+	if err = ms.Unmarshal(marshaled, &sSA); err != nil {
+		return
+	}
+	if s.Metric, err = NewStatMetric(sSA.MetricID, sSA.MinItems, nil); err != nil {
+		return
+	}
+	if err = s.Metric.LoadMarshaled(ms, sSA.Metric); err != nil {
+		return
+	}
+	s.FilterIDs = sSA.FilterIDs
+	s.MinItems = sSA.MinItems
+	s.MetricID = sSA.MetricID
+	s.Alpha = sSA.Alpha
+	s.Baseline = sSA.Baseline
+	if s.Baseline == nil {
+		s.Baseline = make(map[int]*StatBaseline)
+	}
+	s.SlotStart = sSA.SlotStart
+	s.SlotSum = sSA.SlotSum
+	s.SlotCount = sSA.SlotCount
+	return
+}
+
+// UnmarshalJSON decodes the wrapped metric based on the MetricID.
+func (s *StatAnomaly) UnmarshalJSON(data []byte) (err error) {
+	var tmp struct {
+		FilterIDs []string
+		MinItems  int
+		MetricID  string
+		Alpha     float64
+		Metric    json.RawMessage
+		Baseline  map[int]*StatBaseline
+		SlotStart time.Time
+		SlotSum   float64
+		SlotCount int64
+	}
+	if err = json.Unmarshal(data, &tmp); err != nil {
+		return
+	}
+	var metrics map[string]StatMetric
+	if metrics, err = unmarshalStatMetrics(map[string]json.RawMessage{tmp.MetricID: tmp.Metric}); err != nil {
+		return
+	}
+	s.FilterIDs = tmp.FilterIDs
+	s.MinItems = tmp.MinItems
+	s.MetricID = tmp.MetricID
+	s.Alpha = tmp.Alpha
+	s.Metric = metrics[tmp.MetricID]
+	s.Baseline = tmp.Baseline
+	if s.Baseline == nil {
+		s.Baseline = make(map[int]*StatBaseline)
+	}
+	s.SlotStart = tmp.SlotStart
+	s.SlotSum = tmp.SlotSum
+	s.SlotCount = tmp.SlotCount
+	return
+}
+
+// GetFilterIDs is part of StatMetric interface.
+func (s *StatAnomaly) GetFilterIDs() []string {
+	return s.FilterIDs
+}
+
+// GetMinItems returns the minimum items for the metric.
+func (s *StatAnomaly) GetMinItems() int { return s.MinItems }
+
+// Compress is part of StatMetric interface, compressing the wrapped metric.
+func (s *StatAnomaly) Compress(queueLen int64, defaultID string, decimals int) []string {
+	return s.Metric.Compress(queueLen, defaultID, decimals)
+}
+
+func (s *StatAnomaly) GetCompressFactor(events map[string]int) map[string]int {
+	return s.Metric.GetCompressFactor(events)
+}
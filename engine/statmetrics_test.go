@@ -18,6 +18,8 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package engine
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"math"
 	"net"
@@ -3307,6 +3309,24 @@ func TestStatMetricsNewStatMetricError(t *testing.T) {
 
 }
 
+func TestStatMetricsNewStatMetricParams(t *testing.T) {
+	// the metrics taking one parameter ignore the ones following it
+	sum, err := NewStatMetric("*sum#~*req.Cost#extra", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := sum.(*StatSum).Fields.GetRule(utils.InfieldSep); rcv != "~*req.Cost" {
+		t.Errorf("expected <~*req.Cost>, received <%s>", rcv)
+	}
+	prc, err := NewStatMetric("*percentile#95#~*req.Usage", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := prc.(*StatPercentile); rcv.Percentile != 95 || rcv.FieldName != "~*req.Usage" {
+		t.Errorf("unexpected metric: %s", utils.ToJSON(rcv))
+	}
+}
+
 func TestStatMetricsGetMinItems(t *testing.T) {
 	asr, _ := NewASR(2, "", []string{})
 	result := asr.GetMinItems()
@@ -6687,3 +6707,459 @@ func TestStatLowestGetMinItems(t *testing.T) {
 		t.Errorf("expected MinItems 10, got %d", got)
 	}
 }
+
+func TestStatPercentile(t *testing.T) {
+	metric, err := NewStatMetric("*percentile#95#~*req.Usage", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p95 := metric.(*StatPercentile)
+	if p95.Percentile != 95 || p95.FieldName != "~*req.Usage" {
+		t.Errorf("unexpected metric: %s", utils.ToJSON(p95))
+	}
+	if rcv := p95.GetStringValue(2); rcv != utils.NotAvailable {
+		t.Errorf("expected %s, received %s", utils.NotAvailable, rcv)
+	}
+	for i := 1; i <= 100; i++ {
+		if err = p95.AddEvent("ev"+strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Usage: float64(i)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv := p95.GetFloat64Value(2); math.Abs(rcv-95) > 95*sketchRelativeAccuracy {
+		t.Errorf("expected 95 within 1%%, received %v", rcv)
+	}
+	// the sketch keeps only the bins, single events cannot be removed
+	p95.RemEvent("ev100")
+	if p95.Count != 100 || len(p95.Bins) > 100 {
+		t.Errorf("expected 100 values within at most 100 bins, received %d/%d", p95.Count, len(p95.Bins))
+	}
+	if rcv := p95.Compress(10, "ev100", 2); rcv != nil {
+		t.Errorf("expected no events to compress, received %v", rcv)
+	}
+	// the time buckets holding the values up to 50 merged
+	merged, err := NewStatMetric("*percentile#95#~*req.Usage", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, vals := range [][2]int{{1, 25}, {26, 50}} {
+		bkt, err := NewStatMetric("*percentile#95#~*req.Usage", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := vals[0]; i <= vals[1]; i++ {
+			if err = bkt.AddOneEvent(utils.MapStorage{
+				utils.MetaReq: map[string]any{utils.Usage: float64(i)},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		merged.(statMetricMerger).Merge(bkt)
+	}
+	if rcv := merged.GetFloat64Value(2); math.Abs(rcv-47) > 47*sketchRelativeAccuracy {
+		t.Errorf("expected 47 within 1%%, received %v", rcv)
+	}
+	if err = p95.AddEvent("ev101", utils.MapStorage{}); err == nil ||
+		err.Error() != "NOT_FOUND:~*req.Usage" {
+		t.Errorf("expected NOT_FOUND:~*req.Usage, received %v", err)
+	}
+}
+
+func TestStatPercentileAliases(t *testing.T) {
+	for metricID, exp := range map[string]float64{
+		"*p50#~*req.Cost": 50,
+		"*p95#~*req.Cost": 95,
+		"*p99#~*req.Cost": 99,
+	} {
+		metric, err := NewStatMetric(metricID, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv := metric.(*StatPercentile); rcv.Percentile != exp || rcv.FieldName != "~*req.Cost" {
+			t.Errorf("%s: unexpected metric: %s", metricID, utils.ToJSON(rcv))
+		}
+	}
+	for _, metricID := range []string{
+		"*percentile#~*req.Cost",
+		"*percentile#101#~*req.Cost",
+		"*percentile#95",
+		"*p95",
+	} {
+		if _, err := NewStatMetric(metricID, 0, nil); err == nil {
+			t.Errorf("%s: expected error", metricID)
+		}
+	}
+}
+
+func TestStatPercentileNegativeAndZero(t *testing.T) {
+	metric, err := NewStatPercentile(0, "50#~*req.Cost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, val := range []float64{-300, -2, 0, 0, 1.5} {
+		if err = metric.AddEvent(strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Cost: val},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv := metric.GetFloat64Value(-1); rcv != 0 {
+		t.Errorf("expected 0, received %v", rcv)
+	}
+	lowest, err := NewStatPercentile(0, "0#~*req.Cost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lowest.(statMetricMerger).Merge(metric)
+	if rcv := lowest.GetFloat64Value(2); math.Abs(rcv+300) > 300*sketchRelativeAccuracy {
+		t.Errorf("expected -300 within 1%%, received %v", rcv)
+	}
+}
+
+func TestStatPercentileMarshalClone(t *testing.T) {
+	metric, err := NewStatMetric("*p99#~*req.Cost", 2, []string{"*string:~*req.Account:1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 10 {
+		if err = metric.AddEvent(strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Cost: float64(i * 10)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exp := metric.GetFloat64Value(2)
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		marshaled, err := metric.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := NewStatMetric("*p99#~*req.Cost", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = loaded.LoadMarshaled(ms, marshaled); err != nil {
+			t.Fatal(err)
+		}
+		if rcv := loaded.GetFloat64Value(2); rcv != exp {
+			t.Errorf("expected %v, received %v", exp, rcv)
+		}
+	}
+	clone := metric.Clone().(*StatPercentile)
+	if !reflect.DeepEqual(clone, metric) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(metric), utils.ToJSON(clone))
+	}
+	if err = clone.AddEvent("10", utils.MapStorage{
+		utils.MetaReq: map[string]any{utils.Cost: 1000.},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if metric.(*StatPercentile).Count != 10 || metric.GetFloat64Value(2) != exp {
+		t.Error("original affected by the clone modification")
+	}
+}
+
+func TestStatHistogram(t *testing.T) {
+	metric, err := NewStatMetric("*histogram#~*req.Usage#10|30|60", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hist := metric.(*StatHistogram)
+	if !reflect.DeepEqual(hist.Bounds, []float64{10, 30, 60}) || hist.FieldName != "~*req.Usage" {
+		t.Errorf("unexpected metric: %s", utils.ToJSON(hist))
+	}
+	for i, usage := range []float64{5, 10, 20, 45, 90} {
+		if err = hist.AddEvent(strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Usage: usage},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			if rcv := hist.GetStringValue(0); rcv != utils.NotAvailable {
+				t.Errorf("expected %s, received %s", utils.NotAvailable, rcv)
+			}
+			if rcv := hist.BucketValues()["30"]; rcv != utils.StatsNA {
+				t.Errorf("expected %v, received %v", utils.StatsNA, rcv)
+			}
+		}
+	}
+	if rcv, exp := hist.GetStringValue(0), "10:2;30:3;60:4;+Inf:5"; rcv != exp {
+		t.Errorf("expected %s, received %s", exp, rcv)
+	}
+	if rcv := hist.GetFloat64Value(0); rcv != 5 {
+		t.Errorf("expected 5, received %v", rcv)
+	}
+	exp := map[string]float64{"10": 2, "30": 3, "60": 4, utils.PlusInf: 5}
+	if rcv := hist.GetValue(0); !reflect.DeepEqual(rcv, exp) {
+		t.Errorf("expected %v, received %v", exp, rcv)
+	}
+	hist.RemEvent("0")
+	if rcv, exp := hist.GetStringValue(0), "10:2;30:3;60:4;+Inf:5"; rcv != exp {
+		t.Errorf("expected single events not to be removed, received %s", rcv)
+	}
+	for _, metricID := range []string{
+		"*histogram#~*req.Usage",
+		"*histogram#~*req.Usage#30|10",
+		"*histogram#~*req.Usage#10|a",
+	} {
+		if _, err := NewStatMetric(metricID, 0, nil); err == nil {
+			t.Errorf("%s: expected error", metricID)
+		}
+	}
+}
+
+func TestStatHistogramMarshalClone(t *testing.T) {
+	metric, err := NewStatMetric("*histogram#~*req.Cost#1|2.5", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, cost := range []float64{0.5, 2, 3, 3} {
+		if err = metric.AddEvent(strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Cost: cost},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		marshaled, err := metric.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := NewStatMetric("*histogram#~*req.Cost#1|2.5", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = loaded.LoadMarshaled(ms, marshaled); err != nil {
+			t.Fatal(err)
+		}
+		if rcv, exp := loaded.GetStringValue(0), "1:1;2.5:2;+Inf:4"; rcv != exp {
+			t.Errorf("expected %s, received %s", exp, rcv)
+		}
+	}
+	clone := metric.Clone()
+	if !reflect.DeepEqual(clone, metric) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(metric), utils.ToJSON(clone))
+	}
+	if err = clone.AddEvent("4", utils.MapStorage{
+		utils.MetaReq: map[string]any{utils.Cost: 0.1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, exp := metric.GetStringValue(0), "1:1;2.5:2;+Inf:4"; rcv != exp {
+		t.Errorf("original affected by the clone modification, received %s", rcv)
+	}
+}
+
+func TestStatMetricsGobStatQueue(t *testing.T) {
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ_GOB",
+		SQMetrics: make(map[string]StatMetric),
+	}
+	for _, metricID := range []string{
+		"*percentile#95#~*req.Cost",
+		"*p50#~*req.Cost",
+		"*histogram#~*req.Cost#1|2.5",
+		"*anomaly#0.5#*sum#~*req.Cost",
+	} {
+		metric, err := NewStatMetric(metricID, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, cost := range []float64{0.5, 2, 3, 3} {
+			if err = metric.AddEvent(strconv.Itoa(i), utils.MapStorage{
+				utils.MetaReq: map[string]any{utils.Cost: cost},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		sq.SQMetrics[metricID] = metric
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sq); err != nil {
+		t.Fatal(err)
+	}
+	var rcv StatQueue
+	if err := gob.NewDecoder(&buf).Decode(&rcv); err != nil {
+		t.Fatal(err)
+	}
+	if len(rcv.SQMetrics) != len(sq.SQMetrics) {
+		t.Fatalf("expected %s, received %s", utils.ToJSON(sq), utils.ToJSON(rcv))
+	}
+	for metricID, metric := range sq.SQMetrics {
+		if rcvMetric, has := rcv.SQMetrics[metricID]; !has {
+			t.Errorf("missing metric %s", metricID)
+		} else if exp, val := utils.ToJSON(metric), utils.ToJSON(rcvMetric); val != exp {
+			t.Errorf("expected %s for %s, received %s", exp, metricID, val)
+		}
+	}
+}
+
+func TestStatMetricsMerge(t *testing.T) {
+	evs := []utils.MapStorage{
+		{utils.MetaReq: map[string]any{
+			utils.AnswerTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), utils.Usage: 10 * time.Second,
+			utils.Cost: 1.5, utils.PDD: time.Second, utils.Destination: "1001", utils.ReplyState: utils.OK}},
+		{utils.MetaReq: map[string]any{
+			utils.Usage: 30 * time.Second, utils.Cost: 2.5, utils.PDD: 3 * time.Second,
+			utils.Destination: "1002", utils.ReplyState: "ERR_CDRS"}},
+		{utils.MetaReq: map[string]any{
+			utils.AnswerTime: time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC), utils.Usage: 20 * time.Second,
+			utils.Cost: 7, utils.PDD: 2 * time.Second, utils.Destination: "1001", utils.ReplyState: utils.OK}},
+	}
+	for _, metricID := range []string{
+		utils.MetaASR, utils.MetaACD, utils.MetaTCD, utils.MetaACC, utils.MetaTCC, utils.MetaPDD, utils.MetaDDC,
+		utils.MetaSum + "#~*req.Cost", utils.MetaAverage + "#~*req.Cost", utils.MetaDistinct + "#~*req.Destination",
+		utils.MetaHighest + "#~*req.Cost", utils.MetaLowest + "#~*req.Cost", utils.MetaREPSC, utils.MetaREPFC,
+		utils.MetaP50 + "#~*req.Cost", utils.MetaHistogram + "#~*req.Cost#0|5",
+	} {
+		t.Run(metricID, func(t *testing.T) {
+			all, err := NewStatMetric(metricID, 2, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			merged, err := NewStatMetric(metricID, 2, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ev := range evs {
+				if err = all.AddOneEvent(ev); err != nil {
+					t.Fatal(err)
+				}
+				bkt, err := NewStatMetric(metricID, 0, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = bkt.AddOneEvent(ev); err != nil {
+					t.Fatal(err)
+				}
+				merger, canMerge := merged.(statMetricMerger)
+				if !canMerge {
+					t.Fatalf("metric %s cannot be merged", metricID)
+				}
+				merger.Merge(bkt)
+			}
+			if exp, rcv := all.GetStringValue(4), merged.GetStringValue(4); exp != rcv {
+				t.Errorf("expected %s, received %s", exp, rcv)
+			}
+		})
+	}
+}
+
+func TestStatAnomalyParams(t *testing.T) {
+	metric, err := NewStatMetric("*anomaly#*asr", 2, []string{"*string:~*req.Account:1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	anomaly := metric.(*StatAnomaly)
+	if anomaly.MetricID != utils.MetaASR || anomaly.Alpha != anomalyDefaultAlpha ||
+		anomaly.MinItems != 2 || anomaly.Metric.GetMinItems() != 2 {
+		t.Errorf("unexpected metric: %s", utils.ToJSON(anomaly))
+	}
+	if metric, err = NewStatMetric("*anomaly#0.5#*sum#~*req.Cost", 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if anomaly = metric.(*StatAnomaly); anomaly.MetricID != "*sum#~*req.Cost" || anomaly.Alpha != 0.5 {
+		t.Errorf("unexpected metric: %s", utils.ToJSON(anomaly))
+	}
+	for _, metricID := range []string{
+		"*anomaly", "*anomaly#1.5#*asr", "*anomaly#0.5", "*anomaly#*anomaly#*asr", "*anomaly#*unknown",
+	} {
+		if _, err := NewStatMetric(metricID, 0, nil); err == nil {
+			t.Errorf("expected error for <%s>", metricID)
+		}
+	}
+}
+
+func TestStatAnomalyBaseline(t *testing.T) {
+	metric, err := NewStatMetric("*anomaly#*sum#~*req.Cost", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anomaly := metric.(*StatAnomaly)
+	tuesday := time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local)
+	// learn the sum seen on Tuesday at 10:00 during 4 weeks
+	for week, cost := range []float64{10, 12, 8, 10} {
+		now := tuesday.AddDate(0, 0, 7*week)
+		if rcv := anomaly.getValue(now, 2); week < anomalyMinSamples && rcv != utils.StatsNA {
+			t.Errorf("week %d: expected N/A before learning, received %v", week, rcv)
+		}
+		if err = anomaly.Metric.AddEvent("ev", utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Cost: cost}}); err != nil {
+			t.Fatal(err)
+		}
+		anomaly.sample(now.Add(10 * time.Minute))
+		anomaly.sample(now.Add(20 * time.Minute))
+		anomaly.Metric.RemEvent("ev")
+	}
+	now := tuesday.AddDate(0, 0, 28)
+	anomaly.getValue(now, 2)
+	if b := anomaly.Baseline[anomalySlot(tuesday)]; b.Count != 3 {
+		t.Errorf("expected reading not to learn the last week, received %d weeks", b.Count)
+	}
+	if err = anomaly.Metric.AddEvent("ev", utils.MapStorage{
+		utils.MetaReq: map[string]any{utils.Cost: 2.}}); err != nil {
+		t.Fatal(err)
+	}
+	anomaly.sample(now)
+	// mean 9.874 with variance 1.412124 after the 4 weeks
+	if rcv := anomaly.getValue(now, 2); rcv != -6.63 {
+		t.Errorf("expected -6.63, received %v", rcv)
+	}
+	if b := anomaly.Baseline[anomalySlot(tuesday)]; b.Count != 4 {
+		t.Errorf("expected 4 weeks learned, received %d", b.Count)
+	}
+	// nothing learned for Wednesday
+	if rcv := anomaly.getValue(now.AddDate(0, 0, 1), 2); rcv != utils.StatsNA {
+		t.Errorf("expected N/A, received %v", rcv)
+	}
+	if _, canMerge := metric.(statMetricMerger); canMerge {
+		t.Error("expected the anomaly metric not to be mergeable")
+	}
+}
+
+func TestStatAnomalyMarshalClone(t *testing.T) {
+	metric, err := NewStatMetric("*anomaly#*acd", 1, []string{"*string:~*req.Account:1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = metric.AddEvent("ev1", utils.MapStorage{
+		utils.MetaReq: map[string]any{utils.Usage: time.Minute}}); err != nil {
+		t.Fatal(err)
+	}
+	anomaly := metric.(*StatAnomaly)
+	anomaly.Baseline[1] = &StatBaseline{Mean: 30, Variance: 4, Count: 5}
+	exp := utils.ToJSON(metric)
+	for _, ms := range []Marshaler{new(JSONMarshaler), NewCodecMsgpackMarshaler()} {
+		marshaled, err := metric.Marshal(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := NewStatMetric("*anomaly#*acd", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = loaded.LoadMarshaled(ms, marshaled); err != nil {
+			t.Fatal(err)
+		}
+		if rcv := utils.ToJSON(loaded); rcv != exp {
+			t.Errorf("expected %s, received %s", exp, rcv)
+		}
+		loaded.RemEvent("ev1")
+		if rcv := loaded.(*StatAnomaly).Metric.GetFloat64Value(2); rcv != utils.StatsNA {
+			t.Errorf("expected the wrapped event removed, received %v", rcv)
+		}
+	}
+	var sq *StatQueue
+	if err = json.Unmarshal([]byte(utils.ToJSON(&StatQueue{
+		SQMetrics: map[string]StatMetric{"*anomaly#*acd": metric}})), &sq); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := utils.ToJSON(sq.SQMetrics["*anomaly#*acd"]); rcv != exp {
+		t.Errorf("expected %s, received %s", exp, rcv)
+	}
+	if clone := metric.Clone(); !reflect.DeepEqual(clone, metric) {
+		t.Errorf("expected %s, received %s", exp, utils.ToJSON(clone))
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"maps"
 	"runtime"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
@@ -509,6 +510,15 @@ func (sS *StatService) V1GetQueueStringMetrics(ctx *context.Context, args *utils
 	metrics := make(map[string]string, len(sq.SQMetrics))
 	for metricID, metric := range sq.SQMetrics {
 		metrics[metricID] = metric.GetStringValue(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+		if hist, isHist := metric.(*StatHistogram); isHist { // one more metric for each bucket
+			for bound, val := range hist.BucketValues() {
+				bucketVal := utils.NotAvailable
+				if val != utils.StatsNA {
+					bucketVal = strconv.FormatFloat(val, 'f', -1, 64)
+				}
+				metrics[metricID+utils.HashtagSep+bound] = bucketVal
+			}
+		}
 	}
 	*reply = metrics
 	return
@@ -538,6 +548,11 @@ func (sS *StatService) V1GetQueueFloatMetrics(ctx *context.Context, args *utils.
 	metrics := make(map[string]float64, len(sq.SQMetrics))
 	for metricID, metric := range sq.SQMetrics {
 		metrics[metricID] = metric.GetFloat64Value(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+		if hist, isHist := metric.(*StatHistogram); isHist { // one more metric for each bucket
+			for bound, val := range hist.BucketValues() {
+				metrics[metricID+utils.HashtagSep+bound] = val
+			}
+		}
 	}
 	*reply = metrics
 	return
@@ -581,6 +596,7 @@ func (sS *StatService) V1ResetStatQueue(ctx *context.Context, tntID *utils.Tenan
 		return
 	}
 	sq.SQItems = make([]SQItem, 0)
+	sq.SQBuckets = nil
 	metrics := sq.SQMetrics
 	sq.SQMetrics = make(map[string]StatMetric)
 	for id, m := range metrics {
@@ -24,6 +24,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -3274,3 +3275,66 @@ func TestStatQueueProcessEventErr(t *testing.T) {
 	}
 
 }
+
+func TestStatQueueV1GetQueueMetricsHistogram(t *testing.T) {
+	tmpC := config.CgrConfig()
+	defer func() {
+		config.SetCgrConfig(tmpC)
+	}()
+
+	cfg := config.NewDefaultCGRConfig()
+	data, dErr := NewInternalDB(nil, nil, true, nil, config.CgrConfig().DataDbCfg().Items)
+	if dErr != nil {
+		t.Error(dErr)
+	}
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	sS := NewStatService(dm, cfg, NewFilterS(cfg, nil, dm), nil)
+
+	histID := "*histogram#~*req.Usage#10|60"
+	hist, err := NewStatMetric(histID, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, usage := range []float64{5, 30, 90} {
+		if err = hist.AddEvent(strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: map[string]any{utils.Usage: usage},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = dm.SetStatQueue(&StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ_HIST",
+		SQMetrics: map[string]StatMetric{histID: hist},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expFloat := map[string]float64{
+		histID:           3,
+		histID + "#10":   1,
+		histID + "#60":   2,
+		histID + "#+Inf": 3,
+	}
+	var floatRply map[string]float64
+	if err = sS.V1GetQueueFloatMetrics(context.Background(),
+		&utils.TenantID{ID: "SQ_HIST"}, &floatRply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(floatRply, expFloat) {
+		t.Errorf("expected: <%+v>, received: <%+v>", expFloat, floatRply)
+	}
+	expString := map[string]string{
+		histID:           "10:1;60:2;+Inf:3",
+		histID + "#10":   "1",
+		histID + "#60":   "2",
+		histID + "#+Inf": "3",
+	}
+	var strRply map[string]string
+	if err = sS.V1GetQueueStringMetrics(context.Background(),
+		&utils.TenantID{ID: "SQ_HIST"}, &strRply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(strRply, expString) {
+		t.Errorf("expected: <%+v>, received: <%+v>", expString, strRply)
+	}
+}
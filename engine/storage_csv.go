@@ -21,6 +21,7 @@ package engine
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -395,7 +396,7 @@ func appendName(paths []string, fileName string) (out []string) {
 }
 
 func (csvs *CSVStorage) proccesData(listType any, fns []string, process func(any)) error {
-	collumnCount := getColumnCount(listType)
+	collumnCount, requiredCount := getColumnCount(listType)
 	for _, fileName := range fns {
 		csvReader := csvs.generator()
 		err := csvReader.Open(fileName, csvs.sep, collumnCount)
@@ -406,6 +407,13 @@ func (csvs *CSVStorage) proccesData(listType any, fns []string, process func(any
 		if err = func() error { // to execute defer corectly
 			defer csvReader.Close()
 			for record, err := csvReader.Read(); err != io.EOF; record, err = csvReader.Read() {
+				if errors.Is(err, csv.ErrFieldCount) &&
+					len(record) >= requiredCount && len(record) < collumnCount {
+					err = nil // older files without the optional fields
+					for len(record) < collumnCount {
+						record = append(record, utils.EmptyString)
+					}
+				}
 				if err != nil {
 					log.Printf("bad line in %s, %s\n", fileName, err.Error())
 					return err
@@ -21,10 +21,12 @@ package engine
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -495,3 +497,31 @@ func TestGetCfgJSONData(t *testing.T) {
 		}
 	})
 }
+
+func TestCSVStorageOptionalColumns(t *testing.T) {
+	csvs := &CSVStorage{
+		sep:       utils.CSVSep,
+		generator: NewCsvString,
+		ipProfilesFn: []string{`
+#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],TTL[4],Stored[5],Weight[6],PoolID[7],PoolFilterIDs[8],PoolType[9],PoolRange[10],PoolStrategy[11],PoolMessage[12],PoolWeight[13],PoolBlocker[14],PoolPrefixLength[15]
+cgrates.org,IPs1,,,-1,false,10,POOL1,,*ipv4,127.0.0.1/24,*ascending,,10,false
+cgrates.org,IPs2,,,-1,false,10,POOL1,,*ipv6,2001:db8::/48,*ascending,,10,false,64
+`},
+	}
+	tps, err := csvs.GetTPIPs("tpid", utils.EmptyString, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixLengths := make(map[string]int)
+	for _, tp := range tps {
+		prefixLengths[tp.ID] = tp.Pools[0].PrefixLength
+	}
+	if exp := map[string]int{"IPs1": 0, "IPs2": 64}; !reflect.DeepEqual(exp, prefixLengths) {
+		t.Errorf("Expected %v, received: %v", exp, prefixLengths)
+	}
+
+	csvs.ipProfilesFn = []string{`cgrates.org,IPs1,,,-1,false,10,POOL1,,*ipv4,127.0.0.1/24,*ascending,,10`}
+	if _, err = csvs.GetTPIPs("tpid", utils.EmptyString, utils.EmptyString); !errors.Is(err, csv.ErrFieldCount) {
+		t.Errorf("Expected %v, received: %v", csv.ErrFieldCount, err)
+	}
+}
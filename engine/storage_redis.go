@@ -83,8 +83,55 @@ func NewRedisStorage(address string, db int, user, pass, mrshlerStr string,
 	if ms, err = NewMarshaler(mrshlerStr); err != nil {
 		return
 	}
+	var dialOpts []radix.DialOpt
+	if dialOpts, err = redisDialOpts(db, user, pass,
+		connTimeout, readTimeout, writeTimeout,
+		tlsConn, tlsClientCert, tlsClientKey, tlsCACert); err != nil {
+		return
+	}
+	var client radix.Client
+	if client, err = newRedisClient(address, sentinelName,
+		isCluster, clusterSync, clusterOnDownDelay,
+		pipelineWindow, pipelineLimit,
+		maxConns, attempts, dialOpts); err != nil {
+		return
+	}
+	return &RedisStorage{
+		ms:     ms,
+		client: client,
+	}, nil
+}
 
-	dialOpts := make([]radix.DialOpt, 1, 6)
+// NewRedisClient connects to the Redis server(s) at address reusing the
+// credentials and the connection options(sentinel, cluster, TLS) of the DataDB
+func NewRedisClient(address string, dbCfg *config.DataDbCfg) (client radix.Client, err error) {
+	var db int
+	var user, pass string
+	if dbCfg.Type == utils.MetaRedis {
+		if db, err = strconv.Atoi(dbCfg.Name); err != nil {
+			return nil, fmt.Errorf("redis db name must be an integer: %w", err)
+		}
+		user, pass = dbCfg.User, dbCfg.Password
+	}
+	opts := dbCfg.Opts
+	var dialOpts []radix.DialOpt
+	if dialOpts, err = redisDialOpts(db, user, pass,
+		opts.RedisConnectTimeout, opts.RedisReadTimeout, opts.RedisWriteTimeout,
+		opts.RedisTLS, opts.RedisClientCertificate, opts.RedisClientKey, opts.RedisCACertificate); err != nil {
+		return
+	}
+	return newRedisClient(address, opts.RedisSentinel,
+		opts.RedisCluster, opts.RedisClusterSync, opts.RedisClusterOndownDelay,
+		opts.RedisPoolPipelineWindow, opts.RedisPoolPipelineLimit,
+		opts.RedisMaxConns, opts.RedisConnectAttempts, dialOpts)
+}
+
+// redisDialOpts returns the options used to dial each connection, the first one
+// is always selecting the DB so it can be skipped where only one DB is available
+func redisDialOpts(db int, user, pass string,
+	connTimeout, readTimeout, writeTimeout time.Duration,
+	tlsConn bool, tlsClientCert, tlsClientKey, tlsCACert string) (dialOpts []radix.DialOpt, err error) {
+	dialOpts = make([]radix.DialOpt, 1, 6)
 	dialOpts[0] = radix.DialSelectDB(db)
 	if pass != utils.EmptyString {
 		if user == utils.EmptyString {
@@ -127,18 +174,7 @@ func NewRedisStorage(address string, db int, user, pass, mrshlerStr string,
 		radix.DialReadTimeout(readTimeout),
 		radix.DialWriteTimeout(writeTimeout),
 		radix.DialConnectTimeout(connTimeout))
-
-	var client radix.Client
-	if client, err = newRedisClient(address, sentinelName,
-		isCluster, clusterSync, clusterOnDownDelay,
-		pipelineWindow, pipelineLimit,
-		maxConns, attempts, dialOpts); err != nil {
-		return
-	}
-	return &RedisStorage{
-		ms:     ms,
-		client: client,
-	}, nil
+	return
 }
 
 func redisDial(network, addr string, attempts int, opts ...radix.DialOpt) (conn radix.Conn, err error) {
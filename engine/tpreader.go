@@ -1128,6 +1128,11 @@ func (tpr *TpReader) LoadIPProfilesFiltered(tag string) (err error) {
 		if err = verifyInlineFilterS(ip.FilterIDs); err != nil {
 			return
 		}
+		for _, pool := range ip.Pools {
+			if err = CheckIPPoolStrategy(pool.ID, pool.Strategy); err != nil {
+				return fmt.Errorf("IPProfile %q: %v", ip.ID, err)
+			}
+		}
 		mapIPPfls[utils.TenantID{Tenant: ip.Tenant, ID: ip.ID}] = ip
 	}
 	tpr.ipProfiles = mapIPPfls
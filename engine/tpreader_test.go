@@ -1110,6 +1110,34 @@ func TestTPReaderLoadAll(t *testing.T) {
 	}
 }
 
+func TestTPReaderLoadIPProfilesUnsupportedStrategy(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	db, err := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.SetTPIPs([]*utils.TPIPProfile{{
+		TPid:   "TP1",
+		Tenant: "cgrates.org",
+		ID:     "IPS1",
+		Pools: []*utils.TPIPPool{{
+			ID:       "POOL1",
+			Range:    "10.0.0.0/24",
+			Strategy: "*ascendin",
+		}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	tpr, err := NewTpReader(nil, db, "TP1", "local", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expErr := `IPProfile "IPS1": pool "POOL1": unsupported strategy "*ascendin"`
+	if err = tpr.LoadIPProfiles(); err == nil || err.Error() != expErr {
+		t.Errorf("expected error %q, received %v", expErr, err)
+	}
+}
+
 func TestTpReaderReloadScheduler(t *testing.T) {
 	cfg := config.NewDefaultCGRConfig()
 	ccMocK := &ccMock{
@@ -6161,3 +6161,267 @@ func TestResourcesMatchingResourcesForEventFinalCacheSetErr(t *testing.T) {
 		t.Error("expected struct field \"lkID\" to be empty")
 	}
 }
+
+func TestResourcesAllocateRateLimited(t *testing.T) {
+	r := &Resource{
+		Tenant: "cgrates.org",
+		ID:     "RL_RATE",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{
+			Tenant:       "cgrates.org",
+			ID:           "RL_RATE",
+			Limit:        2,
+			RateInterval: time.Second,
+			Burst:        3,
+		},
+	}
+	rs := Resources{r}
+	ru := &ResourceUsage{Tenant: "cgrates.org", ID: "RU1", Units: 1}
+
+	if alcMessage, err := rs.allocateResource(ru, true); err != nil {
+		t.Error(err)
+	} else if !strings.HasPrefix(alcMessage, "RL_RATE;RemainingTokens:2;ResetTime:") {
+		t.Errorf("Wrong allocation message: %v", alcMessage)
+	} else if resetTime, err := time.Parse(time.RFC3339,
+		strings.TrimPrefix(alcMessage, "RL_RATE;RemainingTokens:2;ResetTime:")); err != nil {
+		t.Error(err)
+	} else if wait := time.Until(resetTime); wait > time.Second || wait < -time.Second {
+		t.Errorf("Unexpected reset time: %v", resetTime)
+	}
+	if r.Tokens.Tokens != 3 {
+		t.Errorf("Expected dry run to not consume tokens, received: %v", r.Tokens.Tokens)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := rs.allocateResource(ru, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.Usages) != 0 {
+		t.Errorf("Expected no usages, received: %s", utils.ToJSON(r.Usages))
+	}
+	if r.Tokens.Tokens > 0.01 {
+		t.Errorf("Expected the bucket to be empty, received: %v", r.Tokens.Tokens)
+	}
+	_, err := rs.allocateResource(ru, false)
+	if !errors.Is(err, utils.ErrResourceUnavailable) {
+		t.Fatalf("Expected %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	if rlErr, ok := err.(*utils.ErrRetryAfter); !ok ||
+		rlErr.RetryAfter <= 0 || rlErr.RetryAfter > time.Second/2 {
+		t.Errorf("Unexpected error: %#v", err)
+	} else if expErr := "RESOURCE_UNAVAILABLE;RetryAfter:1s"; err.Error() != expErr {
+		t.Errorf("Expected %v, received: %v", expErr, err)
+	}
+	if _, err := rs.allocateResource(&ResourceUsage{Tenant: "cgrates.org", ID: "RU2",
+		Units: 4}, false); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+
+	// the tokens are not given back on release
+	if err := rs.clearUsage(ru.ID); err != nil {
+		t.Error(err)
+	}
+	if r.Tokens.Tokens > 0.01 {
+		t.Errorf("Expected the bucket to be empty, received: %v", r.Tokens.Tokens)
+	}
+
+	// one second later the bucket is refilled with Limit tokens
+	r.Tokens.LastRefill = r.Tokens.LastRefill.Add(-time.Second)
+	if avail := (&ResourceWithConfig{Resource: r, Config: r.rPrf}).Available(); avail < 2 || avail > 2.01 {
+		t.Errorf("Expected 2 available tokens, received: %v", avail)
+	}
+	if _, err := rs.allocateResource(ru, false); err != nil {
+		t.Error(err)
+	}
+	if r.Tokens.Tokens < 1 || r.Tokens.Tokens > 1.01 {
+		t.Errorf("Expected 1 token left, received: %v", r.Tokens.Tokens)
+	}
+}
+
+func TestResourceProfileCheckRateLimit(t *testing.T) {
+	rp := &ResourceProfile{Limit: -1}
+	if err := rp.checkRateLimit(); err != nil {
+		t.Error(err)
+	}
+	rp.RateInterval = time.Second
+	if err := rp.checkRateLimit(); err == nil {
+		t.Error("Expected error for unlimited rate")
+	}
+	rp.Limit = 10
+	if err := rp.checkRateLimit(); err != nil {
+		t.Error(err)
+	}
+	rp.Burst = -1
+	expErr := "invalid rate limit <10> per <1s> with burst <-1>"
+	if err := rp.checkRateLimit(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received: %v", expErr, err)
+	}
+}
+
+func TestResourcesNestedLimits(t *testing.T) {
+	Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data, dErr := NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if dErr != nil {
+		t.Error(dErr)
+	}
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+
+	for _, rsPrf := range []*ResourceProfile{
+		{
+			Tenant:       "cgrates.org",
+			ID:           "TRUNK1",
+			FilterIDs:    []string{"*string:~*req.Trunk:TRUNK1"},
+			ThresholdIDs: []string{utils.MetaNone},
+			Limit:        10,
+			UsageTTL:     -1,
+		},
+		{
+			Tenant:       "cgrates.org",
+			ID:           "RESELLER1",
+			FilterIDs:    []string{"*string:~*req.Reseller:RESELLER1"},
+			ThresholdIDs: []string{utils.MetaNone},
+			Limit:        3,
+			UsageTTL:     -1,
+			ParentID:     "TRUNK1",
+		},
+		{
+			Tenant:            "cgrates.org",
+			ID:                "CUST1",
+			FilterIDs:         []string{"*string:~*req.Account:1001"},
+			ThresholdIDs:      []string{utils.MetaNone},
+			AllocationMessage: "Customer1",
+			Limit:             2,
+			UsageTTL:          -1,
+			ParentID:          "RESELLER1",
+		},
+		{
+			Tenant:       "cgrates.org",
+			ID:           "CUST2",
+			FilterIDs:    []string{"*string:~*req.Account:1002"},
+			ThresholdIDs: []string{utils.MetaNone},
+			Limit:        5,
+			UsageTTL:     -1,
+			ParentID:     "RESELLER1",
+		},
+	} {
+		if err := dm.SetResourceProfile(rsPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expErr := "cyclic parent chain for resource <cgrates.org:TRUNK1> for item with ID: cgrates.org:TRUNK1"
+	if err := dm.SetResourceProfile(&ResourceProfile{
+		Tenant:   "cgrates.org",
+		ID:       "TRUNK1",
+		Limit:    10,
+		ParentID: "CUST1",
+	}, true); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received: %v", expErr, err)
+	}
+
+	rS := NewResourceService(dm, cfg, NewFilterS(cfg, nil, dm), nil)
+	newEv := func(account, usageID string, units float64) *utils.CGREvent {
+		return &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     utils.GenUUID(),
+			Event: map[string]any{
+				utils.AccountField: account,
+			},
+			APIOpts: map[string]any{
+				utils.OptsResourcesUsageID: usageID,
+				utils.OptsResourcesUnits:   units,
+			},
+		}
+	}
+	usages := func(id string) int {
+		r, err := dm.GetResource("cgrates.org", id, true, false, utils.NonTransactional)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(r.Usages)
+	}
+
+	var reply string
+	if err := rS.V1AllocateResources(context.Background(),
+		newEv("1001", "RU1", 2), &reply); err != nil {
+		t.Fatal(err)
+	} else if exp := "Customer1;RESELLER1;TRUNK1"; reply != exp {
+		t.Errorf("Expected %q, received: %q", exp, reply)
+	}
+	for _, id := range []string{"CUST1", "RESELLER1", "TRUNK1"} {
+		if nrUsages := usages(id); nrUsages != 1 {
+			t.Errorf("Expected 1 usage on %s, received: %d", id, nrUsages)
+		}
+	}
+
+	// the reseller has only one unit left
+	if err := rS.V1AllocateResources(context.Background(),
+		newEv("1002", "RU2", 2), &reply); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	if nrUsages := usages("CUST2"); nrUsages != 0 {
+		t.Errorf("Expected no usages on CUST2, received: %d", nrUsages)
+	}
+	if err := rS.V1AuthorizeResources(context.Background(),
+		newEv("1002", "RU2", 2), &reply); err != utils.ErrResourceUnauthorized {
+		t.Errorf("Expected %v, received: %v", utils.ErrResourceUnauthorized, err)
+	}
+	if err := rS.V1AuthorizeResources(context.Background(),
+		newEv("1002", "RU2", 1), &reply); err != nil {
+		t.Error(err)
+	} else if exp := "CUST2;RESELLER1;TRUNK1"; reply != exp {
+		t.Errorf("Expected %q, received: %q", exp, reply)
+	}
+
+	var rs Resources
+	if err := rS.V1GetResourcesForEvent(context.Background(),
+		newEv("1001", "RU3", 1), &rs); err != nil {
+		t.Error(err)
+	} else if len(rs) != 1 || rs[0].ID != "CUST1" {
+		t.Errorf("Expected only CUST1 to match, received: %s", utils.ToJSON(rs))
+	}
+
+	// releasing gives back the units to all the levels
+	if err := rS.V1ReleaseResources(context.Background(),
+		newEv("1001", "RU1", 2), &reply); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"CUST1", "RESELLER1", "TRUNK1"} {
+		if nrUsages := usages(id); nrUsages != 0 {
+			t.Errorf("Expected no usages on %s, received: %d", id, nrUsages)
+		}
+	}
+	if err := rS.V1AllocateResources(context.Background(),
+		newEv("1002", "RU2", 2), &reply); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResourcesLinkAncestors(t *testing.T) {
+	cust := &Resource{Tenant: "cgrates.org", ID: "CUST1",
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "CUST1", ParentID: "RESELLER1"}}
+	reseller := &Resource{Tenant: "cgrates.org", ID: "RESELLER1", ancestor: true,
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RESELLER1"}}
+	other := &Resource{Tenant: "cgrates.org", ID: "RESELLER2", ancestor: true,
+		rPrf: &ResourceProfile{Tenant: "cgrates.org", ID: "RESELLER2"}}
+	other.lock(utils.EmptyString)
+
+	rs, err := Resources{cust}.linkAncestors(Resources{reseller, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 2 || rs[1] != reseller || cust.parent != reseller {
+		t.Errorf("Unexpected resources: %s", utils.ToJSON(rs))
+	}
+	if other.isLocked() {
+		t.Error("Expected the unused ancestor to be unlocked")
+	}
+
+	reseller.rPrf.ParentID = "CUST1"
+	expErr := "invalid parent resource <CUST1> of <cgrates.org:RESELLER1>"
+	if _, err = (Resources{cust}).linkAncestors(Resources{reseller}); err == nil ||
+		err.Error() != expErr {
+		t.Errorf("Expected %v, received: %v", expErr, err)
+	}
+}
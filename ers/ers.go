@@ -80,6 +80,7 @@ type ERService struct {
 
 	filterS *engine.FilterS
 	connMgr *engine.ConnManager
+	httpSrv utils.Server // where the *http readers register their paths
 
 	partialCache *ltcache.Cache
 }
@@ -116,6 +117,8 @@ func (erS *ERService) V1RunReader(ctx *context.Context, params V1RunReaderParams
 		processReaderDir(rdr.sourceDir, utils.FWVSuffix, rdr.processFile)
 	case *JSONFileER:
 		processReaderDir(rdr.sourceDir, utils.JSNSuffix, rdr.processFile)
+	case *ParquetFileER:
+		processReaderDir(rdr.sourceDir, utils.ParquetSuffix, rdr.processFile)
 	default:
 		return errors.New("reader type does not yet support manual processing")
 	}
@@ -226,10 +229,18 @@ func (erS *ERService) addReader(rdrID string, cfgIdx int) (err error) {
 		erS.filterS, erS.stopLsn[rdrID], erS.dataManager); err != nil {
 		return
 	}
+	if httpRdr, isHTTP := rdr.(*HTTPER); isHTTP {
+		httpRdr.server = erS.httpSrv
+	}
 	erS.rdrs[rdrID] = rdr
 	return rdr.Serve()
 }
 
+// SetHTTPServer sets the server used by the *http readers, called before ListenAndServe
+func (erS *ERService) SetHTTPServer(server utils.Server) {
+	erS.httpSrv = server
+}
+
 // processEvent will be called each time a new event is received from readers
 func (erS *ERService) processEvent(cgrEv *utils.CGREvent,
 	rdrCfg *config.EventReaderCfg) (err error) {
@@ -0,0 +1,283 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func NewParquetFileER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	srcPath := cfg.ERsCfg().Readers[cfgIdx].SourcePath
+	if strings.HasSuffix(srcPath, utils.Slash) {
+		srcPath = srcPath[:len(srcPath)-1]
+	}
+	return &ParquetFileER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		sourceDir:     srcPath,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrError:      rdrErr,
+		rdrExit:       rdrExit,
+		conReqs:       make(chan struct{}, cfg.ERsCfg().Readers[cfgIdx].ConcurrentReqs),
+	}, nil
+}
+
+// ParquetFileER implements EventReader interface for .parquet files
+type ParquetFileER struct {
+	sync.RWMutex
+	cgrCfg        *config.CGRConfig
+	cfgIdx        int // index of config instance within ERsCfg.Readers
+	fltrS         *engine.FilterS
+	sourceDir     string        // path to the directory monitored by the reader for new events
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrError      chan error
+	rdrExit       chan struct{}
+	conReqs       chan struct{} // limit number of opened files
+}
+
+func (rdr *ParquetFileER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+func (rdr *ParquetFileER) serveDefault() {
+	if rdr.Config().StartDelay > 0 {
+		select {
+		case <-time.After(rdr.Config().StartDelay):
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring path <%s>",
+					utils.ERs, rdr.sourceDir))
+			return
+		}
+	}
+	tm := time.NewTimer(0)
+	for {
+		// Not automated, process and sleep approach
+		select {
+		case <-rdr.rdrExit:
+			tm.Stop()
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring path <%s>",
+					utils.ERs, rdr.sourceDir))
+			return
+		case <-tm.C:
+		}
+		processReaderDir(rdr.sourceDir, utils.ParquetSuffix, rdr.processFile)
+		tm.Reset(rdr.Config().RunDelay)
+	}
+}
+
+func (rdr *ParquetFileER) Serve() (err error) {
+	switch rdr.Config().RunDelay {
+	case time.Duration(0): // 0 disables the automatic read, maybe done per API
+		return
+	case time.Duration(-1):
+		go func() {
+			time.Sleep(rdr.Config().StartDelay)
+
+			// Ensure that files already existing in the source path are processed
+			// before the reader starts listening for filesystem change events.
+			processReaderDir(rdr.sourceDir, utils.ParquetSuffix, rdr.processFile)
+
+			if err := utils.WatchDir(rdr.sourceDir, rdr.processFile,
+				utils.ERs, rdr.rdrExit); err != nil {
+				rdr.rdrError <- err
+			}
+		}()
+	default:
+		go rdr.serveDefault()
+	}
+	return
+}
+
+// processFile is called for each file in a directory and dispatches erEvents from it
+func (rdr *ParquetFileER) processFile(fName string) (err error) {
+	if !strings.HasSuffix(fName, utils.ParquetSuffix) {
+		return // files still being written by the exporter have a temporary suffix
+	}
+	if cap(rdr.conReqs) != 0 { // 0 goes for no limit
+		rdr.conReqs <- struct{}{} // Queue here for maxOpenFiles
+		defer func() { <-rdr.conReqs }()
+	}
+	absPath := path.Join(rdr.sourceDir, fName)
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> parsing <%s>", utils.ERs, absPath))
+	var file *os.File
+	if file, err = os.Open(absPath); err != nil {
+		return
+	}
+	defer file.Close()
+	var info os.FileInfo
+	if info, err = file.Stat(); err != nil {
+		return
+	}
+	var pFile *parquet.File
+	if pFile, err = parquet.OpenFile(file, info.Size()); err != nil {
+		return
+	}
+	columns := pFile.Schema().Columns()
+	colPaths := make([][]string, len(columns)) // flat columns named after the exported paths become nested again
+	colTypes := make([]*format.LogicalType, len(columns))
+	for i, col := range columns {
+		colPaths[i] = strings.Split(strings.Join(col, utils.NestingSep), utils.NestingSep)
+		if leaf, has := pFile.Schema().Lookup(col...); has {
+			colTypes[i] = leaf.Node.Type().LogicalType()
+		}
+	}
+	pReader := parquet.NewReader(pFile)
+	defer pReader.Close()
+
+	rowNr := 0
+	evsPosted := 0
+	timeStart := time.Now()
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.MetaFileName: utils.NewLeafNode(fName), utils.MetaReaderID: utils.NewLeafNode(rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx].ID)}}
+	rows := make([]parquet.Row, 64)
+	for {
+		n, errRead := pReader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			rowNr++
+			record := make(utils.MapStorage)
+			for _, val := range row {
+				if val.IsNull() {
+					continue
+				}
+				idx := val.Column()
+				if err = record.Set(colPaths[idx], parquetValueAsIface(val, colTypes[idx])); err != nil {
+					return
+				}
+			}
+			reqVars.Map[utils.MetaFileLineNumber] = utils.NewLeafNode(rowNr)
+			agReq := agents.NewAgentRequest(
+				record, reqVars,
+				nil, nil, nil, rdr.Config().Tenant,
+				rdr.cgrCfg.GeneralCfg().DefaultTenant,
+				utils.FirstNonEmpty(rdr.Config().Timezone,
+					rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+				rdr.fltrS, nil) // create an AgentRequest
+			if pass, err := rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+				agReq); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reading file: <%s> row <%d>, ignoring due to filter error: <%s>",
+						utils.ERs, absPath, rowNr, err.Error()))
+				return err
+			} else if !pass {
+				continue
+			}
+			if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> reading file: <%s> row <%d>, ignoring due to error: <%s>",
+						utils.ERs, absPath, rowNr, err.Error()))
+				return
+			}
+			cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+			rdrEv := rdr.rdrEvents
+			if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+				rdrEv = rdr.partialEvents
+			}
+			rdrEv <- &erEvent{
+				cgrEvent: cgrEv,
+				rdrCfg:   rdr.Config(),
+			}
+			evsPosted++
+		}
+		if errRead == io.EOF {
+			break
+		}
+		if errRead != nil {
+			return errRead
+		}
+	}
+	if rdr.Config().ProcessedPath != "" {
+		// Finished with file, move it to processed folder
+		outPath := path.Join(rdr.Config().ProcessedPath, fName)
+		if err = os.Rename(absPath, outPath); err != nil {
+			return
+		}
+	}
+
+	utils.Logger.Info(
+		fmt.Sprintf("%s finished processing file <%s>. Total records processed: %d, events posted: %d, run duration: %s",
+			utils.ERs, absPath, rowNr, evsPosted, time.Since(timeStart)))
+	return
+}
+
+// parquetValueAsIface converts the parquet value based on the logical type of its column:
+// timestamps become time.Time and decimals their string representation
+func parquetValueAsIface(val parquet.Value, lType *format.LogicalType) any {
+	switch {
+	case lType != nil && lType.Timestamp != nil:
+		switch unit := lType.Timestamp.Unit; {
+		case unit.Millis != nil:
+			return time.UnixMilli(val.Int64()).UTC()
+		case unit.Nanos != nil:
+			return time.Unix(0, val.Int64()).UTC()
+		default:
+			return time.UnixMicro(val.Int64()).UTC()
+		}
+	case lType != nil && lType.Decimal != nil:
+		scale := int(lType.Decimal.Scale)
+		switch val.Kind() {
+		case parquet.Int32:
+			return utils.NewDecimal(int64(val.Int32()), scale).String()
+		case parquet.Int64:
+			return utils.NewDecimal(val.Int64(), scale).String()
+		default: // big-endian two's complement unscaled value
+			raw := val.ByteArray()
+			unscaled := new(big.Int).SetBytes(raw)
+			if len(raw) != 0 && raw[0]&0x80 != 0 {
+				unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(raw)*8)))
+			}
+			return new(decimal.Big).SetBigMantScale(unscaled, scale).String()
+		}
+	}
+	switch val.Kind() {
+	case parquet.Boolean:
+		return val.Boolean()
+	case parquet.Int32:
+		return int64(val.Int32())
+	case parquet.Int64:
+		return val.Int64()
+	case parquet.Float:
+		return float64(val.Float())
+	case parquet.Double:
+		return val.Double()
+	default:
+		return string(val.ByteArray())
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/parquet-go/parquet-go"
+)
+
+func writeTestParquetFile(t *testing.T, filePath string) {
+	t.Helper()
+	schema := parquet.NewSchema("cdr", parquet.Group{
+		"Account":    parquet.Optional(parquet.String()),
+		"AnswerTime": parquet.Optional(parquet.Timestamp(parquet.Microsecond)),
+		"Cost":       parquet.Optional(parquet.Decimal(4, 18, parquet.Int64Type)),
+		"Usage":      parquet.Optional(parquet.Int(64)),
+	})
+	f, err := os.Create(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := parquet.NewWriter(f, schema)
+	aTime := time.Date(2025, 3, 4, 10, 11, 12, 0, time.UTC)
+	if _, err := w.WriteRows([]parquet.Row{
+		{
+			parquet.ByteArrayValue([]byte("1001")).Level(0, 1, 0),
+			parquet.Int64Value(aTime.UnixMicro()).Level(0, 1, 1),
+			parquet.Int64Value(12346).Level(0, 1, 2),
+			parquet.Int64Value(int64(90*time.Second)).Level(0, 1, 3),
+		},
+		{
+			parquet.ByteArrayValue([]byte("1002")).Level(0, 1, 0),
+			parquet.Value{}.Level(0, 0, 1),
+			parquet.Int64Value(-5000).Level(0, 1, 2),
+			parquet.Int64Value(int64(time.Second)).Level(0, 1, 3),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParquetFileERProcessFile(t *testing.T) {
+	srcDir, prcDir := t.TempDir(), t.TempDir()
+	writeTestParquetFile(t, path.Join(srcDir, "cdrs.parquet"))
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().Readers[0].ProcessedPath = prcDir
+	cfg.ERsCfg().Readers[0].Fields = []*config.FCTemplate{
+		{Tag: utils.AccountField, Path: "*cgreq.Account", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep)},
+		{Tag: utils.AnswerTime, Path: "*cgreq.AnswerTime", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.AnswerTime", utils.InfieldSep)},
+		{Tag: utils.Cost, Path: "*cgreq.Cost", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Cost", utils.InfieldSep)},
+		{Tag: utils.Usage, Path: "*cgreq.Usage", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Usage", utils.InfieldSep)},
+	}
+	for _, fld := range cfg.ERsCfg().Readers[0].Fields {
+		fld.ComputePath()
+	}
+	idb, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdr := &ParquetFileER{
+		cgrCfg:    cfg,
+		cfgIdx:    0,
+		fltrS:     engine.NewFilterS(cfg, nil, engine.NewDataManager(idb, cfg.CacheCfg(), nil)),
+		sourceDir: srcDir,
+		rdrEvents: make(chan *erEvent, 2),
+		rdrError:  make(chan error, 1),
+		rdrExit:   make(chan struct{}),
+		conReqs:   make(chan struct{}, 1),
+	}
+	// files still being written are ignored
+	if err := rdr.processFile("cdrs.parquet" + utils.TmpSuffix); err != nil {
+		t.Error(err)
+	}
+	if err := rdr.processFile("cdrs.parquet"); err != nil {
+		t.Fatal(err)
+	}
+	expEvs := []map[string]any{
+		{
+			utils.AccountField: "1001",
+			utils.AnswerTime:   "2025-03-04T10:11:12Z",
+			utils.Cost:         "1.2346",
+			utils.Usage:        "90000000000",
+		},
+		{
+			utils.AccountField: "1002",
+			utils.Cost:         "-0.5000",
+			utils.Usage:        "1000000000",
+		},
+	}
+	for _, expEv := range expEvs {
+		select {
+		case ev := <-rdr.rdrEvents:
+			if !reflect.DeepEqual(ev.cgrEvent.Event, expEv) {
+				t.Errorf("expected %s, received %s", utils.ToJSON(expEv), utils.ToJSON(ev.cgrEvent.Event))
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the event")
+		}
+	}
+	if _, err := os.Stat(path.Join(prcDir, "cdrs.parquet")); err != nil {
+		t.Errorf("expected the file to be moved to the processed path: %v", err)
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// httpRdrs routes the requests received on the paths of the *http readers. The
+// handlers can not be removed from the server so each path is registered once
+// and the requests are passed to the reader serving it at that moment
+var httpRdrs = &httpRouter{
+	rdrs:       make(map[string]*HTTPER),
+	registered: make(map[utils.Server]utils.StringSet),
+}
+
+type httpRouter struct {
+	sync.RWMutex
+	rdrs       map[string]*HTTPER               // map[path]*HTTPER
+	registered map[utils.Server]utils.StringSet // paths registered on each server
+}
+
+// add makes the reader serve its path, registering it on the server if needed
+func (rtr *httpRouter) add(srv utils.Server, rdr *HTTPER) error {
+	path := rdr.Config().SourcePath
+	rtr.Lock()
+	defer rtr.Unlock()
+	if crntRdr, has := rtr.rdrs[path]; has && !crntRdr.stopped() {
+		return fmt.Errorf("path <%s> already served by reader <%s>", path, crntRdr.Config().ID)
+	}
+	rtr.rdrs[path] = rdr
+	if _, has := rtr.registered[srv]; !has {
+		rtr.registered[srv] = make(utils.StringSet)
+	}
+	if !rtr.registered[srv].Has(path) {
+		srv.RegisterHttpFunc("POST "+path, rtr.handler(path))
+		rtr.registered[srv].Add(path)
+	}
+	return nil
+}
+
+// remove stops routing the requests to the reader, unless it was already replaced
+func (rtr *httpRouter) remove(rdr *HTTPER) {
+	path := rdr.Config().SourcePath
+	rtr.Lock()
+	if rtr.rdrs[path] == rdr {
+		delete(rtr.rdrs, path)
+	}
+	rtr.Unlock()
+}
+
+func (rtr *httpRouter) handler(path string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rtr.RLock()
+		rdr, has := rtr.rdrs[path]
+		rtr.RUnlock()
+		if !has {
+			http.NotFound(w, r)
+			return
+		}
+		rdr.ServeHTTP(w, r)
+	}
+}
+
+// NewHTTPER return a new HTTP event reader
+func NewHTTPER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (EventReader, error) {
+	rdr := &HTTPER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrExit:       rdrExit,
+		rdrErr:        rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+	}
+	rdr.setOpts(rdr.Config().Opts)
+	return rdr, nil
+}
+
+// HTTPER implements EventReader interface for records posted over HTTP
+type HTTPER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+	server utils.Server // where the path is registered, set by the ERService
+
+	username    string
+	password    string
+	hmacSecret  []byte
+	hmacHeader  string
+	maxBodySize int64
+
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrExit       chan struct{}
+	rdrErr        chan error
+	cap           chan struct{}
+}
+
+// HTTPRecordReply is the status of one of the records received, the replies
+// are returned in the order of the records within the request
+type HTTPRecordReply struct {
+	Status string // OK once passed for processing, FILTERED if not passing the reader filters, otherwise ERROR
+	Error  string `json:",omitempty"`
+}
+
+// httpRecord is one record decoded out of the request body
+type httpRecord struct {
+	dP       utils.DataProvider
+	rawEvent map[string]any
+}
+
+// Config returns the curent configuration
+func (rdr *HTTPER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start accepting requests on the path until the rdrExit channel is closed
+func (rdr *HTTPER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the reader
+		return
+	}
+	if rdr.server == nil {
+		return errors.New("no HTTP server available")
+	}
+	if err = httpRdrs.add(rdr.server, rdr); err != nil {
+		return
+	}
+	go func() {
+		<-rdr.rdrExit
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> stop accepting requests on path <%s>",
+				utils.ERs, rdr.Config().SourcePath))
+		httpRdrs.remove(rdr)
+	}()
+	return
+}
+
+// stopped returns true once the rdrExit channel is closed
+func (rdr *HTTPER) stopped() bool {
+	select {
+	case <-rdr.rdrExit:
+		return true
+	default:
+		return false
+	}
+}
+
+// ServeHTTP decodes the records out of the request body and replies with the status of each of them
+func (rdr *HTTPER) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if rdr.Config().ConcurrentReqs != -1 {
+		rdr.cap <- struct{}{}
+		defer func() { <-rdr.cap }()
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, rdr.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = rdr.authorize(r, body); err != nil {
+		if rdr.username != utils.EmptyString {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+utils.CGRateS+`"`)
+		}
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get(utils.ContentType))
+	var records []*httpRecord
+	switch {
+	case mediaType == utils.JsonBody || strings.HasSuffix(mediaType, "+json"):
+		records, err = decodeHTTPJSON(body)
+	case mediaType == "text/csv" || mediaType == "application/csv":
+		records, err = rdr.decodeHTTPCSV(body)
+	case mediaType == "application/xml" || mediaType == "text/xml" ||
+		strings.HasSuffix(mediaType, "+xml"):
+		records, err = rdr.decodeHTTPXML(body)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type: <%s>", mediaType),
+			http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rply := make([]*HTTPRecordReply, len(records))
+	for i, rec := range records {
+		rply[i] = &HTTPRecordReply{Status: utils.OK}
+		var pass bool
+		if pass, err = rdr.processRecord(rec); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> reader <%s> record <%d> ignored due to error: <%s>",
+					utils.ERs, rdr.Config().ID, i, err.Error()))
+			rply[i].Status = utils.ErrorCaps
+			rply[i].Error = err.Error()
+		} else if !pass {
+			rply[i].Status = utils.FilteredCaps
+		}
+	}
+	w.Header().Set(utils.ContentType, utils.JsonBody)
+	json.NewEncoder(w).Encode(rply)
+}
+
+// authorize checks the basic auth credentials and the body signature, when configured
+func (rdr *HTTPER) authorize(r *http.Request, body []byte) error {
+	if rdr.username != utils.EmptyString {
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(rdr.username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(rdr.password)) != 1 {
+			return errors.New("invalid credentials")
+		}
+	}
+	if len(rdr.hmacSecret) != 0 {
+		sign, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(rdr.hmacHeader), "sha256="))
+		if err != nil || len(sign) == 0 {
+			return errors.New("missing or malformed signature")
+		}
+		mac := hmac.New(sha256.New, rdr.hmacSecret)
+		mac.Write(body)
+		if !hmac.Equal(sign, mac.Sum(nil)) {
+			return errors.New("invalid signature")
+		}
+	}
+	return nil
+}
+
+// decodeHTTPJSON accepts one object or an array of objects
+func decodeHTTPJSON(body []byte) (records []*httpRecord, err error) {
+	var evs []map[string]any
+	if body = bytes.TrimSpace(body); len(body) != 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &evs)
+	} else {
+		var ev map[string]any
+		err = json.Unmarshal(body, &ev)
+		evs = []map[string]any{ev}
+	}
+	if err != nil {
+		return
+	}
+	records = make([]*httpRecord, len(evs))
+	for i, ev := range evs {
+		records[i] = &httpRecord{dP: utils.MapStorage(ev), rawEvent: ev}
+	}
+	return
+}
+
+// decodeHTTPCSV returns a record for each line, using the same options as the *file_csv reader
+func (rdr *HTTPER) decodeHTTPCSV(body []byte) (records []*httpRecord, err error) {
+	csvOpts := rdr.Config().Opts.CSV
+	csvReader := csv.NewReader(bytes.NewReader(body))
+	if csvOpts.RowLength != nil {
+		csvReader.FieldsPerRecord = *csvOpts.RowLength
+	}
+	csvReader.Comment = utils.CommentChar
+	csvReader.Comma = utils.CSVSep
+	if csvOpts.FieldSeparator != nil {
+		csvReader.Comma = rune((*csvOpts.FieldSeparator)[0])
+	}
+	if csvOpts.LazyQuotes != nil {
+		csvReader.LazyQuotes = *csvOpts.LazyQuotes
+	}
+	var hdrDefChar string
+	if csvOpts.HeaderDefineChar != nil {
+		hdrDefChar = *csvOpts.HeaderDefineChar
+	}
+	var lines [][]string
+	if lines, err = csvReader.ReadAll(); err != nil {
+		return
+	}
+	var hdr []string
+	var indxAls map[string]int
+	if len(lines) != 0 && len(lines[0]) != 0 &&
+		hdrDefChar != utils.EmptyString &&
+		strings.HasPrefix(lines[0][0], hdrDefChar) {
+		hdr = lines[0]
+		hdr[0] = strings.TrimPrefix(hdr[0], hdrDefChar)
+		indxAls = make(map[string]int)
+		for i, fld := range hdr {
+			indxAls[fld] = i
+		}
+		lines = lines[1:]
+	}
+	records = make([]*httpRecord, len(lines))
+	for i, line := range lines {
+		rawEvent := make(map[string]any, len(line))
+		for j, val := range line {
+			fld := strconv.Itoa(j)
+			if j < len(hdr) {
+				fld = hdr[j]
+			}
+			rawEvent[fld] = val
+		}
+		records[i] = &httpRecord{dP: config.NewSliceDP(line, indxAls), rawEvent: rawEvent}
+	}
+	return
+}
+
+// decodeHTTPXML returns a record for each element found at the xmlRootPath
+func (rdr *HTTPER) decodeHTTPXML(body []byte) (records []*httpRecord, err error) {
+	var doc *xmlquery.Node
+	if doc, err = xmlquery.Parse(bytes.NewReader(body)); err != nil {
+		return
+	}
+	var xmlRootPath utils.HierarchyPath
+	if rdr.Config().Opts.XMLRootPath != nil {
+		xmlRootPath = utils.ParseHierarchyPath(*rdr.Config().Opts.XMLRootPath, utils.EmptyString)
+	}
+	var xmlElmts []*xmlquery.Node
+	if xmlElmts, err = xmlquery.QueryAll(doc, xmlRootPath.AsString("/", true)); err != nil {
+		return
+	}
+	records = make([]*httpRecord, len(xmlElmts))
+	for i, xmlElmt := range xmlElmts {
+		records[i] = &httpRecord{dP: config.NewXMLProvider(xmlElmt, xmlRootPath)}
+	}
+	return
+}
+
+// processRecord applies the reader filters and templates, dispatching the resulting event
+func (rdr *HTTPER) processRecord(rec *httpRecord) (pass bool, err error) {
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.MetaReaderID: utils.NewLeafNode(rdr.Config().ID)}}
+	agReq := agents.NewAgentRequest(
+		rec.dP, reqVars,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil) // create an AgentRequest
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	rdrEv := rdr.rdrEvents
+	if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+		rdrEv = rdr.partialEvents
+	}
+	var rawEvent map[string]any
+	if len(rdr.Config().EEsSuccessIDs) != 0 ||
+		len(rdr.Config().EEsFailedIDs) != 0 { // forward the original record once processed
+		rawEvent = rec.rawEvent
+	}
+	rdrEv <- &erEvent{
+		cgrEvent: cgrEv,
+		rawEvent: rawEvent,
+		rdrCfg:   rdr.Config(),
+	}
+	return
+}
+
+func (rdr *HTTPER) setOpts(opts *config.EventReaderOpts) {
+	rdr.hmacHeader = utils.HTTPDefaultHMACHeader
+	rdr.maxBodySize = utils.HTTPDefaultMaxBodySize
+	if httpOpts := opts.HTTP; httpOpts != nil {
+		if httpOpts.Username != nil {
+			rdr.username = *httpOpts.Username
+		}
+		if httpOpts.Password != nil {
+			rdr.password = *httpOpts.Password
+		}
+		if httpOpts.HMACSecret != nil {
+			rdr.hmacSecret = []byte(*httpOpts.HMACSecret)
+		}
+		if httpOpts.HMACHeader != nil {
+			rdr.hmacHeader = *httpOpts.HMACHeader
+		}
+		if httpOpts.MaxBodySize != nil {
+			rdr.maxBodySize = *httpOpts.MaxBodySize
+		}
+	}
+}
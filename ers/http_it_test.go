@@ -0,0 +1,126 @@
+//go:build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+var httpRdrCfg string = `{
+"data_db": {
+	"db_type": "*internal"
+},
+"stor_db": {
+	"db_type": "*internal"
+},
+"ees": {
+	"enabled": true,
+	"exporters": [
+		{
+			"id": "http_processed",
+			"type": "*virt",
+			"fields": [
+				{"tag": "Key", "type": "*variable", "value": "~*req.Key", "path": "*uch.Key"}
+			]
+		}
+	]
+},
+"ers": {
+	"enabled": true,
+	"sessions_conns":[],
+	"ees_conns": ["*internal"],
+	"readers": [
+		{
+			"id": "http_reader",
+			"type": "*http",
+			"run_delay": "-1",
+			"source_path": "/ers/partner1",
+			"flags": ["*dryrun", "*export"],
+			"opts": {
+				"httpUsername": "partner1",
+				"httpPassword": "pass"
+			},
+			"fields":[
+				{"tag": "Key", "type": "*variable", "value": "~*req.Key", "path": "*cgreq.Key", "mandatory": true}
+			]
+		}
+	]
+}
+}`
+
+func TestHTTPReader(t *testing.T) {
+	switch *utils.DBType {
+	case utils.MetaInternal:
+	case utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres:
+		t.SkipNow()
+	default:
+		t.Fatal("unsupported dbtype value")
+	}
+	ng := engine.TestEngine{
+		ConfigJSON: httpRdrCfg,
+	}
+	client, cfg := ng.Run(t)
+
+	post := func(contentType, body, pass string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost,
+			"http://"+cfg.ListenCfg().HTTPListen+"/ers/partner1", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(utils.ContentType, contentType)
+		req.SetBasicAuth("partner1", pass)
+		rply, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rply.Body.Close()
+		rplyBody, _ := io.ReadAll(rply.Body)
+		return rply.StatusCode, strings.TrimSpace(string(rplyBody))
+	}
+
+	if code, _ := post(utils.JsonBody, `{"Key":"key1"}`, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("Expected %d, received %d", http.StatusUnauthorized, code)
+	}
+	if code, rply := post(utils.JsonBody, `{"Key":"key1"}`, "pass"); code != http.StatusOK ||
+		rply != `[{"Status":"OK"}]` {
+		t.Errorf("Unexpected reply: %d %s", code, rply)
+	}
+	checkNATSExports(t, client, "key1")
+
+	if code, rply := post("text/csv", ":Key\nkey2\n", "pass"); code != http.StatusOK ||
+		rply != `[{"Status":"OK"}]` {
+		t.Errorf("Unexpected reply: %d %s", code, rply)
+	}
+	checkNATSExports(t, client, "key2")
+
+	if code, rply := post(utils.JsonBody, `[{"Account":"1001"},{"Key":"key3"}]`, "pass"); code != http.StatusOK ||
+		rply != `[{"Status":"ERROR","Error":"NOT_FOUND:Key"},{"Status":"OK"}]` {
+		t.Errorf("Unexpected reply: %d %s", code, rply)
+	}
+	checkNATSExports(t, client, "key3")
+}
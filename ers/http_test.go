@@ -0,0 +1,314 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// httpTestServer registers the handlers on a ServeMux
+type httpTestServer struct {
+	utils.Server
+	mux *http.ServeMux
+}
+
+func (srv *httpTestServer) RegisterHttpFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	srv.mux.HandleFunc(pattern, handler)
+}
+
+func newTestHTTPER(t *testing.T, cfg *config.CGRConfig) *HTTPER {
+	t.Helper()
+	data, err := engine.NewInternalDB(nil, nil, true, nil, cfg.DataDbCfg().Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	rdr, err := NewHTTPER(cfg, 0, make(chan *erEvent, 3), make(chan *erEvent, 3),
+		make(chan error, 1), engine.NewFilterS(cfg, nil, dm), make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rdr.(*HTTPER)
+}
+
+func postHTTPER(t *testing.T, rdr *HTTPER, contentType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/ers/cdrs", strings.NewReader(body))
+	req.Header.Set(utils.ContentType, contentType)
+	rec := httptest.NewRecorder()
+	rdr.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHTTPERProcessBatch(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.Filters = []string{"*string:~*req.Type:voice"}
+	rdrCfg.Fields = []*config.FCTemplate{
+		{
+			Tag:       "Account",
+			Type:      utils.MetaVariable,
+			Value:     config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep),
+			Path:      "*cgreq.Account",
+			Mandatory: true,
+		},
+	}
+	rdrCfg.Fields[0].ComputePath()
+	defer func() {
+		rdrCfg.Filters = nil
+		rdrCfg.Fields = nil
+	}()
+	rdr := newTestHTTPER(t, cfg)
+
+	rec := postHTTPER(t, rdr, "application/json; charset=utf-8",
+		`[{"Type":"voice","Account":"1001"},{"Type":"sms","Account":"1002"},{"Type":"voice"}]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected %d, received %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var rply []*HTTPRecordReply
+	if err := json.NewDecoder(rec.Body).Decode(&rply); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*HTTPRecordReply{
+		{Status: utils.OK},
+		{Status: utils.FilteredCaps},
+		{Status: utils.ErrorCaps, Error: "NOT_FOUND:Account"},
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+	select {
+	case ev := <-rdr.rdrEvents:
+		if ev.cgrEvent.Event[utils.AccountField] != "1001" {
+			t.Errorf("Unexpected event: %s", utils.ToJSON(ev.cgrEvent))
+		}
+	default:
+		t.Error("Expected one event to be dispatched")
+	}
+	if len(rdr.rdrEvents) != 0 {
+		t.Errorf("Expected only one event, %d more received", len(rdr.rdrEvents))
+	}
+
+	// a single object is also accepted
+	rec = postHTTPER(t, rdr, utils.JsonBody, `{"Type":"voice","Account":"1003"}`)
+	if exp := `[{"Status":"OK"}]`; strings.TrimSpace(rec.Body.String()) != exp {
+		t.Errorf("Expected %s, received %s", exp, rec.Body.String())
+	}
+	<-rdr.rdrEvents
+
+	if rec = postHTTPER(t, rdr, utils.JsonBody, `[{"Type":`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected %d, received %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec = postHTTPER(t, rdr, "text/plain", `Account=1001`); rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected %d, received %d", http.StatusUnsupportedMediaType, rec.Code)
+	}
+}
+
+func TestHTTPERDecodeCSV(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr := newTestHTTPER(t, cfg)
+	// the header is defined by the csvHeaderDefineChar, ":" by default
+	records, err := rdr.decodeHTTPCSV([]byte(":Account,Usage\n1001,10\n1002,20\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, received %d", len(records))
+	}
+	if exp := map[string]any{"Account": "1002", "Usage": "20"}; !reflect.DeepEqual(exp, records[1].rawEvent) {
+		t.Errorf("Expected %v, received %v", exp, records[1].rawEvent)
+	}
+	if val, err := records[1].dP.FieldAsString([]string{"Usage"}); err != nil || val != "20" {
+		t.Errorf("Expected %q, received %q, %v", "20", val, err)
+	}
+	if val, err := records[0].dP.FieldAsString([]string{"0"}); err != nil || val != "1001" {
+		t.Errorf("Expected %q, received %q, %v", "1001", val, err)
+	}
+
+	// without header the raw event is indexed by position
+	if records, err = rdr.decodeHTTPCSV([]byte("1003,30\n")); err != nil {
+		t.Fatal(err)
+	}
+	if exp := map[string]any{"0": "1003", "1": "30"}; len(records) != 1 ||
+		!reflect.DeepEqual(exp, records[0].rawEvent) {
+		t.Errorf("Expected %v, received %v", exp, records[0].rawEvent)
+	}
+}
+
+func TestHTTPERDecodeXML(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr := newTestHTTPER(t, cfg)
+	rdr.Config().Opts.XMLRootPath = utils.StringPointer("CDRs.CDR")
+	defer func() { rdr.Config().Opts.XMLRootPath = nil }()
+
+	records, err := rdr.decodeHTTPXML([]byte(`<?xml version="1.0"?>
+<CDRs>
+	<CDR><Account>1001</Account></CDR>
+	<CDR><Account>1002</Account></CDR>
+</CDRs>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, received %d", len(records))
+	}
+	if val, err := records[1].dP.FieldAsString([]string{"CDRs", "CDR", "Account"}); err != nil || val != "1002" {
+		t.Errorf("Expected %q, received %q, %v", "1002", val, err)
+	}
+}
+
+func TestHTTPERAuthorize(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr := newTestHTTPER(t, cfg)
+	if rdr.hmacHeader != utils.HTTPDefaultHMACHeader {
+		t.Errorf("Expected %q, received %q", utils.HTTPDefaultHMACHeader, rdr.hmacHeader)
+	}
+	rdr.setOpts(&config.EventReaderOpts{
+		HTTP: &config.HTTPROpts{
+			Username:   utils.StringPointer("partner1"),
+			Password:   utils.StringPointer("pass"),
+			HMACSecret: utils.StringPointer("s3cr3t"),
+			HMACHeader: utils.StringPointer("X-Hub-Signature-256"),
+		},
+	})
+	body := `{"Account":"1001"}`
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write([]byte(body))
+	sign := hex.EncodeToString(mac.Sum(nil))
+
+	newReq := func(user, pass, sign string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/ers/cdrs", strings.NewReader(body))
+		req.SetBasicAuth(user, pass)
+		if sign != utils.EmptyString {
+			req.Header.Set("X-Hub-Signature-256", sign)
+		}
+		return req
+	}
+	if err := rdr.authorize(newReq("partner1", "pass", sign), []byte(body)); err != nil {
+		t.Error(err)
+	}
+	if err := rdr.authorize(newReq("partner1", "pass", "sha256="+sign), []byte(body)); err != nil {
+		t.Error(err)
+	}
+	if err := rdr.authorize(newReq("partner1", "wrong", sign), []byte(body)); err == nil ||
+		err.Error() != "invalid credentials" {
+		t.Errorf("Expected invalid credentials, received %v", err)
+	}
+	if err := rdr.authorize(newReq("partner1", "pass", ""), []byte(body)); err == nil ||
+		err.Error() != "missing or malformed signature" {
+		t.Errorf("Expected missing signature, received %v", err)
+	}
+	if err := rdr.authorize(newReq("partner1", "pass", sign), []byte(`{"Account":"1002"}`)); err == nil ||
+		err.Error() != "invalid signature" {
+		t.Errorf("Expected invalid signature, received %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	rdr.ServeHTTP(rec, newReq("partner1", "wrong", sign))
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == utils.EmptyString {
+		t.Errorf("Expected basic auth challenge, received %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestHTTPERMaxBodySize(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr := newTestHTTPER(t, cfg)
+	if rdr.maxBodySize != utils.HTTPDefaultMaxBodySize {
+		t.Errorf("Expected %d, received %d", utils.HTTPDefaultMaxBodySize, rdr.maxBodySize)
+	}
+	rdr.setOpts(&config.EventReaderOpts{
+		HTTP: &config.HTTPROpts{
+			Username:    utils.StringPointer("partner1"),
+			MaxBodySize: utils.Int64Pointer(16),
+		},
+	})
+	// the body is rejected before checking the credentials
+	if rec := postHTTPER(t, rdr, utils.JsonBody, `{"Account":"1001","Destination":"1002"}`); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected %d, received %d", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+	if rec := postHTTPER(t, rdr, utils.JsonBody, `{}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected %d, received %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestHTTPERServe(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdrCfg := cfg.ERsCfg().Readers[0]
+	rdrCfg.SourcePath = "/ers/test_serve"
+	rdrCfg.RunDelay = -1
+	defer func() {
+		rdrCfg.SourcePath = "/var/spool/cgrates/ers/in"
+		rdrCfg.RunDelay = 0
+	}()
+	srv := &httpTestServer{mux: http.NewServeMux()}
+
+	rdr := newTestHTTPER(t, cfg)
+	if err := rdr.Serve(); err == nil || err.Error() != "no HTTP server available" {
+		t.Errorf("Expected missing server error, received %v", err)
+	}
+	rdr.server = srv
+	if err := rdr.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	post := func() int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/ers/test_serve", strings.NewReader(`[]`))
+		req.Header.Set(utils.ContentType, utils.JsonBody)
+		srv.mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := post(); code != http.StatusOK {
+		t.Errorf("Expected %d, received %d", http.StatusOK, code)
+	}
+
+	// a second reader can not serve the same path while the first one is running
+	rdr2 := newTestHTTPER(t, cfg)
+	rdr2.server = srv
+	if err := rdr2.Serve(); err == nil ||
+		err.Error() != "path </ers/test_serve> already served by reader <*default>" {
+		t.Errorf("Expected path in use error, received %v", err)
+	}
+
+	// the replacing reader, as on reload, reuses the path already registered on the server
+	close(rdr.rdrExit)
+	if err := rdr2.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	httpRdrs.remove(rdr) // the stopped reader does not remove its replacement
+	if code := post(); code != http.StatusOK {
+		t.Errorf("Expected %d, received %d", http.StatusOK, code)
+	}
+	close(rdr2.rdrExit)
+	httpRdrs.remove(rdr2)
+	if code := post(); code != http.StatusNotFound {
+		t.Errorf("Expected %d, received %d", http.StatusNotFound, code)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// NewMQTTER return a new MQTT event reader
+func NewMQTTER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (EventReader, error) {
+	rdr := &MQTTER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrExit:       rdrExit,
+		rdrErr:        rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+	}
+	if err := rdr.setOpts(rdr.Config().Opts); err != nil {
+		return nil, err
+	}
+	return rdr, nil
+}
+
+// MQTTER implements EventReader interface for MQTT messages
+type MQTTER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	topic        string // topic filter, can contain + and # wildcards
+	qos          byte
+	clientID     string
+	cleanSession bool
+	username     string
+	password     string
+	tlsCfg       *tls.Config // nil for plain connections
+
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrExit       chan struct{}
+	rdrErr        chan error
+	cap           chan struct{}
+}
+
+// Config returns the curent configuration
+func (rdr *MQTTER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will connect to the broker and subscribe to the topic until the rdrExit channel is closed
+func (rdr *MQTTER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	clntOpts := mqtt.NewClientOptions().
+		AddBroker(rdr.Config().SourcePath).
+		SetClientID(rdr.clientID).
+		SetCleanSession(rdr.cleanSession).
+		SetUsername(rdr.username).
+		SetPassword(rdr.password).
+		SetConnectTimeout(rdr.cgrCfg.GeneralCfg().ConnectTimeout).
+		SetOrderMatters(false). // each message is handled on its own goroutine
+		SetAutoReconnect(true).
+		SetDefaultPublishHandler(rdr.handleMessage). // messages queued by a persistent session arrive before subscribing
+		SetOnConnectHandler(rdr.subscribe)           // (re)subscribe on every connect since clean sessions lose it
+	if rdr.Config().MaxReconnectInterval > 0 {
+		clntOpts.SetMaxReconnectInterval(rdr.Config().MaxReconnectInterval)
+	}
+	if rdr.tlsCfg != nil {
+		clntOpts.SetTLSConfig(rdr.tlsCfg)
+	}
+	clnt := mqtt.NewClient(clntOpts)
+	if tkn := clnt.Connect(); tkn.Wait() && tkn.Error() != nil {
+		return tkn.Error()
+	}
+	go func() {
+		<-rdr.rdrExit
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> stop monitoring mqtt path <%s>",
+				utils.ERs, rdr.Config().SourcePath))
+		clnt.Disconnect(250)
+	}()
+	return
+}
+
+// subscribe is called every time the client connects to the broker
+func (rdr *MQTTER) subscribe(clnt mqtt.Client) {
+	if rdr.Config().StartDelay > 0 {
+		select {
+		case <-time.After(rdr.Config().StartDelay):
+		case <-rdr.rdrExit:
+			return
+		}
+	}
+	if tkn := clnt.Subscribe(rdr.topic, rdr.qos, rdr.handleMessage); tkn.Wait() && tkn.Error() != nil {
+		rdr.rdrErr <- tkn.Error()
+	}
+}
+
+// handleMessage processes one message, the broker receives the acknowledgement
+// only after the event was passed to ERs
+func (rdr *MQTTER) handleMessage(_ mqtt.Client, msg mqtt.Message) {
+	if rdr.Config().ConcurrentReqs != -1 {
+		rdr.cap <- struct{}{}
+		defer func() { <-rdr.cap }()
+	}
+	if err := rdr.processMessage(msg.Payload()); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> processing message from topic %s error: %s",
+				utils.ERs, msg.Topic(), err.Error()))
+	}
+}
+
+func (rdr *MQTTER) processMessage(msg []byte) (err error) {
+	var decodedMessage map[string]any
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return
+	}
+
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.MetaReaderID: utils.NewLeafNode(rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx].ID)}}
+
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), reqVars,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	rdrEv := rdr.rdrEvents
+	if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+		rdrEv = rdr.partialEvents
+	}
+	var rawEvent map[string]any
+	if len(rdr.Config().EEsSuccessIDs) != 0 ||
+		len(rdr.Config().EEsFailedIDs) != 0 { // forward the original message once processed
+		rawEvent = decodedMessage
+	}
+	rdrEv <- &erEvent{
+		cgrEvent: cgrEv,
+		rawEvent: rawEvent,
+		rdrCfg:   rdr.Config(),
+	}
+	return
+}
+
+func (rdr *MQTTER) setOpts(opts *config.EventReaderOpts) (err error) {
+	rdr.topic = utils.MQTTDefaultTopic
+	rdr.qos = utils.MQTTDefaultQoS
+	rdr.clientID = utils.CGRateSLwr + utils.Underline +
+		rdr.cgrCfg.GeneralCfg().NodeID + utils.Underline + rdr.Config().ID
+	rdr.cleanSession = true
+	mqttOpts := opts.MQTT
+	if mqttOpts == nil {
+		return
+	}
+	if mqttOpts.Topic != nil {
+		rdr.topic = *mqttOpts.Topic
+	}
+	if mqttOpts.QoS != nil {
+		if *mqttOpts.QoS < 0 || *mqttOpts.QoS > 2 {
+			return fmt.Errorf("invalid %s: %d", utils.MQTTQoS, *mqttOpts.QoS)
+		}
+		rdr.qos = byte(*mqttOpts.QoS)
+	}
+	if mqttOpts.ClientID != nil {
+		rdr.clientID = *mqttOpts.ClientID
+	}
+	if mqttOpts.CleanSession != nil {
+		rdr.cleanSession = *mqttOpts.CleanSession
+	}
+	if mqttOpts.Username != nil {
+		rdr.username = *mqttOpts.Username
+	}
+	if mqttOpts.Password != nil {
+		rdr.password = *mqttOpts.Password
+	}
+	if mqttOpts.TLS != nil && *mqttOpts.TLS {
+		rdr.tlsCfg, err = mqttTLSConfig(mqttOpts.CAPath, mqttOpts.ClientCertificate,
+			mqttOpts.ClientKey, mqttOpts.SkipTLSVerify)
+	}
+	return
+}
+
+// mqttTLSConfig builds the TLS configuration used towards the broker
+func mqttTLSConfig(caPath, certPath, keyPath *string, skipVerify *bool) (tlsCfg *tls.Config, err error) {
+	tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	if skipVerify != nil {
+		tlsCfg.InsecureSkipVerify = *skipVerify
+	}
+	if caPath != nil && *caPath != utils.EmptyString {
+		var rootCAs *x509.CertPool
+		if rootCAs, err = x509.SystemCertPool(); err != nil {
+			return
+		}
+		var ca []byte
+		if ca, err = os.ReadFile(*caPath); err != nil {
+			return
+		}
+		if !rootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse root certificate from %q", *caPath)
+		}
+		tlsCfg.RootCAs = rootCAs
+	}
+	switch {
+	case certPath != nil && keyPath != nil:
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(*certPath, *keyPath); err != nil {
+			return
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	case certPath != nil:
+		return nil, fmt.Errorf("has certificate but no key")
+	case keyPath != nil:
+		return nil, fmt.Errorf("has key but no certificate")
+	}
+	return
+}
@@ -0,0 +1,130 @@
+//go:build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+var mqttCfg string = `{
+"data_db": {
+	"db_type": "*internal"
+},
+"stor_db": {
+	"db_type": "*internal"
+},
+"ees": {
+	"enabled": true,
+	"exporters": [
+		{
+			"id": "mqtt_processed",
+			"type": "*virt",
+			"fields": [
+				{"tag": "Key", "type": "*variable", "value": "~*req.Key", "path": "*uch.Key"}
+			]
+		}
+	]
+},
+"ers": {
+	"enabled": true,
+	"sessions_conns":[],
+	"ees_conns": ["*internal"],
+	"readers": [
+		{
+			"id": "mqtt_reader",
+			"type": "*mqtt_json_map",
+			"run_delay": "-1",
+			"source_path": "tcp://127.0.0.1:1883",
+			"flags": ["*dryrun", "*export"],
+			"opts": {
+				"mqttTopic": "meters/+/usage",
+				"mqttQoS": %d,
+				"mqttCleanSession": %t
+			},
+			"fields":[
+				{"tag": "Key", "type": "*variable", "value": "~*req.Key", "path": "*cgreq.Key"},
+				{"tag": "readerId", "type": "*variable", "value": "~*vars.*readerID", "path": "*cgreq.ReaderID"},
+			]
+		}
+	]
+}
+}`
+
+func TestMQTTReader(t *testing.T) {
+	switch *utils.DBType {
+	case utils.MetaInternal:
+	case utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres:
+		t.SkipNow()
+	default:
+		t.Fatal("unsupported dbtype value")
+	}
+
+	testcases := []struct {
+		name         string
+		qos          int
+		cleanSession bool
+	}{
+		{name: "QoS0", qos: 0, cleanSession: true},
+		{name: "QoS1", qos: 1, cleanSession: true},
+		{name: "QoS2PersistentSession", qos: 2, cleanSession: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := exec.Command("mosquitto", "-p", "1883")
+			if err := cmd.Start(); err != nil {
+				t.Fatal(err) // most probably not installed
+			}
+			t.Cleanup(func() { cmd.Process.Kill() })
+			time.Sleep(100 * time.Millisecond) // give the broker time to listen
+
+			ng := engine.TestEngine{
+				ConfigJSON: fmt.Sprintf(mqttCfg, tc.qos, tc.cleanSession),
+			}
+			client, _ := ng.Run(t)
+
+			pub := mqtt.NewClient(mqtt.NewClientOptions().
+				AddBroker("tcp://127.0.0.1:1883").
+				SetClientID("cgrates_test_publisher"))
+			if tkn := pub.Connect(); tkn.Wait() && tkn.Error() != nil {
+				t.Fatal(tkn.Error())
+			}
+			t.Cleanup(func() { pub.Disconnect(250) })
+			time.Sleep(50 * time.Millisecond) // wait for the reader to subscribe
+
+			for i := range 3 {
+				key := fmt.Sprintf("key%d", i+1)
+				topic := fmt.Sprintf("meters/device%d/usage", i+1) // matched by the + wildcard
+				if tkn := pub.Publish(topic, byte(tc.qos), false,
+					fmt.Sprintf(`{"Key": "%s"}`, key)); tkn.Wait() && tkn.Error() != nil {
+					t.Error(tkn.Error())
+				}
+				checkNATSExports(t, client, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestMQTTERsetOpts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().NodeID = "node1"
+	rdr := &MQTTER{cgrCfg: cfg}
+	if err := rdr.setOpts(&config.EventReaderOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if rdr.topic != utils.MQTTDefaultTopic {
+		t.Errorf("Expected %q, received %q", utils.MQTTDefaultTopic, rdr.topic)
+	}
+	if rdr.qos != utils.MQTTDefaultQoS {
+		t.Errorf("Expected %d, received %d", utils.MQTTDefaultQoS, rdr.qos)
+	}
+	if exp := "cgrates_node1_*default"; rdr.clientID != exp {
+		t.Errorf("Expected %q, received %q", exp, rdr.clientID)
+	}
+	if !rdr.cleanSession || rdr.tlsCfg != nil {
+		t.Errorf("Expected clean session without TLS, received %+v", rdr)
+	}
+
+	rdr = &MQTTER{cgrCfg: cfg}
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		MQTT: &config.MQTTROpts{
+			Topic:         utils.StringPointer("meters/#"),
+			QoS:           utils.IntPointer(2),
+			ClientID:      utils.StringPointer("meters_reader"),
+			CleanSession:  utils.BoolPointer(false),
+			Username:      utils.StringPointer("user"),
+			Password:      utils.StringPointer("pass"),
+			TLS:           utils.BoolPointer(true),
+			SkipTLSVerify: utils.BoolPointer(true),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exp := &MQTTER{
+		cgrCfg:       cfg,
+		topic:        "meters/#",
+		qos:          2,
+		clientID:     "meters_reader",
+		cleanSession: false,
+		username:     "user",
+		password:     "pass",
+		tlsCfg:       rdr.tlsCfg,
+	}
+	if !reflect.DeepEqual(exp, rdr) {
+		t.Errorf("Expected %+v, received %+v", exp, rdr)
+	}
+	if rdr.tlsCfg == nil || !rdr.tlsCfg.InsecureSkipVerify {
+		t.Errorf("Expected TLS config skipping verification, received %+v", rdr.tlsCfg)
+	}
+
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		MQTT: &config.MQTTROpts{QoS: utils.IntPointer(3)},
+	}); err == nil || err.Error() != "invalid mqttQoS: 3" {
+		t.Errorf("Expected error for invalid QoS, received %v", err)
+	}
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		MQTT: &config.MQTTROpts{
+			TLS:               utils.BoolPointer(true),
+			ClientCertificate: utils.StringPointer("/tmp/cert.pem"),
+		},
+	}); err == nil || err.Error() != "has certificate but no key" {
+		t.Errorf("Expected error for missing key, received %v", err)
+	}
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		MQTT: &config.MQTTROpts{
+			TLS:    utils.BoolPointer(true),
+			CAPath: utils.StringPointer("/tmp/nonexistent_ca.pem"),
+		},
+	}); err == nil {
+		t.Error("Expected error for missing CA file")
+	}
+}
+
+func TestMQTTERServeDisabled(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr, err := NewMQTTER(cfg, 0, make(chan *erEvent, 1), make(chan *erEvent, 1),
+		make(chan error, 1), new(engine.FilterS), make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RunDelay 0 disables the reader, no connection is attempted
+	if err := rdr.Serve(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMQTTERProcessMessage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr := &MQTTER{
+		cgrCfg:    cfg,
+		cfgIdx:    0,
+		fltrS:     new(engine.FilterS),
+		rdrEvents: make(chan *erEvent, 1),
+	}
+	rdr.Config().Fields = []*config.FCTemplate{
+		{
+			Tag:   "Usage",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Usage", utils.InfieldSep),
+			Path:  "*cgreq.Usage",
+		},
+	}
+	rdr.Config().Fields[0].ComputePath()
+	rdr.Config().EEsFailedIDs = []string{"mqtt_failed"}
+	defer func() { rdr.Config().EEsFailedIDs = nil }()
+
+	expEvent := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]any{
+			utils.Usage: "10",
+		},
+		APIOpts: map[string]any{},
+	}
+	if err := rdr.processMessage([]byte(`{"Device":"meter1","Usage":"10"}`)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case data := <-rdr.rdrEvents:
+		expEvent.ID = data.cgrEvent.ID
+		expEvent.Time = data.cgrEvent.Time
+		if !reflect.DeepEqual(data.cgrEvent, expEvent) {
+			t.Errorf("Expected %v but received %v", utils.ToJSON(expEvent), utils.ToJSON(data.cgrEvent))
+		}
+		expRaw := map[string]any{"Device": "meter1", "Usage": "10"}
+		if !reflect.DeepEqual(data.rawEvent, expRaw) {
+			t.Errorf("Expected raw event %v but received %v", expRaw, data.rawEvent)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Error("Time limit exceeded")
+	}
+
+	if err := rdr.processMessage([]byte(`{"invalid":`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+// redisStreamsBlock is the maximum time XREADGROUP waits for new entries
+// before checking if the reader was stopped
+const redisStreamsBlock = time.Second
+
+// NewRedisStreamsER return a new Redis Streams event reader
+func NewRedisStreamsER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents, partialEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (EventReader, error) {
+	rdr := &RedisStreamsER{
+		cgrCfg:        cfg,
+		cfgIdx:        cfgIdx,
+		fltrS:         fltrS,
+		rdrEvents:     rdrEvents,
+		partialEvents: partialEvents,
+		rdrExit:       rdrExit,
+		rdrErr:        rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+	}
+	if err := rdr.setOpts(rdr.Config().Opts); err != nil {
+		return nil, err
+	}
+	return rdr, nil
+}
+
+// RedisStreamsER implements EventReader interface for Redis Streams entries
+type RedisStreamsER struct {
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	stream     string
+	group      string
+	consumer   string
+	deadLetter string // stream where the entries failing to be processed are moved
+	count      int
+	block      time.Duration
+	client     radix.Client
+
+	rdrEvents     chan *erEvent // channel to dispatch the events created to
+	partialEvents chan *erEvent // channel to dispatch the partial events created to
+	rdrExit       chan struct{}
+	rdrErr        chan error
+	cap           chan struct{}
+}
+
+// Config returns the curent configuration
+func (rdr *RedisStreamsER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will join the consumer group and read the stream until the rdrExit channel is closed
+func (rdr *RedisStreamsER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	if rdr.client, err = engine.NewRedisClient(rdr.Config().SourcePath,
+		rdr.cgrCfg.DataDbCfg()); err != nil {
+		return
+	}
+	if err = rdr.createGroup(); err != nil {
+		rdr.client.Close()
+		return
+	}
+	go func() {
+		<-rdr.rdrExit
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> stop monitoring redis stream <%s>",
+				utils.ERs, rdr.stream))
+		rdr.client.Close()
+	}()
+	go rdr.readLoop()
+	return
+}
+
+// createGroup creates the consumer group together with the stream, the group
+// starts with the entries already in the stream
+func (rdr *RedisStreamsER) createGroup() (err error) {
+	if err = rdr.client.Do(radix.Cmd(nil, "XGROUP", "CREATE",
+		rdr.stream, rdr.group, "0", "MKSTREAM")); err != nil &&
+		strings.HasPrefix(err.Error(), "BUSYGROUP") { // the group already exists
+		err = nil
+	}
+	return
+}
+
+func (rdr *RedisStreamsER) readLoop() {
+	if rdr.Config().StartDelay > 0 {
+		select {
+		case <-time.After(rdr.Config().StartDelay):
+		case <-rdr.rdrExit:
+			return
+		}
+	}
+	blockMs := strconv.FormatInt(max(rdr.block.Milliseconds(), 1), 10)
+	count := strconv.Itoa(rdr.count)
+	lastID := "0" // start with the entries delivered to this consumer but never acknowledged
+	for {
+		select {
+		case <-rdr.rdrExit:
+			return
+		default:
+		}
+		var streams []radix.StreamEntries
+		if err := rdr.client.Do(radix.Cmd(&streams, "XREADGROUP",
+			"GROUP", rdr.group, rdr.consumer, "COUNT", count, "BLOCK", blockMs,
+			"STREAMS", rdr.stream, lastID)); err != nil {
+			select {
+			case <-rdr.rdrExit: // the client was closed when stopping the reader
+			default:
+				rdr.rdrErr <- err
+			}
+			return
+		}
+		var entries []radix.StreamEntry
+		if len(streams) != 0 {
+			entries = streams[0].Entries
+		}
+		if lastID != ">" {
+			if len(entries) == 0 { // no more pending entries, continue with the new ones
+				lastID = ">"
+				continue
+			}
+			lastID = entries[len(entries)-1].ID.String()
+		}
+		for _, entry := range entries {
+			if rdr.Config().ConcurrentReqs != -1 {
+				rdr.cap <- struct{}{} // do not try to read if the limit is reached
+			}
+			go func(entry radix.StreamEntry) {
+				rdr.handleEntry(entry)
+				if rdr.Config().ConcurrentReqs != -1 {
+					<-rdr.cap
+				}
+			}(entry)
+		}
+	}
+}
+
+// handleEntry processes one entry and acknowledges it, the entries failing
+// processing are acknowledged only after they were moved to the dead-letter stream
+func (rdr *RedisStreamsER) handleEntry(entry radix.StreamEntry) {
+	entryID := entry.ID.String()
+	if err := rdr.processMessage(entry.Fields); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> processing entry %s from stream %s error: %s",
+				utils.ERs, entryID, rdr.stream, err.Error()))
+		if err = rdr.client.Do(radix.Cmd(nil, "XADD",
+			deadLetterArgs(rdr.deadLetter, entry, err)...)); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> moving entry %s to stream %s error: %s",
+					utils.ERs, entryID, rdr.deadLetter, err.Error()))
+			return // stays pending and is read again after a restart
+		}
+	}
+	if err := rdr.client.Do(radix.Cmd(nil, "XACK",
+		rdr.stream, rdr.group, entryID)); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> acknowledging entry %s from stream %s error: %s",
+				utils.ERs, entryID, rdr.stream, err.Error()))
+	}
+}
+
+// deadLetterArgs returns the XADD arguments copying the entry to the dead-letter
+// stream together with its original ID and the processing error
+func deadLetterArgs(deadLetter string, entry radix.StreamEntry, processErr error) []string {
+	args := make([]string, 0, 2*len(entry.Fields)+6)
+	args = append(args, deadLetter, "*")
+	for fld, val := range entry.Fields {
+		args = append(args, fld, val)
+	}
+	return append(args,
+		utils.RedisStreamsDeadLetterEntryID, entry.ID.String(),
+		utils.RedisStreamsDeadLetterError, processErr.Error())
+}
+
+func (rdr *RedisStreamsER) processMessage(fields map[string]string) (err error) {
+	msg := make(map[string]any, len(fields))
+	for fld, val := range fields {
+		msg[fld] = val
+	}
+
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.MetaReaderID: utils.NewLeafNode(rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx].ID)}}
+
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(msg), reqVars,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	rdrEv := rdr.rdrEvents
+	if _, isPartial := cgrEv.APIOpts[utils.PartialOpt]; isPartial {
+		rdrEv = rdr.partialEvents
+	}
+	var rawEvent map[string]any
+	if len(rdr.Config().EEsSuccessIDs) != 0 ||
+		len(rdr.Config().EEsFailedIDs) != 0 { // forward the original entry once processed
+		rawEvent = msg
+	}
+	rdrEv <- &erEvent{
+		cgrEvent: cgrEv,
+		rawEvent: rawEvent,
+		rdrCfg:   rdr.Config(),
+	}
+	return
+}
+
+func (rdr *RedisStreamsER) setOpts(opts *config.EventReaderOpts) (err error) {
+	rdr.stream = utils.RedisStreamsDefaultStream
+	rdr.group = utils.RedisStreamsDefaultGroup
+	rdr.consumer = utils.CGRateSLwr + utils.Underline +
+		rdr.cgrCfg.GeneralCfg().NodeID + utils.Underline + rdr.Config().ID
+	rdr.count = utils.RedisStreamsDefaultCount
+	rdr.block = redisStreamsBlock
+	if readTimeout := rdr.cgrCfg.DataDbCfg().Opts.RedisReadTimeout; readTimeout > 0 &&
+		readTimeout <= rdr.block { // the reply must arrive before the connection times out
+		rdr.block = readTimeout / 2
+	}
+	if redisOpts := opts.RedisStreams; redisOpts != nil {
+		if redisOpts.Stream != nil {
+			rdr.stream = *redisOpts.Stream
+		}
+		if redisOpts.Group != nil {
+			rdr.group = *redisOpts.Group
+		}
+		if redisOpts.Consumer != nil {
+			rdr.consumer = *redisOpts.Consumer
+		}
+		if redisOpts.DeadLetter != nil {
+			rdr.deadLetter = *redisOpts.DeadLetter
+		}
+		if redisOpts.Count != nil {
+			if *redisOpts.Count <= 0 {
+				return fmt.Errorf("invalid %s: %d", utils.RedisStreamsCount, *redisOpts.Count)
+			}
+			rdr.count = *redisOpts.Count
+		}
+	}
+	if rdr.deadLetter == utils.EmptyString {
+		rdr.deadLetter = rdr.stream + utils.RedisStreamsDeadLetterSuffix
+	}
+	return
+}
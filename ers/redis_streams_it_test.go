@@ -0,0 +1,147 @@
+//go:build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+var redisStreamsCfg string = `{
+"data_db": {
+	"db_type": "*internal"
+},
+"stor_db": {
+	"db_type": "*internal"
+},
+"ees": {
+	"enabled": true,
+	"exporters": [
+		{
+			"id": "redis_processed",
+			"type": "*virt",
+			"fields": [
+				{"tag": "Key", "type": "*variable", "value": "~*req.Key", "path": "*uch.Key"}
+			]
+		}
+	]
+},
+"ers": {
+	"enabled": true,
+	"sessions_conns":[],
+	"ees_conns": ["*internal"],
+	"readers": [
+		{
+			"id": "redis_reader",
+			"type": "*redis_streams",
+			"run_delay": "-1",
+			"source_path": "127.0.0.1:6379",
+			"flags": ["*dryrun", "*export"],
+			"opts": {
+				"redisStreamsStream": "cgrates_test_cdrs",
+				"redisStreamsGroup": "cgrates_test"
+			},
+			"fields":[
+				{"tag": "Key", "type": "*variable", "value": "~*req.Key", "path": "*cgreq.Key", "mandatory": true}
+			]
+		}
+	]
+}
+}`
+
+func TestRedisStreamsReader(t *testing.T) {
+	switch *utils.DBType {
+	case utils.MetaInternal:
+	case utils.MetaMySQL, utils.MetaMongo, utils.MetaPostgres:
+		t.SkipNow()
+	default:
+		t.Fatal("unsupported dbtype value")
+	}
+
+	rds, err := radix.NewPool(utils.TCP, "127.0.0.1:6379", 1)
+	if err != nil {
+		t.Fatal(err) // most probably redis is not running
+	}
+	defer rds.Close()
+	cleanup := func() {
+		if err := rds.Do(radix.Cmd(nil, "DEL", "cgrates_test_cdrs",
+			"cgrates_test_cdrs"+utils.RedisStreamsDeadLetterSuffix)); err != nil {
+			t.Error(err)
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	// added before the reader starts, read once the consumer group is created
+	if err := rds.Do(radix.Cmd(nil, "XADD", "cgrates_test_cdrs", "*", "Key", "key1")); err != nil {
+		t.Fatal(err)
+	}
+
+	ng := engine.TestEngine{
+		ConfigJSON: redisStreamsCfg,
+	}
+	client, _ := ng.Run(t)
+	checkNATSExports(t, client, "key1")
+
+	for i := 2; i <= 3; i++ {
+		key := fmt.Sprintf("key%d", i)
+		if err := rds.Do(radix.Cmd(nil, "XADD", "cgrates_test_cdrs", "*", "Key", key)); err != nil {
+			t.Fatal(err)
+		}
+		checkNATSExports(t, client, key)
+	}
+
+	// missing the mandatory Key so it ends up in the dead-letter stream
+	if err := rds.Do(radix.Cmd(nil, "XADD", "cgrates_test_cdrs", "*", "Account", "1001")); err != nil {
+		t.Fatal(err)
+	}
+	var deadLetters []radix.StreamEntry
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) && len(deadLetters) == 0 {
+		time.Sleep(10 * time.Millisecond)
+		if err := rds.Do(radix.Cmd(&deadLetters, "XRANGE",
+			"cgrates_test_cdrs"+utils.RedisStreamsDeadLetterSuffix, "-", "+")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(deadLetters) != 1 {
+		t.Fatalf("Expected 1 dead-letter entry, received %d", len(deadLetters))
+	}
+	if fields := deadLetters[0].Fields; fields["Account"] != "1001" ||
+		fields[utils.RedisStreamsDeadLetterEntryID] == utils.EmptyString ||
+		fields[utils.RedisStreamsDeadLetterError] == utils.EmptyString {
+		t.Errorf("Unexpected dead-letter entry: %v", fields)
+	}
+
+	// all the entries were acknowledged, including the one moved to the dead-letter stream
+	var pending []any
+	if err := rds.Do(radix.Cmd(&pending, "XPENDING", "cgrates_test_cdrs", "cgrates_test")); err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) == 0 || pending[0] != int64(0) {
+		t.Errorf("Expected no pending entries, received %v", pending)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/mediocregopher/radix/v3"
+)
+
+func TestRedisStreamsERsetOpts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().NodeID = "node1"
+	cfg.DataDbCfg().Opts.RedisReadTimeout = 0
+	rdr := &RedisStreamsER{cgrCfg: cfg}
+	if err := rdr.setOpts(&config.EventReaderOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	exp := &RedisStreamsER{
+		cgrCfg:     cfg,
+		stream:     utils.RedisStreamsDefaultStream,
+		group:      utils.RedisStreamsDefaultGroup,
+		consumer:   "cgrates_node1_*default",
+		deadLetter: utils.RedisStreamsDefaultStream + utils.RedisStreamsDeadLetterSuffix,
+		count:      utils.RedisStreamsDefaultCount,
+		block:      redisStreamsBlock,
+	}
+	if !reflect.DeepEqual(exp, rdr) {
+		t.Errorf("Expected %+v, received %+v", exp, rdr)
+	}
+
+	// the blocking read must return before the DataDB read timeout
+	cfg.DataDbCfg().Opts.RedisReadTimeout = 500 * time.Millisecond
+	rdr = &RedisStreamsER{cgrCfg: cfg}
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		RedisStreams: &config.RedisStreamsROpts{
+			Stream:   utils.StringPointer("cdrs"),
+			Group:    utils.StringPointer("billing"),
+			Consumer: utils.StringPointer("billing1"),
+			Count:    utils.IntPointer(50),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exp = &RedisStreamsER{
+		cgrCfg:     cfg,
+		stream:     "cdrs",
+		group:      "billing",
+		consumer:   "billing1",
+		deadLetter: "cdrs" + utils.RedisStreamsDeadLetterSuffix,
+		count:      50,
+		block:      250 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(exp, rdr) {
+		t.Errorf("Expected %+v, received %+v", exp, rdr)
+	}
+
+	rdr = &RedisStreamsER{cgrCfg: cfg}
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		RedisStreams: &config.RedisStreamsROpts{DeadLetter: utils.StringPointer("cdrs_failed")},
+	}); err != nil {
+		t.Fatal(err)
+	} else if rdr.deadLetter != "cdrs_failed" {
+		t.Errorf("Expected %q, received %q", "cdrs_failed", rdr.deadLetter)
+	}
+	if err := rdr.setOpts(&config.EventReaderOpts{
+		RedisStreams: &config.RedisStreamsROpts{Count: utils.IntPointer(0)},
+	}); err == nil || err.Error() != "invalid redisStreamsCount: 0" {
+		t.Errorf("Expected error for invalid count, received %v", err)
+	}
+}
+
+func TestRedisStreamsERServeDisabled(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr, err := NewRedisStreamsER(cfg, 0, make(chan *erEvent, 1), make(chan *erEvent, 1),
+		make(chan error, 1), new(engine.FilterS), make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RunDelay 0 disables the reader, no connection is attempted
+	if err := rdr.Serve(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRedisStreamsERProcessMessage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rdr := &RedisStreamsER{
+		cgrCfg:    cfg,
+		cfgIdx:    0,
+		fltrS:     new(engine.FilterS),
+		rdrEvents: make(chan *erEvent, 1),
+	}
+	rdr.Config().Fields = []*config.FCTemplate{
+		{
+			Tag:   "Usage",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Usage", utils.InfieldSep),
+			Path:  "*cgreq.Usage",
+		},
+	}
+	rdr.Config().Fields[0].ComputePath()
+	defer func() { rdr.Config().Fields = nil }()
+
+	expEvent := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]any{
+			utils.Usage: "10",
+		},
+		APIOpts: map[string]any{},
+	}
+	if err := rdr.processMessage(map[string]string{"Device": "meter1", "Usage": "10"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case data := <-rdr.rdrEvents:
+		expEvent.ID = data.cgrEvent.ID
+		expEvent.Time = data.cgrEvent.Time
+		if !reflect.DeepEqual(data.cgrEvent, expEvent) {
+			t.Errorf("Expected %v but received %v", utils.ToJSON(expEvent), utils.ToJSON(data.cgrEvent))
+		}
+		if data.rawEvent != nil {
+			t.Errorf("Expected no raw event, received %v", data.rawEvent)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Error("Time limit exceeded")
+	}
+}
+
+func TestRedisStreamsDeadLetterArgs(t *testing.T) {
+	entry := radix.StreamEntry{
+		ID:     radix.StreamEntryID{Time: 1700000000000, Seq: 1},
+		Fields: map[string]string{"Account": "1001"},
+	}
+	exp := []string{"cdrs_dead_letter", "*", "Account", "1001",
+		utils.RedisStreamsDeadLetterEntryID, "1700000000000-1",
+		utils.RedisStreamsDeadLetterError, "NOT_FOUND"}
+	if rcv := deadLetterArgs("cdrs_dead_letter", entry, errors.New("NOT_FOUND")); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+}
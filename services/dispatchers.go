@@ -87,6 +87,7 @@ func (dspS *DispatcherService) Start() error {
 	defer dspS.Unlock()
 
 	dspS.dspS = dispatchers.NewDispatcherService(datadb, dspS.cfg, fltrS, dspS.connMgr)
+	dspS.dspS.StartHealthChecks()
 
 	_ = dspS.server.RpcUnregisterName(utils.AttributeSv1)
 
@@ -98,6 +98,7 @@ func (erS *EventReaderService) Start() (err error) {
 
 	// build the service
 	erS.ers = ers.NewERService(erS.cfg, datadb, filterS, erS.connMgr)
+	erS.ers.SetHTTPServer(erS.server)
 	go erS.listenAndServe(erS.ers, erS.stopChan, erS.rldChan)
 
 	// Register ERsV1 methods.
@@ -1380,6 +1380,9 @@ type TPResourceProfile struct {
 	ActivationInterval *TPActivationInterval // Time when this limit becomes active/expires
 	UsageTTL           string
 	Limit              string // Limit value
+	RateInterval       string // Interval refilling Limit units when rate limiting
+	Burst              string // Maximum units available at once when rate limiting
+	ParentID           string // ID of the parent resource consuming the same units
 	AllocationMessage  string
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Stored             bool
@@ -1398,6 +1401,9 @@ func (trp *TPResourceProfile) Clone() *TPResourceProfile {
 		ID:                trp.ID,
 		UsageTTL:          trp.UsageTTL,
 		Limit:             trp.Limit,
+		RateInterval:      trp.RateInterval,
+		Burst:             trp.Burst,
+		ParentID:          trp.ParentID,
 		AllocationMessage: trp.AllocationMessage,
 		Blocker:           trp.Blocker,
 		Stored:            trp.Stored,
@@ -1424,14 +1430,15 @@ func (trp *TPResourceProfile) CacheClone() any {
 
 // TPIPPool is used in TPIPProfile
 type TPIPPool struct {
-	ID        string
-	FilterIDs []string
-	Type      string
-	Range     string
-	Strategy  string
-	Message   string
-	Weight    float64
-	Blocker   bool
+	ID           string
+	FilterIDs    []string
+	Type         string
+	Range        string
+	PrefixLength int
+	Strategy     string
+	Message      string
+	Weight       float64
+	Blocker      bool
 }
 
 // Clone method for TPIPPool
@@ -1440,14 +1447,15 @@ func (p *TPIPPool) Clone() *TPIPPool {
 		return nil
 	}
 	return &TPIPPool{
-		ID:        p.ID,
-		FilterIDs: slices.Clone(p.FilterIDs),
-		Type:      p.Type,
-		Range:     p.Range,
-		Strategy:  p.Strategy,
-		Message:   p.Message,
-		Weight:    p.Weight,
-		Blocker:   p.Blocker,
+		ID:           p.ID,
+		FilterIDs:    slices.Clone(p.FilterIDs),
+		Type:         p.Type,
+		Range:        p.Range,
+		PrefixLength: p.PrefixLength,
+		Strategy:     p.Strategy,
+		Message:      p.Message,
+		Weight:       p.Weight,
+		Blocker:      p.Blocker,
 	}
 }
 
@@ -1596,6 +1604,7 @@ type TPStatProfile struct {
 	ActivationInterval *TPActivationInterval
 	QueueLength        int
 	TTL                string
+	BucketInterval     string
 	Metrics            []*MetricWithFilters
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Stored             bool
@@ -1610,15 +1619,16 @@ func (tsp *TPStatProfile) Clone() *TPStatProfile {
 		return nil
 	}
 	clone := &TPStatProfile{
-		TPid:        tsp.TPid,
-		Tenant:      tsp.Tenant,
-		ID:          tsp.ID,
-		QueueLength: tsp.QueueLength,
-		TTL:         tsp.TTL,
-		Blocker:     tsp.Blocker,
-		Stored:      tsp.Stored,
-		Weight:      tsp.Weight,
-		MinItems:    tsp.MinItems,
+		TPid:           tsp.TPid,
+		Tenant:         tsp.Tenant,
+		ID:             tsp.ID,
+		QueueLength:    tsp.QueueLength,
+		TTL:            tsp.TTL,
+		BucketInterval: tsp.BucketInterval,
+		Blocker:        tsp.Blocker,
+		Stored:         tsp.Stored,
+		Weight:         tsp.Weight,
+		MinItems:       tsp.MinItems,
 	}
 	if tsp.FilterIDs != nil {
 		clone.FilterIDs = make([]string, len(tsp.FilterIDs))
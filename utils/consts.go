@@ -368,6 +368,8 @@ const (
 	MetaSQSjsonMap            = "*sqs_json_map"
 	MetaKafkajsonMap          = "*kafka_json_map"
 	MetaNatsjsonMap           = "*nats_json_map"
+	MetaMQTTjsonMap           = "*mqtt_json_map"
+	MetaRedisStreams          = "*redis_streams"
 	MetaSQL                   = "*sql"
 	MetaMySQL                 = "*mysql"
 	MetaS3jsonMap             = "*s3_json_map"
@@ -416,6 +418,7 @@ const (
 	XML                      = "xml"
 	MetaGOB                  = "*gob"
 	MetaJSON                 = "*json"
+	MetaDiameter             = "*diameter"
 	MetaMSGPACK              = "*msgpack"
 	MetaDateTime             = "*datetime"
 	MetaMaskedDestination    = "*masked_destination"
@@ -436,6 +439,8 @@ const (
 	ServiceManager           = "service_manager"
 	ServiceAlreadyRunning    = "service already running"
 	RunningCaps              = "RUNNING"
+	ErrorCaps                = "ERROR"
+	FilteredCaps             = "FILTERED"
 	StoppedCaps              = "STOPPED"
 	SchedulerNotRunningCaps  = "SCHEDULER_NOT_RUNNING"
 	MetaScheduler            = "*scheduler"
@@ -466,6 +471,7 @@ const (
 	MetaEEs                  = "*ees"
 	MetaERs                  = "*ers"
 	MetaContinue             = "*continue"
+	MetaRelay                = "*relay"
 	Migrator                 = "migrator"
 	UnsupportedMigrationTask = "unsupported migration task"
 	NoStorDBConnection       = "not connected to StorDB"
@@ -476,6 +482,7 @@ const (
 	XMLSuffix                = ".xml"
 	CSVSuffix                = ".csv"
 	FWVSuffix                = ".fwv"
+	ParquetSuffix            = ".parquet"
 	ContentJSON              = "json"
 	ContentForm              = "form"
 	FileLockPrefix           = "file_"
@@ -485,6 +492,7 @@ const (
 	MetaVirt                 = "*virt"
 	MetaElastic              = "*els"
 	MetaFileFWV              = "*file_fwv"
+	MetaFileParquet          = "*file_parquet"
 	MetaFile                 = "*file"
 	Accounts                 = "Accounts"
 	AccountService           = "AccountS"
@@ -515,6 +523,7 @@ const (
 	ID                       = "ID"
 	UniqueID                 = "UniqueID"
 	Address                  = "Address"
+	Prefix                   = "Prefix"
 	Transport                = "Transport"
 	TLS                      = "TLS"
 	Subsystems               = "Subsystems"
@@ -605,6 +614,12 @@ const (
 	UsageTTL             = "UsageTTL"
 	Message              = "Message"
 	AllocationMessage    = "AllocationMessage"
+	RemainingTokens      = "RemainingTokens"
+	ResetTime            = "ResetTime"
+	RetryAfter           = "RetryAfter"
+	RateInterval         = "RateInterval"
+	Burst                = "Burst"
+	ParentID             = "ParentID"
 	Stored               = "Stored"
 	AddressPool          = "AddressPool"
 	Allocation           = "Allocation"
@@ -639,6 +654,11 @@ const (
 	ConnStatusUp   = "UP"
 	ConnStatusDown = "DOWN"
 
+	// Relay counters of the connection, sent with the connection status events.
+	ConnRelayedRequests = "RelayedRequests" // requests relayed towards the peer
+	ConnRelayedAnswers  = "RelayedAnswers"  // answers received from the peer for the relayed requests
+	ConnRelayTimeouts   = "RelayTimeouts"   // relayed requests not answered by the peer in time
+
 	// ReplyState error constants
 	ErrReplyStateAuthorize = "ERR_AUTHORIZE"
 	ErrReplyStateInitiate  = "ERR_INITIATE"
@@ -649,6 +669,7 @@ const (
 	ErrReplyStateCDRs      = "ERR_CDRS"
 	ErrReplyStateExport    = "ERR_EXPORT"
 	ErrReplyStateRadauth   = "ERR_RADAUTH"
+	ErrReplyStateSIPAuth   = "ERR_SIPAUTH"
 
 	AccountSummary           = "AccountSummary"
 	RatingFilters            = "RatingFilters"
@@ -717,6 +738,7 @@ const (
 	CorrelationType          = "CorrelationType"
 	Tolerance                = "Tolerance"
 	TTL                      = "TTL"
+	BucketInterval           = "BucketInterval"
 	MinItems                 = "MinItems"
 	MetricIDs                = "MetricIDs"
 	Metrics                  = "Metrics"
@@ -809,11 +831,16 @@ const (
 	MetaAppID          = "*appid"
 	MetaSessionID      = "*sessionID" // used to retrieve RADIUS Access-Reqest packets of active sessions
 	JanusAdminSubProto = "janus-admin-protocol"
+	SIPWSSubProto      = "sip"
 
 	RemoteHost              = "RemoteHost"
 	Local                   = "local"
 	TCP                     = "tcp"
 	UDP                     = "udp"
+	DoH                     = "doh"
+	TCPTLS                  = "tcp-tls"
+	WS                      = "ws"
+	WSS                     = "wss"
 	VersionName             = "Version"
 	MetaTenant              = "*tenant"
 	ResourceUsage           = "ResourceUsage"
@@ -913,6 +940,8 @@ const (
 	MetaRadDAReq            = "*radDAReq"
 	MetaRadCoATemplate      = "*radCoATemplate"
 	MetaRadDMRTemplate      = "*radDMRTemplate"
+	MetaChannelVars         = "*channelVars"
+	MetaMaxUsage            = "*maxUsage"
 	MetaCost                = "*cost"
 	MetaGroup               = "*group"
 	InternalRPCSet          = "InternalRPCSet"
@@ -925,6 +954,8 @@ const (
 	MetaPAP                 = "*pap"
 	MetaCHAP                = "*chap"
 	MetaMSCHAPV2            = "*mschapv2"
+	MetaSIPAuth             = "*sipauth"
+	SIPAuthFailed           = "SIPAUTH_FAILED"
 	MetaDynaprepaid         = "*dynaprepaid"
 	MetaFD                  = "*fd"
 	SortingData             = "SortingData"
@@ -933,6 +964,7 @@ const (
 	PoolFilterIDs           = "PoolFilterIDs"
 	PoolType                = "PoolType"
 	PoolRange               = "PoolRange"
+	PoolPrefixLength        = "PoolPrefixLength"
 	PoolStrategy            = "PoolStrategy"
 	PoolMessage             = "PoolMessage"
 	PoolWeight              = "PoolWeight"
@@ -955,6 +987,7 @@ const (
 	HashtagSep              = "#"
 	MetaRounding            = "*rounding"
 	StatsNA                 = -1.0
+	PlusInf                 = "+Inf" // upper bound of the last histogram bucket
 	InvalidUsage            = -1
 	InvalidDuration         = time.Duration(-1)
 	Schedule                = "Schedule"
@@ -1052,6 +1085,12 @@ const (
 	DNSUri                = "Uri"
 	DNSHdr                = "Hdr"
 	DNSA                  = "A"
+	DNSAAAA               = "AAAA"
+	DNSTxt                = "Txt"
+	DNSPtr                = "Ptr"
+	DNSMx                 = "Mx"
+	DoHPath               = "/dns-query"
+	DNSMessageContentType = "application/dns-message"
 	DNSTarget             = "Target"
 	DNSPriority           = "Priority"
 	DNSPort               = "Port"
@@ -1168,26 +1207,33 @@ const (
 	MetaNodeID              = "*node_id"
 	MetaAscending           = "*ascending"
 	MetaDescending          = "*descending"
+	MetaLeastRecentlyUsed   = "*least_recently_used"
 	MetaDesc                = "*desc"
 	MetaAsc                 = "*asc"
 )
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaREPSC    = "*repsc"
-	MetaREPFC    = "*repfc"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaHighest  = "*highest"
-	MetaLowest   = "*lowest"
+	MetaASR        = "*asr"
+	MetaACD        = "*acd"
+	MetaTCD        = "*tcd"
+	MetaACC        = "*acc"
+	MetaTCC        = "*tcc"
+	MetaPDD        = "*pdd"
+	MetaDDC        = "*ddc"
+	MetaSum        = "*sum"
+	MetaREPSC      = "*repsc"
+	MetaREPFC      = "*repfc"
+	MetaAverage    = "*average"
+	MetaDistinct   = "*distinct"
+	MetaHighest    = "*highest"
+	MetaLowest     = "*lowest"
+	MetaPercentile = "*percentile"
+	MetaP50        = "*p50"
+	MetaP95        = "*p95"
+	MetaP99        = "*p99"
+	MetaHistogram  = "*histogram"
+	MetaAnomaly    = "*anomaly"
 )
 
 // Diameter/Radius request types
@@ -1386,6 +1432,14 @@ const (
 	MetaFirst          = "*first"
 	MetaRandom         = "*random"
 	MetaRoundRobin     = "*round_robin"
+	MetaLeastLoad      = "*least_load"
+	MetaLeastLatency   = "*least_latency"
+	MetaHash           = "*hash"
+	MetaHashKey        = "*hash_key"
+	MetaVirtualNodes   = "*virtual_nodes"
+	MetaUp             = "*up"
+	MetaDown           = "*down"
+	MetaHalfOpen       = "*half_open"
 	MetaRatio          = "*ratio"
 	MetaDefaultRatio   = "*default_ratio"
 	ThresholdSv1       = "ThresholdSv1"
@@ -2033,6 +2087,7 @@ const (
 	DispatcherSv1RemoteStatus        = "DispatcherSv1.RemoteStatus"
 	DispatcherSv1RemoteSleep         = "DispatcherSv1.RemoteSleep"
 	DispatcherSv1RemotePing          = "DispatcherSv1.RemotePing"
+	DispatcherSv1GetHostsHealth      = "DispatcherSv1.GetHostsHealth"
 )
 
 // RegistrarS APIs
@@ -2525,6 +2580,7 @@ const (
 	ChargerSConnsCfg       = "chargers_conns"
 	AttributeSConnsCfg     = "attributes_conns"
 	RetransmissionTimerCfg = "retransmission_timer"
+	MethodProcessorsCfg    = "method_processors"
 	OnlineCDRExportsCfg    = "online_cdr_exports"
 	SessionCostRetires     = "session_cost_retries"
 	RateSConnsCfg          = "rates_conns"
@@ -2622,6 +2678,17 @@ const (
 	ConnStatusStatQueueIDsCfg  = "conn_status_stat_queue_ids"
 	ConnStatusThresholdIDsCfg  = "conn_status_threshold_ids"
 	ConnHealthCheckIntervalCfg = "conn_health_check_interval"
+	OCSClientCfg               = "ocs_client"
+	CCRTemplateCfg             = "ccr_template"
+	CCATemplateCfg             = "cca_template"
+	WatchdogIntervalCfg        = "watchdog_interval"
+	TxTimerCfg                 = "tx_timer"
+	TccTimerCfg                = "tcc_timer"
+	RelayWatchdogIntervalCfg   = "relay_watchdog_interval"
+	RealmRoutesCfg             = "realm_routes"
+	RealmCfg                   = "realm"
+	ApplicationIDsCfg          = "application_ids"
+	PeersCfg                   = "peers"
 	TemplatesCfg               = "templates"
 	RequestProcessorsCfg       = "request_processors"
 
@@ -2641,6 +2708,8 @@ const (
 	CoATemplateCfg        = "coa_template"
 	HostCfg               = "host"
 	PortCfg               = "port"
+	EAPServerCfg          = "eap_server"
+	SecretCfg             = "secret"
 
 	// PrometheusAgentCfg
 	CoreSConnsCfg            = "cores_conns"
@@ -2743,8 +2812,12 @@ const (
 	MaxUsage      = "max_usage"
 
 	// DispatcherSCfg
-	AnySubsystemCfg = "any_subsystem"
-	PreventLoopCfg  = "prevent_loop"
+	AnySubsystemCfg           = "any_subsystem"
+	PreventLoopCfg            = "prevent_loop"
+	HealthCheckIntervalCfg    = "health_check_interval"
+	HealthCheckMethodCfg      = "health_check_method"
+	CircuitBreakerFailuresCfg = "circuit_breaker_failures"
+	CircuitBreakerCooldownCfg = "circuit_breaker_cooldown"
 )
 
 // FC Template
@@ -3163,6 +3236,67 @@ const (
 	NatsJetStream            = "natsJetStream"
 	NatsJetStreamMaxWait     = "natsJetStreamMaxWait"
 
+	// mqtt
+	MQTTDefaultTopic = "cgrates/cdrs"
+	MQTTDefaultQoS   = 1
+
+	MQTTTopic             = "mqttTopic"
+	MQTTQoS               = "mqttQoS"
+	MQTTClientID          = "mqttClientID"
+	MQTTCleanSession      = "mqttCleanSession"
+	MQTTUsername          = "mqttUsername"
+	MQTTPassword          = "mqttPassword"
+	MQTTTLS               = "mqttTLS"
+	MQTTCAPath            = "mqttCAPath"
+	MQTTClientCertificate = "mqttClientCertificate"
+	MQTTClientKey         = "mqttClientKey"
+	MQTTSkipTLSVerify     = "mqttSkipTLSVerify"
+	MQTTRetain            = "mqttRetain"
+
+	// redis streams
+	RedisStreamsDefaultStream     = "cgrates_cdrs"
+	RedisStreamsDefaultGroup      = "cgrates"
+	RedisStreamsDefaultCount      = 10
+	RedisStreamsDeadLetterSuffix  = "_dead_letter"
+	RedisStreamsDeadLetterError   = "cgr_error"
+	RedisStreamsDeadLetterEntryID = "cgr_entry_id"
+
+	RedisStreamsStream     = "redisStreamsStream"
+	RedisStreamsGroup      = "redisStreamsGroup"
+	RedisStreamsConsumer   = "redisStreamsConsumer"
+	RedisStreamsDeadLetter = "redisStreamsDeadLetter"
+	RedisStreamsCount      = "redisStreamsCount"
+	RedisStreamsMaxLen     = "redisStreamsMaxLen"
+
+	// http reader
+	HTTPDefaultHMACHeader  = "X-Signature"
+	HTTPDefaultMaxBodySize = 1 << 20
+
+	HTTPUsername    = "httpUsername"
+	HTTPPassword    = "httpPassword"
+	HTTPHMACSecret  = "httpHMACSecret"
+	HTTPHMACHeader  = "httpHMACHeader"
+	HTTPMaxBodySize = "httpMaxBodySize"
+
+	// parquet
+	ParquetDefaultDecimalScale = 6
+
+	MetaInt64     = "*int64"
+	MetaBool      = "*bool"
+	MetaTimestamp = "*timestamp"
+	MetaDecimal   = "*decimal"
+	MetaSnappy    = "*snappy"
+	MetaGzip      = "*gzip"
+	MetaZstd      = "*zstd"
+
+	ParquetColumnTypes    = "parquetColumnTypes"
+	ParquetDecimalScale   = "parquetDecimalScale"
+	ParquetCompression    = "parquetCompression"
+	ParquetMaxRecords     = "parquetMaxRecords"
+	ParquetMaxFileSize    = "parquetMaxFileSize"
+	ParquetRotateInterval = "parquetRotateInterval"
+	ParquetS3Endpoint     = "parquetS3Endpoint"
+
 	// rpc
 	RpcCodec        = "rpcCodec"
 	ServiceMethod   = "serviceMethod"
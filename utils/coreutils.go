@@ -906,6 +906,14 @@ func CastRPCErr(err error) error {
 		if _, has := ErrMap[err.Error()]; has {
 			return ErrMap[err.Error()]
 		}
+		if errTxt, retryAfter, has := strings.Cut(err.Error(),
+			InfieldSep+RetryAfter+InInFieldSep); has { // ErrRetryAfter
+			if sentinel, has := ErrMap[errTxt]; has {
+				if wait, pErr := time.ParseDuration(retryAfter); pErr == nil {
+					return NewErrRetryAfter(sentinel, wait)
+				}
+			}
+		}
 	}
 	return err
 }
@@ -1564,6 +1564,13 @@ func TestCastRPCErr(t *testing.T) {
 	if rcv := CastRPCErr(ErrNoMoreData); rcv.Error() != ErrNoMoreData.Error() {
 		t.Errorf("Expecting: %+v, received %+v", ErrNoMoreData.Error(), rcv)
 	}
+	rcv := CastRPCErr(errors.New("RESOURCE_UNAVAILABLE;RetryAfter:2s"))
+	if rlErr, ok := rcv.(*ErrRetryAfter); !ok || rlErr.Err != ErrResourceUnavailable ||
+		rlErr.RetryAfter != 2*time.Second {
+		t.Errorf("Unexpected error: %#v", rcv)
+	} else if !errors.Is(rcv, ErrResourceUnavailable) {
+		t.Errorf("Expecting %v to wrap %v", rcv, ErrResourceUnavailable)
+	}
 }
 
 func TestRandomInteger(t *testing.T) {
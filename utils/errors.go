@@ -21,7 +21,9 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 )
 
 var (
@@ -174,6 +176,27 @@ func NewErrMandatoryIeMissing(fields ...string) error {
 	return fmt.Errorf("MANDATORY_IE_MISSING: %v", fields)
 }
 
+// ErrRetryAfter is the error of an operation which can succeed after waiting RetryAfter,
+// keeping the original error text as prefix so it can be cast back after RPC
+type ErrRetryAfter struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// NewErrRetryAfter returns err carrying the time to wait before retrying
+func NewErrRetryAfter(err error, retryAfter time.Duration) error {
+	return &ErrRetryAfter{Err: err, RetryAfter: retryAfter}
+}
+
+func (e *ErrRetryAfter) Error() string {
+	retryAfter := time.Duration(math.Ceil(e.RetryAfter.Seconds())) * time.Second
+	return e.Err.Error() + InfieldSep + RetryAfter + InInFieldSep + retryAfter.String()
+}
+
+func (e *ErrRetryAfter) Unwrap() error {
+	return e.Err
+}
+
 func NewErrServerError(err error) error {
 	return fmt.Errorf("SERVER_ERROR: %s", err)
 }
@@ -19,11 +19,15 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>
 package utils
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -140,3 +144,44 @@ type serverResponse struct {
 	Result any              `json:"result"`
 	Error  any              `json:"error"`
 }
+
+// LoadTLSConfig builds the server side TLS configuration out of the certificate, the
+// optional CA used to verify the clients, the client auth policy and the server name
+func LoadTLSConfig(serverCrt, serverKey, caCert string, serverPolicy int,
+	serverName string) (config *tls.Config, err error) {
+	cert, err := tls.LoadX509KeyPair(serverCrt, serverKey)
+	if err != nil {
+		Logger.Crit(fmt.Sprintf("Error: %s when load server keys", err))
+		return nil, err
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	//This will only happen on windows
+	if err != nil {
+		Logger.Crit(fmt.Sprintf("Error: %s when load SystemCertPool", err))
+		return nil, err
+	}
+
+	if caCert != "" {
+		ca, err := os.ReadFile(caCert)
+		if err != nil {
+			Logger.Crit(fmt.Sprintf("Error: %s when read CA", err))
+			return config, err
+		}
+
+		if ok := rootCAs.AppendCertsFromPEM(ca); !ok {
+			Logger.Crit("Cannot append certificate authority")
+			return config, errors.New("Cannot append certificate authority")
+		}
+	}
+
+	config = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.ClientAuthType(serverPolicy),
+		ClientCAs:    rootCAs,
+	}
+	if serverName != "" {
+		config.ServerName = serverName
+	}
+	return
+}